@@ -27,6 +27,8 @@ import (
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	"github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 const (
@@ -122,6 +124,14 @@ func setSidecarSetUpdateStrategy(strategy *v1alpha1.SidecarSetUpdateStrategy) {
 		partition := intstr.FromInt(0)
 		strategy.Partition = &partition
 	}
+	if strategy.FailurePolicy != nil {
+		if strategy.FailurePolicy.Type == "" {
+			strategy.FailurePolicy.Type = v1alpha1.RollbackSidecarSetUpdateFailurePolicyType
+		}
+		if strategy.FailurePolicy.UnreadyThresholdSeconds == nil {
+			strategy.FailurePolicy.UnreadyThresholdSeconds = ptr.To(int32(300))
+		}
+	}
 }
 
 func setDefaultContainer(sidecarContainer *v1alpha1.SidecarContainer) {
@@ -263,6 +273,12 @@ func SetDefaultsCloneSet(obj *v1alpha1.CloneSet, injectTemplateDefaults bool) {
 		maxSurge := intstr.FromInt(0)
 		obj.Spec.UpdateStrategy.MaxSurge = &maxSurge
 	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetAutoResizePVCGate) {
+		if obj.Spec.VolumeClaimUpdateStrategy.Type == "" {
+			obj.Spec.VolumeClaimUpdateStrategy.Type = v1alpha1.OnPVCDeleteCloneSetVolumeClaimUpdateStrategyType
+		}
+	}
 }
 
 // SetDefaults_DaemonSet set default values for DaemonSet.
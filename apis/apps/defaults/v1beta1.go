@@ -76,6 +76,14 @@ func SetDefaultsStatefulSet(obj *v1beta1.StatefulSet, injectTemplateDefaults boo
 			obj.Spec.VolumeClaimUpdateStrategy.Type = v1beta1.OnPVCDeleteVolumeClaimUpdateStrategyType
 		}
 	}
+	if migration := obj.Spec.VolumeClaimUpdateStrategy.DataMigration; migration != nil {
+		if migration.BackoffLimit == nil {
+			migration.BackoffLimit = ptr.To(int32(3))
+		}
+		if migration.OldVolumeClaimPolicy == "" {
+			migration.OldVolumeClaimPolicy = v1beta1.RetainOldVolumeClaimPolicyType
+		}
+	}
 
 	if obj.Spec.Replicas == nil {
 		obj.Spec.Replicas = ptr.To(int32(1))
@@ -114,6 +122,17 @@ func SetDefaultsBroadcastJob(obj *v1beta1.BroadcastJob, injectTemplateDefaults b
 	if obj.Spec.FailurePolicy.Type == "" {
 		obj.Spec.FailurePolicy.Type = v1beta1.FailurePolicyTypeFailFast
 	}
+	if obj.Spec.FailurePolicy.BackoffLimitPerNode != nil && obj.Spec.FailurePolicy.BackoffSeconds == nil {
+		obj.Spec.FailurePolicy.BackoffSeconds = ptr.To(int32(10))
+	}
+
+	if obj.Spec.NodeResults != nil && obj.Spec.NodeResults.Type == "" {
+		obj.Spec.NodeResults.Type = v1beta1.BroadcastJobNodeResultsStatus
+	}
+
+	if obj.Spec.RollingStrategy != nil && obj.Spec.RollingStrategy.MinSuccessPercent == nil {
+		obj.Spec.RollingStrategy.MinSuccessPercent = ptr.To(int32(100))
+	}
 }
 
 // SetDefaultsAdvancedCronJob set default values for AdvancedCronJob.
@@ -162,6 +181,14 @@ func SetDefaultsAdvancedCronJob(obj *v1beta1.AdvancedCronJob, injectTemplateDefa
 		obj.Spec.FailedJobsHistoryLimit = new(int32)
 		*obj.Spec.FailedJobsHistoryLimit = 1
 	}
+	if obj.Spec.MissedSchedulePolicy != nil {
+		if obj.Spec.MissedSchedulePolicy.Type == "" {
+			obj.Spec.MissedSchedulePolicy.Type = v1beta1.MissedSchedulePolicyRunOnce
+		}
+		if obj.Spec.MissedSchedulePolicy.Type == v1beta1.MissedSchedulePolicyBackfill && obj.Spec.MissedSchedulePolicy.MaxBackfill == nil {
+			obj.Spec.MissedSchedulePolicy.MaxBackfill = ptr.To(int32(1))
+		}
+	}
 }
 
 // SetDefaultsImagePullJobV1beta1 sets default values for v1beta1 ImagePullJob.
@@ -252,6 +279,10 @@ func SetDefaultsDaemonSetV1beta1(obj *v1beta1.DaemonSet) {
 			MaxSurge := intstr.FromInt(0)
 			obj.Spec.UpdateStrategy.RollingUpdate.MaxSurge = &MaxSurge
 		}
+
+		if obj.Spec.UpdateStrategy.RollingUpdate.UnschedulableNodes != nil && obj.Spec.UpdateStrategy.RollingUpdate.UnschedulableNodes.Policy == "" {
+			obj.Spec.UpdateStrategy.RollingUpdate.UnschedulableNodes.Policy = v1beta1.DeprioritizeUnschedulableNodesPolicyType
+		}
 	}
 
 	if obj.Spec.RevisionHistoryLimit == nil {
@@ -398,4 +429,12 @@ func setSidecarSetUpdateStrategyV1beta1(strategy *v1beta1.SidecarSetUpdateStrate
 	if strategy.Partition == nil {
 		strategy.Partition = &intstr.IntOrString{Type: intstr.Int, IntVal: 0}
 	}
+	if strategy.FailurePolicy != nil {
+		if strategy.FailurePolicy.Type == "" {
+			strategy.FailurePolicy.Type = v1beta1.RollbackSidecarSetUpdateFailurePolicyType
+		}
+		if strategy.FailurePolicy.UnreadyThresholdSeconds == nil {
+			strategy.FailurePolicy.UnreadyThresholdSeconds = ptr.To(int32(300))
+		}
+	}
 }
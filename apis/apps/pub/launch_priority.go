@@ -33,4 +33,20 @@ const (
 	// ContainerLaunchPriorityCompletedKey is the annotation indicates the pod has all its priorities
 	// patched into its barrier configmap.
 	ContainerLaunchPriorityCompletedKey = "apps.kruise.io/container-launch-priority-completed"
+
+	// ContainerLaunchDependenciesEnvName is the env name that users could define in pod container
+	// to declare the containers it depends on, in the format of "name[:condition],...", e.g. "sidecar-a,sidecar-b:Started".
+	// The container will not be launched until all its dependencies meet the conditions, which default to Ready.
+	// It can not be used together with launch priority.
+	ContainerLaunchDependenciesEnvName = "KRUISE_CONTAINER_DEPENDS_ON"
+)
+
+// ContainerLaunchConditionType is the condition of a container that its dependents wait for.
+type ContainerLaunchConditionType string
+
+const (
+	// ContainerLaunchConditionStarted means the container has started and passed its startup probe.
+	ContainerLaunchConditionStarted ContainerLaunchConditionType = "Started"
+	// ContainerLaunchConditionReady means the container is ready.
+	ContainerLaunchConditionReady ContainerLaunchConditionType = "Ready"
 )
@@ -16,9 +16,13 @@ limitations under the License.
 
 package pub
 
+import v1 "k8s.io/api/core/v1"
+
 const (
 	LifecycleStateKey     = "lifecycle.apps.kruise.io/state"
 	LifecycleTimestampKey = "lifecycle.apps.kruise.io/timestamp"
+	// LifecycleHTTPHookStatusKey records the result of the HTTPHandler called for the current lifecycle state.
+	LifecycleHTTPHookStatusKey = "lifecycle.apps.kruise.io/http-hook-status"
 
 	// LifecycleStatePreparingNormal means the Pod is created but unavailable.
 	// It will translate to Normal state if Lifecycle.PreNormal is hooked.
@@ -37,6 +41,10 @@ const (
 	// LifecycleStatePreparingDelete means the Pod is prepared to delete.
 	// The Pod will be deleted by workload if Lifecycle.PreDelete is Not hooked.
 	LifecycleStatePreparingDelete LifecycleStateType = "PreparingDelete"
+
+	// LifecycleHookTimedOut is the Pod condition set when Pod has stayed in the state of a hook longer than its timeoutSeconds.
+	// The reason of the condition is the lifecycle state that timed out.
+	LifecycleHookTimedOut v1.PodConditionType = "LifecycleHookTimedOut"
 )
 
 type LifecycleStateType string
@@ -60,4 +68,74 @@ type LifecycleHook struct {
 	// Currently, MarkPodNotReady only takes effect on InPlaceUpdate & PreDelete hook.
 	// Default to false.
 	MarkPodNotReady bool `json:"markPodNotReady,omitempty"`
+	// HTTPHandler is an endpoint called by the workload controller when Pod enters the state of this hook.
+	// The hook is considered done when the endpoint returns a 2xx response.
+	// +optional
+	HTTPHandler *LifecycleHTTPHandler `json:"httpHandler,omitempty"`
+	// TimeoutSeconds is the max duration that Pod can stay in the state of this hook,
+	// counted from the time in lifecycle.apps.kruise.io/timestamp. No timeout if not set.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// OnTimeout is the action when Pod has stayed in the state of this hook longer than TimeoutSeconds.
+	// Defaults to Block.
+	// +optional
+	OnTimeout LifecycleHookTimeoutActionType `json:"onTimeout,omitempty"`
+}
+
+type LifecycleHookTimeoutActionType string
+
+const (
+	// LifecycleHookTimeoutProceed means the hook is considered done once it times out.
+	LifecycleHookTimeoutProceed LifecycleHookTimeoutActionType = "Proceed"
+	// LifecycleHookTimeoutBlock means Pod keeps waiting in the state of the hook.
+	LifecycleHookTimeoutBlock LifecycleHookTimeoutActionType = "Block"
+	// LifecycleHookTimeoutMarkFailed means Pod keeps waiting in the state of the hook,
+	// and it is listed in the LifecycleHookFailed condition of the workload.
+	LifecycleHookTimeoutMarkFailed LifecycleHookTimeoutActionType = "MarkFailed"
+)
+
+type LifecycleHookFailurePolicyType string
+
+const (
+	// LifecycleHookFailurePolicyProceed means the hook is considered done after the retries are exhausted.
+	LifecycleHookFailurePolicyProceed LifecycleHookFailurePolicyType = "Proceed"
+	// LifecycleHookFailurePolicyBlock means Pod keeps waiting in the hook state and the endpoint keeps being retried.
+	LifecycleHookFailurePolicyBlock LifecycleHookFailurePolicyType = "Block"
+)
+
+// LifecycleHTTPHandler is an HTTP endpoint for lifecycle hook.
+// The controller POSTs a JSON body with the namespace, name, uid and lifecycle state of the Pod to the endpoint
+// in background, and records the result in the lifecycle.apps.kruise.io/http-hook-status annotation.
+type LifecycleHTTPHandler struct {
+	// URL of the endpoint, which must be a http or https URL with the host allowed by
+	// the --http-hook-allowed-hosts flag of kruise-manager.
+	URL string `json:"url"`
+	// TimeoutSeconds is the timeout of each call. Defaults to 10, and max to 30.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// RetryLimit is the number of retries after the first failed call, before FailurePolicy takes effect.
+	// Retries are done with exponential backoff. Defaults to 3.
+	// +optional
+	RetryLimit *int32 `json:"retryLimit,omitempty"`
+	// FailurePolicy defines what to do when the endpoint still fails after retries, Proceed or Block.
+	// Defaults to Block.
+	// +optional
+	FailurePolicy LifecycleHookFailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// LifecycleHTTPHookStatus is the result of the HTTPHandler for a lifecycle state of Pod,
+// which is recorded in the annotation of LifecycleHTTPHookStatusKey.
+type LifecycleHTTPHookStatus struct {
+	// State is the lifecycle state that the handler is called for.
+	State LifecycleStateType `json:"state"`
+	// Timestamp is the value of LifecycleTimestampKey when Pod entered the state.
+	Timestamp string `json:"timestamp,omitempty"`
+	// Completed means the hook is done, either the endpoint succeeded or failed with Proceed policy.
+	Completed bool `json:"completed,omitempty"`
+	// Failures is the number of failed calls.
+	Failures int32 `json:"failures,omitempty"`
+	// LastCallTime is the time of the last call.
+	LastCallTime string `json:"lastCallTime,omitempty"`
+	// Message is the error of the last failed call.
+	Message string `json:"message,omitempty"`
 }
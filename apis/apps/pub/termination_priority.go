@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ContainerTerminationPriorityKey is the annotation key that users could define in pod annotation
+	// to make containers in pod terminated by priority when the pod is deleted, e.g. {"app":10,"istio-proxy":-10}.
+	// Containers with higher priority are stopped first, and the containers not listed have priority 0.
+	// Kruise-daemon stops containers with lower priority only after all containers with higher priority have exited,
+	// so the containers with lower priority should hold on in their preStop hooks.
+	ContainerTerminationPriorityKey = "apps.kruise.io/container-termination-priority"
+	// ContainerTerminationStepTimeoutKey is the annotation key that users could define in pod annotation
+	// to limit the seconds each priority waits for the higher ones, defaults to 30.
+	ContainerTerminationStepTimeoutKey = "apps.kruise.io/container-termination-step-timeout-seconds"
+
+	// DefaultContainerTerminationStepTimeoutSeconds is the default seconds each priority waits for the higher ones.
+	DefaultContainerTerminationStepTimeoutSeconds = 30
+)
+
+// GetContainerTerminationPriorities returns the termination priorities of containers defined in pod annotation.
+func GetContainerTerminationPriorities(pod *v1.Pod) (map[string]int, error) {
+	str, ok := pod.Annotations[ContainerTerminationPriorityKey]
+	if !ok {
+		return nil, nil
+	}
+	priorities := make(map[string]int)
+	if err := json.Unmarshal([]byte(str), &priorities); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", ContainerTerminationPriorityKey, err)
+	}
+	return priorities, nil
+}
+
+// GetContainerTerminationStepTimeoutSeconds returns the seconds each priority waits for the higher ones.
+func GetContainerTerminationStepTimeoutSeconds(pod *v1.Pod) (int64, error) {
+	str, ok := pod.Annotations[ContainerTerminationStepTimeoutKey]
+	if !ok {
+		return DefaultContainerTerminationStepTimeoutSeconds, nil
+	}
+	seconds, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, must be a positive integer", ContainerTerminationStepTimeoutKey, str)
+	}
+	return seconds, nil
+}
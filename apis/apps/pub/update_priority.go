@@ -18,12 +18,14 @@ package pub
 
 import (
 	"fmt"
+	"net/url"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // UpdatePriorityStrategy is the strategy to define priority for pods update.
 // Only one of orderPriority and weightPriority can be set.
+// Extender can be used with either of them, and the order computed by extender comes first.
 type UpdatePriorityStrategy struct {
 	// Order priority terms, pods will be sorted by the value of orderedKey.
 	// For example:
@@ -38,6 +40,36 @@ type UpdatePriorityStrategy struct {
 	OrderPriority []UpdatePriorityOrderTerm `json:"orderPriority,omitempty"`
 	// Weight priority terms, pods will be sorted by the sum of all terms weight.
 	WeightPriority []UpdatePriorityWeightTerm `json:"weightPriority,omitempty"`
+	// Extender computes the update order from the live state of pods.
+	// +optional
+	Extender *UpdatePriorityExtender `json:"extender,omitempty"`
+}
+
+// UpdatePriorityExtender defines how to compute the update order from the live state of pods.
+// Only one of httpHandler and podConditionType can be set.
+type UpdatePriorityExtender struct {
+	// HTTPHandler is an endpoint to rank the pods waiting for update.
+	// +optional
+	HTTPHandler *UpdatePriorityHTTPHandler `json:"httpHandler,omitempty"`
+	// PodConditionType is a pod condition type, usually set by a PodProbeMarker probe such as the leader check.
+	// Pods with this condition True are updated after the others.
+	// +optional
+	PodConditionType string `json:"podConditionType,omitempty"`
+}
+
+// UpdatePriorityHTTPHandler is an HTTP endpoint to rank pods for update.
+// The controller POSTs a JSON body with the namespace and name of pods waiting for update to the endpoint,
+// and the endpoint responds with the names of pods in update order. Pods not in the response are updated
+// after the ranked ones. The ranking is reused until any of the pods waiting for update is changed to another
+// revision or recreated, or for at most 5 minutes.
+// If the endpoint fails or does not respond in time, the order without extender is used.
+type UpdatePriorityHTTPHandler struct {
+	// URL of the endpoint, which must be a http or https URL with the host allowed by
+	// the --http-hook-allowed-hosts flag of kruise-manager.
+	URL string `json:"url"`
+	// TimeoutSeconds is the timeout of each call. Defaults to 1, and max to 10.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // UpdatePriorityOrderTerm defines order priority.
@@ -84,5 +116,28 @@ func (strategy *UpdatePriorityStrategy) FieldsValidation() error {
 		}
 	}
 
+	return strategy.Extender.fieldsValidation()
+}
+
+func (extender *UpdatePriorityExtender) fieldsValidation() error {
+	if extender == nil {
+		return nil
+	}
+
+	if (extender.HTTPHandler == nil) == (extender.PodConditionType == "") {
+		return fmt.Errorf("exactly one of httpHandler and podConditionType must be set in extender")
+	}
+
+	if handler := extender.HTTPHandler; handler != nil {
+		if u, err := url.Parse(handler.URL); err != nil {
+			return fmt.Errorf("invalid extender url %v", err)
+		} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("extender url must be an absolute http or https URL")
+		}
+		if handler.TimeoutSeconds != nil && (*handler.TimeoutSeconds <= 0 || *handler.TimeoutSeconds > 10) {
+			return fmt.Errorf("extender timeoutSeconds must be in range (0, 10]")
+		}
+	}
+
 	return nil
 }
@@ -182,6 +182,46 @@ func (in *Lifecycle) DeepCopy() *Lifecycle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleHTTPHandler) DeepCopyInto(out *LifecycleHTTPHandler) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryLimit != nil {
+		in, out := &in.RetryLimit, &out.RetryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHTTPHandler.
+func (in *LifecycleHTTPHandler) DeepCopy() *LifecycleHTTPHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleHTTPHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleHTTPHookStatus) DeepCopyInto(out *LifecycleHTTPHookStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHTTPHookStatus.
+func (in *LifecycleHTTPHookStatus) DeepCopy() *LifecycleHTTPHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleHTTPHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LifecycleHook) DeepCopyInto(out *LifecycleHook) {
 	*out = *in
@@ -197,6 +237,16 @@ func (in *LifecycleHook) DeepCopyInto(out *LifecycleHook) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HTTPHandler != nil {
+		in, out := &in.HTTPHandler, &out.HTTPHandler
+		*out = new(LifecycleHTTPHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHook.
@@ -260,6 +310,46 @@ func (in *RuntimeContainerMetaSet) DeepCopy() *RuntimeContainerMetaSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePriorityExtender) DeepCopyInto(out *UpdatePriorityExtender) {
+	*out = *in
+	if in.HTTPHandler != nil {
+		in, out := &in.HTTPHandler, &out.HTTPHandler
+		*out = new(UpdatePriorityHTTPHandler)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePriorityExtender.
+func (in *UpdatePriorityExtender) DeepCopy() *UpdatePriorityExtender {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePriorityExtender)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePriorityHTTPHandler) DeepCopyInto(out *UpdatePriorityHTTPHandler) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePriorityHTTPHandler.
+func (in *UpdatePriorityHTTPHandler) DeepCopy() *UpdatePriorityHTTPHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePriorityHTTPHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdatePriorityOrderTerm) DeepCopyInto(out *UpdatePriorityOrderTerm) {
 	*out = *in
@@ -290,6 +380,11 @@ func (in *UpdatePriorityStrategy) DeepCopyInto(out *UpdatePriorityStrategy) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Extender != nil {
+		in, out := &in.Extender, &out.Extender
+		*out = new(UpdatePriorityExtender)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePriorityStrategy.
@@ -61,6 +61,11 @@ type CloneSetSpec struct {
 	// +kubebuilder:validation:Schemaless
 	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
 
+	// VolumeClaimUpdateStrategy specifies the strategy for updating existing PVCs when VolumeClaimTemplates changed.
+	// This field is currently only effective if the CloneSetAutoResizePVCGate is enabled.
+	// +optional
+	VolumeClaimUpdateStrategy CloneSetVolumeClaimUpdateStrategy `json:"volumeClaimUpdateStrategy,omitempty"`
+
 	// ScaleStrategy indicates the ScaleStrategy that will be employed to
 	// create and delete Pods in the CloneSet.
 	ScaleStrategy CloneSetScaleStrategy `json:"scaleStrategy,omitempty"`
@@ -104,6 +109,25 @@ type CloneSetScaleStrategy struct {
 	// Indicate if cloneSet will reuse already existed pvc to
 	// rebuild a new pod
 	DisablePVCReuse bool `json:"disablePVCReuse,omitempty"`
+
+	// ScaleInRanker is an external endpoint to rank the candidate pods when scaling in.
+	// If the endpoint fails or does not respond in time, the built-in sorter is used.
+	// +optional
+	ScaleInRanker *CloneSetScaleInRanker `json:"scaleInRanker,omitempty"`
+}
+
+// CloneSetScaleInRanker is an HTTP endpoint to choose the pods to delete when scaling in.
+// Unscheduled and not ready pods are always deleted first. For the rest, the controller POSTs a JSON body
+// with the name, node and revision of the scheduled and ready candidate pods to the endpoint, and the endpoint
+// responds with the names of pods in the order to be deleted, the first one to be deleted first.
+// Pods not in the response are deleted after the ranked ones, in the order of built-in sorter.
+type CloneSetScaleInRanker struct {
+	// URL of the endpoint, which must be a http or https URL with the host allowed by
+	// the --http-hook-allowed-hosts flag of kruise-manager.
+	URL string `json:"url"`
+	// TimeoutSeconds is the timeout of each call. Defaults to 1, and max to 10.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // CloneSetUpdateStrategy defines strategies for pods update.
@@ -213,6 +237,39 @@ type CloneSetStatus struct {
 
 	// LabelSelector is label selectors for query over pods that should match the replica count used by HPA.
 	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// VolumeClaims represents the status of compatibility between existing PVCs and their templates.
+	// It is only calculated if the CloneSetAutoResizePVCGate is enabled.
+	VolumeClaims []CloneSetVolumeClaimStatus `json:"volumeClaims,omitempty"`
+}
+
+// CloneSetVolumeClaimUpdateStrategyType defines the update strategy types for volume claims of CloneSet.
+// +enum
+type CloneSetVolumeClaimUpdateStrategyType string
+
+const (
+	// OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType indicates that existing PVCs are expanded in-place
+	// when the storage requests in volumeClaimTemplates grow, at most maxUnavailable pods at a time.
+	OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType CloneSetVolumeClaimUpdateStrategyType = "OnPodRollingUpdate"
+	// OnPVCDeleteCloneSetVolumeClaimUpdateStrategyType indicates that existing PVCs are not changed,
+	// and the new templates only work for PVCs created later. It is the default type.
+	OnPVCDeleteCloneSetVolumeClaimUpdateStrategyType CloneSetVolumeClaimUpdateStrategyType = "OnDelete"
+)
+
+// CloneSetVolumeClaimUpdateStrategy defines the strategy for updating volume claims of CloneSet.
+type CloneSetVolumeClaimUpdateStrategy struct {
+	// Type specifies the type of update strategy, OnPodRollingUpdate or OnDelete.
+	Type CloneSetVolumeClaimUpdateStrategyType `json:"type,omitempty"`
+}
+
+// CloneSetVolumeClaimStatus describes the status of a volume claim template in CloneSet.
+type CloneSetVolumeClaimStatus struct {
+	// VolumeClaimName is the name of the volume claim template.
+	VolumeClaimName string `json:"volumeClaimName"`
+	// CompatibleReplicas is the number of replicas whose PVC storage requests are no less than the template.
+	CompatibleReplicas int32 `json:"compatibleReplicas"`
+	// CompatibleReadyReplicas is the number of compatible replicas whose PVC capacity has reached the storage requests.
+	CompatibleReadyReplicas int32 `json:"compatibleReadyReplicas"`
 }
 
 // CloneSetConditionReason is type for CloneSet reasons.
@@ -229,6 +286,8 @@ const (
 	CloneSetProgressPartitionAvailable CloneSetConditionReason = "ProgressPartitionAvailable"
 	// CloneSetAvailable is added in a cloneset when it is available.
 	CloneSetAvailable CloneSetConditionReason = "CloneSetAvailable"
+	// CloneSetLifecycleHookTimeout is added in a cloneset when some pods timed out in lifecycle hook.
+	CloneSetLifecycleHookTimeout CloneSetConditionReason = "LifecycleHookTimeout"
 )
 
 // CloneSetConditionType is type for CloneSet conditions.
@@ -241,6 +300,8 @@ const (
 	CloneSetConditionFailedUpdate CloneSetConditionType = "FailedUpdate"
 	// CloneSetConditionTypeProgressing indicates cloneset controller is progressing.
 	CloneSetConditionTypeProgressing CloneSetConditionType = "Progressing"
+	// CloneSetConditionLifecycleHookFailed indicates some pods timed out in lifecycle hook with MarkFailed action.
+	CloneSetConditionLifecycleHookFailed CloneSetConditionType = "LifecycleHookFailed"
 )
 
 // CloneSetCondition describes the state of a CloneSet at a certain point.
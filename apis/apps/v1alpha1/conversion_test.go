@@ -2177,12 +2177,16 @@ func TestSidecarSet_ConvertTo(t *testing.T) {
 								TargetContainersNameRegex: "^large-engine-v.*$",
 								ResourceExpr: ResourceExpr{
 									Limits: &ResourceExprLimits{
-										CPU:    "max(cpu*50%, 50m)",
-										Memory: "200Mi",
+										CPU:               "max(cpu*50%, 50m)",
+										Memory:            "200Mi",
+										EphemeralStorage:  "max(ephemeral_storage*10%, 500Mi)",
+										ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "1"},
 									},
 									Requests: &ResourceExprRequests{
-										CPU:    "max(cpu*50%, 50m)",
-										Memory: "100Mi",
+										CPU:               "max(cpu*50%, 50m)",
+										Memory:            "100Mi",
+										EphemeralStorage:  "max(ephemeral_storage*10%, 200Mi)",
+										ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "1"},
 									},
 								},
 							},
@@ -2209,12 +2213,16 @@ func TestSidecarSet_ConvertTo(t *testing.T) {
 								TargetContainersNameRegex: "^large-engine-v.*$",
 								ResourceExpr: v1beta1.ResourceExpr{
 									Limits: &v1beta1.ResourceExprLimits{
-										CPU:    "max(cpu*50%, 50m)",
-										Memory: "200Mi",
+										CPU:               "max(cpu*50%, 50m)",
+										Memory:            "200Mi",
+										EphemeralStorage:  "max(ephemeral_storage*10%, 500Mi)",
+										ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "1"},
 									},
 									Requests: &v1beta1.ResourceExprRequests{
-										CPU:    "max(cpu*50%, 50m)",
-										Memory: "100Mi",
+										CPU:               "max(cpu*50%, 50m)",
+										Memory:            "100Mi",
+										EphemeralStorage:  "max(ephemeral_storage*10%, 200Mi)",
+										ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "1"},
 									},
 								},
 							},
@@ -82,6 +82,7 @@ func (scs *SidecarSet) ConvertTo(dst conversion.Hub) error {
 			UpdatedReadyPods:   scs.Status.UpdatedReadyPods,
 			LatestRevision:     scs.Status.LatestRevision,
 			CollisionCount:     scs.Status.CollisionCount,
+			Conditions:         convertSidecarSetConditionsToV1Beta1(scs.Status.Conditions),
 		}
 
 		return nil
@@ -131,6 +132,7 @@ func (scs *SidecarSet) ConvertFrom(src conversion.Hub) error {
 			UpdatedReadyPods:   scsv1beta1.Status.UpdatedReadyPods,
 			LatestRevision:     scsv1beta1.Status.LatestRevision,
 			CollisionCount:     scsv1beta1.Status.CollisionCount,
+			Conditions:         convertSidecarSetConditionsToV1Alpha1(scsv1beta1.Status.Conditions),
 		}
 
 		return nil
@@ -314,6 +316,7 @@ func convertUpdateStrategyToV1Beta1(strategy SidecarSetUpdateStrategy) v1beta1.S
 		MaxUnavailable:   strategy.MaxUnavailable,
 		PriorityStrategy: strategy.PriorityStrategy,
 		ScatterStrategy:  convertScatterStrategyToV1Beta1(strategy.ScatterStrategy),
+		FailurePolicy:    convertUpdateFailurePolicyToV1Beta1(strategy.FailurePolicy),
 	}
 }
 
@@ -326,9 +329,64 @@ func convertUpdateStrategyToV1Alpha1(strategy v1beta1.SidecarSetUpdateStrategy)
 		MaxUnavailable:   strategy.MaxUnavailable,
 		PriorityStrategy: strategy.PriorityStrategy,
 		ScatterStrategy:  convertScatterStrategyToV1Alpha1(strategy.ScatterStrategy),
+		FailurePolicy:    convertUpdateFailurePolicyToV1Alpha1(strategy.FailurePolicy),
 	}
 }
 
+func convertUpdateFailurePolicyToV1Beta1(policy *SidecarSetUpdateFailurePolicy) *v1beta1.SidecarSetUpdateFailurePolicy {
+	if policy == nil {
+		return nil
+	}
+	return &v1beta1.SidecarSetUpdateFailurePolicy{
+		Type:                    v1beta1.SidecarSetUpdateFailurePolicyType(policy.Type),
+		UnreadyThresholdSeconds: policy.UnreadyThresholdSeconds,
+	}
+}
+
+func convertUpdateFailurePolicyToV1Alpha1(policy *v1beta1.SidecarSetUpdateFailurePolicy) *SidecarSetUpdateFailurePolicy {
+	if policy == nil {
+		return nil
+	}
+	return &SidecarSetUpdateFailurePolicy{
+		Type:                    SidecarSetUpdateFailurePolicyType(policy.Type),
+		UnreadyThresholdSeconds: policy.UnreadyThresholdSeconds,
+	}
+}
+
+func convertSidecarSetConditionsToV1Beta1(conditions []SidecarSetCondition) []v1beta1.SidecarSetCondition {
+	if conditions == nil {
+		return nil
+	}
+	result := make([]v1beta1.SidecarSetCondition, len(conditions))
+	for i, c := range conditions {
+		result[i] = v1beta1.SidecarSetCondition{
+			Type:               v1beta1.SidecarSetConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		}
+	}
+	return result
+}
+
+func convertSidecarSetConditionsToV1Alpha1(conditions []v1beta1.SidecarSetCondition) []SidecarSetCondition {
+	if conditions == nil {
+		return nil
+	}
+	result := make([]SidecarSetCondition, len(conditions))
+	for i, c := range conditions {
+		result[i] = SidecarSetCondition{
+			Type:               SidecarSetConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		}
+	}
+	return result
+}
+
 func convertScatterStrategyToV1Beta1(strategy UpdateScatterStrategy) v1beta1.UpdateScatterStrategy {
 	if strategy == nil {
 		return nil
@@ -411,14 +469,18 @@ func convertResourceExprToV1Beta1(expr ResourceExpr) v1beta1.ResourceExpr {
 	result := v1beta1.ResourceExpr{}
 	if expr.Limits != nil {
 		result.Limits = &v1beta1.ResourceExprLimits{
-			CPU:    expr.Limits.CPU,
-			Memory: expr.Limits.Memory,
+			CPU:               expr.Limits.CPU,
+			Memory:            expr.Limits.Memory,
+			EphemeralStorage:  expr.Limits.EphemeralStorage,
+			ExtendedResources: expr.Limits.ExtendedResources,
 		}
 	}
 	if expr.Requests != nil {
 		result.Requests = &v1beta1.ResourceExprRequests{
-			CPU:    expr.Requests.CPU,
-			Memory: expr.Requests.Memory,
+			CPU:               expr.Requests.CPU,
+			Memory:            expr.Requests.Memory,
+			EphemeralStorage:  expr.Requests.EphemeralStorage,
+			ExtendedResources: expr.Requests.ExtendedResources,
 		}
 	}
 	return result
@@ -428,14 +490,18 @@ func convertResourceExprToV1Alpha1(expr v1beta1.ResourceExpr) ResourceExpr {
 	result := ResourceExpr{}
 	if expr.Limits != nil {
 		result.Limits = &ResourceExprLimits{
-			CPU:    expr.Limits.CPU,
-			Memory: expr.Limits.Memory,
+			CPU:               expr.Limits.CPU,
+			Memory:            expr.Limits.Memory,
+			EphemeralStorage:  expr.Limits.EphemeralStorage,
+			ExtendedResources: expr.Limits.ExtendedResources,
 		}
 	}
 	if expr.Requests != nil {
 		result.Requests = &ResourceExprRequests{
-			CPU:    expr.Requests.CPU,
-			Memory: expr.Requests.Memory,
+			CPU:               expr.Requests.CPU,
+			Memory:            expr.Requests.Memory,
+			EphemeralStorage:  expr.Requests.EphemeralStorage,
+			ExtendedResources: expr.Requests.ExtendedResources,
 		}
 	}
 	return result
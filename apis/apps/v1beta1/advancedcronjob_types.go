@@ -65,6 +65,47 @@ type AdvancedCronJobSpec struct {
 
 	// Specifies the job that will be created when executing a CronJob.
 	Template CronJobTemplate `json:"template" protobuf:"bytes,7,opt,name=template"`
+
+	// MissedSchedulePolicy indicates how to handle the schedules missed for any reason,
+	// such as controller downtime or blocked by concurrency policy.
+	// If not specified, only the latest missed schedule will be run, which is the same as RunOnce.
+	// +optional
+	MissedSchedulePolicy *MissedSchedulePolicy `json:"missedSchedulePolicy,omitempty"`
+
+	// RunHistoryLimit is the number of runs to record in status.runHistory, the newest first.
+	// If not specified or 0, no run history will be recorded.
+	// +optional
+	RunHistoryLimit *int32 `json:"runHistoryLimit,omitempty"`
+}
+
+// MissedSchedulePolicyType is the type of MissedSchedulePolicy.
+// +kubebuilder:validation:Enum=Skip;RunOnce;Backfill
+type MissedSchedulePolicyType string
+
+const (
+	// MissedSchedulePolicySkip skips all the missed schedules if more than one schedule has been missed,
+	// or the only missed one is late for more than startingDeadlineSeconds (one minute if not set),
+	// and waits for the next schedule.
+	MissedSchedulePolicySkip MissedSchedulePolicyType = "Skip"
+
+	// MissedSchedulePolicyRunOnce runs only the latest missed schedule.
+	MissedSchedulePolicyRunOnce MissedSchedulePolicyType = "RunOnce"
+
+	// MissedSchedulePolicyBackfill runs the missed schedules one by one from the oldest,
+	// no more than maxBackfill ones besides the latest schedule. The older ones are skipped.
+	MissedSchedulePolicyBackfill MissedSchedulePolicyType = "Backfill"
+)
+
+// MissedSchedulePolicy indicates how to handle the missed schedules.
+type MissedSchedulePolicy struct {
+	// Type is the type of policy, defaults to RunOnce.
+	// +optional
+	Type MissedSchedulePolicyType `json:"type,omitempty"`
+
+	// MaxBackfill is the max number of missed schedules to run besides the latest one, for Backfill type.
+	// Defaults to 1. The backfill runs are still restricted by the concurrency policy.
+	// +optional
+	MaxBackfill *int32 `json:"maxBackfill,omitempty"`
 }
 
 type CronJobTemplate struct {
@@ -145,6 +186,57 @@ type AdvancedCronJobStatus struct {
 	// Information when was the last time the job was successfully scheduled.
 	// +optional
 	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSkippedScheduleTime is the latest schedule skipped by the missed schedule policy.
+	// The schedules before it will not be run any more.
+	// +optional
+	LastSkippedScheduleTime *metav1.Time `json:"lastSkippedScheduleTime,omitempty"`
+
+	// RunHistory is the records of recent runs, the newest first, bounded by spec.runHistoryLimit.
+	// +optional
+	RunHistory []AdvancedCronJobRunRecord `json:"runHistory,omitempty"`
+}
+
+// AdvancedCronJobRunPhase is the phase of a run of AdvancedCronJob.
+type AdvancedCronJobRunPhase string
+
+const (
+	// AdvancedCronJobRunRunning means the created object has not finished.
+	AdvancedCronJobRunRunning AdvancedCronJobRunPhase = "Running"
+
+	// AdvancedCronJobRunSucceeded means the created object has completed.
+	AdvancedCronJobRunSucceeded AdvancedCronJobRunPhase = "Succeeded"
+
+	// AdvancedCronJobRunFailed means the created object has failed.
+	AdvancedCronJobRunFailed AdvancedCronJobRunPhase = "Failed"
+
+	// AdvancedCronJobRunDeleted means the created object was deleted before it finished,
+	// e.g., replaced by the next run.
+	AdvancedCronJobRunDeleted AdvancedCronJobRunPhase = "Deleted"
+)
+
+// AdvancedCronJobRunRecord is the record of a run of AdvancedCronJob.
+type AdvancedCronJobRunRecord struct {
+	// ScheduledTime is the time at which the run was scheduled.
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+
+	// Object is the reference to the Job, BroadcastJob or ImageListPullJob created for the run.
+	Object corev1.ObjectReference `json:"object"`
+
+	// Phase is the final phase of the run, or Running if it has not finished.
+	Phase AdvancedCronJobRunPhase `json:"phase"`
+
+	// StartTime is the time at which the created object started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the created object finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration is the duration from start to completion of the run.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // +genclient
@@ -48,8 +48,112 @@ type BroadcastJobSpec struct {
 	// FailurePolicy indicates the behavior of the job, when failed pod is found.
 	// +optional
 	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty" protobuf:"bytes,5,opt,name=failurePolicy"`
+
+	// NodeSelector is a label query over nodes that the job should run pods on,
+	// which works independent of the node affinity of template.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// NodeParameters indicates the node-specific parameters passed to each pod,
+	// such as the node name, the index of node and the labels or annotations of node.
+	// +optional
+	NodeParameters *BroadcastJobNodeParameters `json:"nodeParameters,omitempty"`
+
+	// NodeResults indicates where to report the result of each node.
+	// The results are not reported if it is nil.
+	// +optional
+	NodeResults *BroadcastJobNodeResultsPolicy `json:"nodeResults,omitempty"`
+
+	// RollingStrategy indicates to run pods on nodes in batches, and pause the job if the success ratio
+	// of finished pods is below the threshold when a batch finished.
+	// +optional
+	RollingStrategy *BroadcastJobRollingStrategy `json:"rollingStrategy,omitempty"`
+}
+
+// BroadcastJobRollingStrategy indicates how to run pods on nodes in batches.
+type BroadcastJobRollingStrategy struct {
+	// Batches are the cumulative number or percentage of the desired nodes to run pods in each batch, such as 5%, 20%, 100%.
+	// The next batch starts only after all pods of the previous batches finished and the success ratio passed.
+	// The nodes are picked in order of name, and the rest nodes run after the last batch.
+	Batches []intstr.IntOrString `json:"batches"`
+
+	// MinSuccessPercent is the minimum percentage of succeeded pods among the finished pods before the next batch starts.
+	// The job is paused as the Pause failure policy does if the success ratio is below it, and paused again after resumed
+	// unless the failed pods deleted to run again or the threshold changed. The pods of the last batch are evaluated only
+	// by the failure policy, and so are the failed pods within a batch, which means it works with the Continue policy.
+	// Defaults to 100.
+	// +optional
+	MinSuccessPercent *int32 `json:"minSuccessPercent,omitempty"`
+}
+
+// BroadcastJobNodeResultsType is the type of where to report the results of nodes.
+type BroadcastJobNodeResultsType string
+
+const (
+	// BroadcastJobNodeResultsStatus means the results are reported in status.nodeResults.
+	// This is the default BroadcastJobNodeResultsType.
+	BroadcastJobNodeResultsStatus BroadcastJobNodeResultsType = "Status"
+
+	// BroadcastJobNodeResultsConfigMap means the results are reported in a ConfigMap named by status.nodeResultsConfigMap,
+	// which keeps the result of each node as JSON keyed by node name. This is useful for large clusters.
+	// The results beyond the size limit of ConfigMap are not reported, the failed nodes first to report,
+	// and the NodeResultsTruncated condition is set.
+	BroadcastJobNodeResultsConfigMap BroadcastJobNodeResultsType = "ConfigMap"
+)
+
+// BroadcastJobNodeResultsPolicy indicates where to report the results of nodes.
+type BroadcastJobNodeResultsPolicy struct {
+	// Type indicates where to report the results, Status or ConfigMap.
+	// Default is Status.
+	// +optional
+	Type BroadcastJobNodeResultsType `json:"type,omitempty"`
 }
 
+// BroadcastJobNodeParameters indicates how to pass the node-specific parameters to pods.
+// The node name and the index of node are always passed as BROADCASTJOB_NODE_NAME and BROADCASTJOB_NODE_INDEX.
+type BroadcastJobNodeParameters struct {
+	// Params are the parameters from the labels or annotations of node.
+	// +optional
+	Params []BroadcastJobNodeParam `json:"params,omitempty"`
+
+	// InjectEnv indicates whether to inject the parameters as env vars into all containers.
+	// +optional
+	InjectEnv bool `json:"injectEnv,omitempty"`
+
+	// MountPath is the directory to mount the parameters as files into all containers, one file for each parameter.
+	// Files are not mounted if it is empty.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// BroadcastJobNodeParam is a parameter from the label or annotation of node.
+type BroadcastJobNodeParam struct {
+	// Name of the parameter, which is used as the env name and the file name.
+	// Must be a C identifier.
+	Name string `json:"name"`
+
+	// NodeLabel is the key of node label to get the value from.
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// NodeAnnotation is the key of node annotation to get the value from.
+	// Only one of nodeLabel and nodeAnnotation can be set.
+	// +optional
+	NodeAnnotation string `json:"nodeAnnotation,omitempty"`
+}
+
+const (
+	// BroadcastJobNodeNameParam is the parameter of node name passed to pods.
+	BroadcastJobNodeNameParam = "BROADCASTJOB_NODE_NAME"
+	// BroadcastJobNodeIndexParam is the parameter of node index passed to pods, which is the index of node
+	// among the desired nodes sorted by name when the pod created.
+	BroadcastJobNodeIndexParam = "BROADCASTJOB_NODE_INDEX"
+	// BroadcastJobNodeParamAnnotationPrefix is the prefix of pod annotations that record the parameters.
+	BroadcastJobNodeParamAnnotationPrefix = "apps.kruise.io/broadcastjob-param-"
+	// BroadcastJobAttemptAnnotation is the annotation of pods that records the attempt of pod on its node, starting from 1.
+	BroadcastJobAttemptAnnotation = "apps.kruise.io/broadcastjob-attempt"
+)
+
 // CompletionPolicy indicates the completion policy for the job
 type CompletionPolicy struct {
 	// Type indicates the type of the CompletionPolicy.
@@ -131,6 +235,50 @@ type BroadcastJobStatus struct {
 	// The phase of the job.
 	// +optional
 	Phase BroadcastJobPhase `json:"phase" protobuf:"varint,8,opt,name=phase"`
+
+	// NodeResults are the results of pods on each node, if spec.nodeResults.type is Status.
+	// +optional
+	NodeResults []BroadcastJobNodeResult `json:"nodeResults,omitempty"`
+
+	// NodeResultsConfigMap is the name of ConfigMap keeping the results of pods on each node,
+	// if spec.nodeResults.type is ConfigMap.
+	// +optional
+	NodeResultsConfigMap string `json:"nodeResultsConfigMap,omitempty"`
+
+	// CurrentBatch is the index of the batch in running, if spec.rollingStrategy is set.
+	// It equals to the number of batches after all batches passed.
+	// +optional
+	CurrentBatch int32 `json:"currentBatch,omitempty"`
+}
+
+// BroadcastJobNodeResult is the result of the latest pod on a node.
+type BroadcastJobNodeResult struct {
+	// NodeName is the name of node.
+	NodeName string `json:"nodeName"`
+
+	// Phase is the phase of the latest pod on the node.
+	// +optional
+	Phase v1.PodPhase `json:"phase,omitempty"`
+
+	// ExitCode is the exit code of the container terminated, the failed one comes first.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Message is the termination message of the container terminated.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Attempts is the number of pods created on the node.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastError is the reason of the last failure on the node.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// NextRetryTime is the time to retry the failed pod on the node.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
 }
 
 // BroadcastJobPhase indicates the phase of the job.
@@ -158,6 +306,20 @@ type FailurePolicy struct {
 
 	// RestartLimit specifies the number of retries before marking the pod failed.
 	RestartLimit int32 `json:"restartLimit,omitempty" protobuf:"varint,2,opt,name=restartLimit"`
+
+	// BackoffLimitPerNode specifies the number of retries on each node before marking the pod failed.
+	// The pod in Failed phase is retried by creating a new pod on the same node with exponential backoff,
+	// and it is not counted as failed for the job until the retries on the node exceed this limit.
+	// The pod still running with restarts exceeding restartLimit is not retried, and counted as failed at once.
+	// Not setting this value means no retry on nodes.
+	// +optional
+	BackoffLimitPerNode *int32 `json:"backoffLimitPerNode,omitempty"`
+
+	// BackoffSeconds is the initial backoff duration to retry on each node, which is doubled for each retry
+	// and capped at 6 minutes. Only works with backoffLimitPerNode.
+	// Defaults to 10.
+	// +optional
+	BackoffSeconds *int32 `json:"backoffSeconds,omitempty"`
 }
 
 // FailurePolicyType indicates the type of FailurePolicyType.
@@ -189,6 +351,9 @@ const (
 	// JobFailed means the job has failed its execution. A failed job means the job has either exceeded the
 	// ActiveDeadlineSeconds limit, or the aggregated number of container restarts for all pods have exceeded the RestartLimit.
 	JobFailed JobConditionType = "Failed"
+
+	// JobNodeResultsTruncated means some results of nodes are not reported in the ConfigMap due to its size limit.
+	JobNodeResultsTruncated JobConditionType = "NodeResultsTruncated"
 )
 
 // JobCondition describes current state of a job.
@@ -53,6 +53,11 @@ const (
 	InplaceRollingUpdateType RollingUpdateType = "InPlaceIfPossible"
 )
 
+const (
+	// DaemonSetNodeProfileAnnotation is the name of node profile applied to the daemon pod.
+	DaemonSetNodeProfileAnnotation = "apps.kruise.io/daemonset-node-profile"
+)
+
 // Spec to control the desired behavior of daemon set rolling update.
 type RollingUpdateDaemonSet struct {
 	// Type is to specify which kind of rollingUpdate.
@@ -112,6 +117,72 @@ type RollingUpdateDaemonSet struct {
 	// daemon set controller.
 	// +optional
 	Paused *bool `json:"paused,omitempty"`
+
+	// Waves is an ordered list of node groups to be updated one after another.
+	// A wave starts after all daemon pods of the previous waves have been updated and available for their soak time.
+	// A node belongs to the first wave it matches, and nodes matching none of the waves are updated after all waves.
+	// It can not be used together with Selector.
+	// +optional
+	Waves []DaemonSetUpdateWave `json:"waves,omitempty"`
+
+	// UnschedulableNodes indicates how to update daemon pods on unschedulable nodes, which are cordoned or
+	// have any of the configured taints, e.g. nodes being drained. Daemon pods on these nodes do not count
+	// against maxUnavailable or maxSurge, and are excluded from numberUnavailable in status.
+	// +optional
+	UnschedulableNodes *DaemonSetUnschedulableNodesStrategy `json:"unschedulableNodes,omitempty"`
+}
+
+// UnschedulableNodesPolicyType is the policy to update daemon pods on unschedulable nodes.
+type UnschedulableNodesPolicyType string
+
+const (
+	// SkipUnschedulableNodesPolicyType means daemon pods on unschedulable nodes are not updated.
+	SkipUnschedulableNodesPolicyType UnschedulableNodesPolicyType = "Skip"
+
+	// DeprioritizeUnschedulableNodesPolicyType means daemon pods on unschedulable nodes are updated
+	// after all daemon pods on the other nodes have been updated.
+	DeprioritizeUnschedulableNodesPolicyType UnschedulableNodesPolicyType = "Deprioritize"
+)
+
+// DaemonSetUnschedulableNodesStrategy defines how to update daemon pods on unschedulable nodes.
+type DaemonSetUnschedulableNodesStrategy struct {
+	// Policy is Skip or Deprioritize. Default is Deprioritize.
+	// +optional
+	Policy UnschedulableNodesPolicyType `json:"policy,omitempty"`
+
+	// Taints are the taints that make nodes unschedulable besides cordoned.
+	// +optional
+	Taints []UnschedulableNodeTaint `json:"taints,omitempty"`
+}
+
+// UnschedulableNodeTaint matches the taints of nodes by key and effect.
+type UnschedulableNodeTaint struct {
+	// Key is the taint key to match.
+	Key string `json:"key"`
+
+	// Effect is the taint effect to match. Empty means matching all effects.
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+}
+
+// DaemonSetUpdateWave is a group of nodes to be updated together.
+type DaemonSetUpdateWave struct {
+	// Name is the unique name of the wave.
+	Name string `json:"name"`
+
+	// NodeSelector is a label query over nodes in the wave.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+
+	// MaxUnavailable overrides the maxUnavailable of rolling update in this wave.
+	// Value can be an absolute number (ex: 5) or a percentage of the daemon pods in the wave (ex: 10%).
+	// It may not be set when maxSurge of rolling update is non-zero.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// SoakSeconds is the time to wait after all daemon pods of the wave updated and available,
+	// before the next wave starts.
+	// +optional
+	SoakSeconds int32 `json:"soakSeconds,omitempty"`
 }
 
 // DaemonSetSpec defines the desired state of DaemonSet
@@ -164,6 +235,38 @@ type DaemonSetSpec struct {
 	// employed to create Pods in the DaemonSet.
 	// +optional
 	ScaleStrategy *DaemonSetScaleStrategy `json:"scaleStrategy,omitempty"`
+
+	// NodeProfiles overrides the container resources and env of daemon pods on different classes of nodes.
+	// A node uses the first profile it matches, and pods on nodes matching none of the profiles use the template.
+	// Resources are resized in-place if the profile of a node changes, which requires InPlaceWorkloadVerticalScaling enabled.
+	// +optional
+	NodeProfiles []DaemonSetNodeProfile `json:"nodeProfiles,omitempty"`
+}
+
+// DaemonSetNodeProfile is the overrides of daemon pods on a class of nodes.
+type DaemonSetNodeProfile struct {
+	// Name is the unique name of the profile.
+	Name string `json:"name"`
+
+	// NodeSelector is a label query over nodes of the profile.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+
+	// Containers is the overrides of containers in the template.
+	Containers []DaemonSetContainerProfile `json:"containers"`
+}
+
+// DaemonSetContainerProfile is the overrides of a container in the template.
+type DaemonSetContainerProfile struct {
+	// Name is the name of container in the template.
+	Name string `json:"name"`
+
+	// Resources are merged into the resources of container by resource name.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env are merged into the env of container by env name.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
 // DaemonSetScaleStrategy defines strategies for DaemonSet scaling.
@@ -233,6 +336,41 @@ type DaemonSetStatus struct {
 
 	// UpdateRevision is the controller-revision-hash, which represents the latest version of the DaemonSet.
 	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// CurrentWave is the name of the wave in rolling update or soaking.
+	// It is empty if waves are not set or all of them have been finished.
+	// +optional
+	CurrentWave string `json:"currentWave,omitempty"`
+
+	// Waves is the status of waves in rolling update.
+	// +optional
+	Waves []DaemonSetWaveStatus `json:"waves,omitempty"`
+}
+
+// These are valid conditions of a DaemonSet.
+const (
+	// DaemonSetConditionLifecycleHookFailed indicates some pods timed out in lifecycle hook with MarkFailed action.
+	DaemonSetConditionLifecycleHookFailed appsv1.DaemonSetConditionType = "LifecycleHookFailed"
+)
+
+// DaemonSetWaveStatus is the status of a wave in rolling update.
+type DaemonSetWaveStatus struct {
+	// Name is the name of the wave.
+	Name string `json:"name"`
+
+	// DesiredNumberScheduled is the number of nodes in the wave that should be running the daemon pod.
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// UpdatedNumberScheduled is the number of nodes in the wave that are running updated daemon pod.
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
+
+	// UpdatedNumberAvailable is the number of nodes in the wave that are running updated and available daemon pod.
+	UpdatedNumberAvailable int32 `json:"updatedNumberAvailable"`
+
+	// CompletionTime is the time all daemon pods of the wave became updated and available,
+	// which is kept in the update revision even if some of them become unavailable later.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 // +genclient
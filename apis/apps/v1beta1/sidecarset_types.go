@@ -246,6 +246,20 @@ type ResourceExprLimits struct {
 	// If the expression result is unlimited, sidecar container resources.limits won't be configured
 	// +optional
 	Memory string `json:"memory,omitempty"`
+
+	// EphemeralStorage expression for calculating ephemeral-storage limits
+	// Support +, -, *, /, max(), min() and variable 'ephemeral_storage'
+	// Variable 'ephemeral_storage' represents the sum or max of resources.limits.ephemeral-storage of all matched containers
+	// If matched containers don't have resources.limits configured, this field will be treated as unlimited
+	// +optional
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+
+	// ExtendedResources contains expressions for calculating extended resource limits, keyed by resource name, e.g. nvidia.com/gpu
+	// Support +, -, *, /, max(), min() and a variable named after the resource, with all characters other than
+	// letters and digits replaced by '_', e.g. 'nvidia_com_gpu'
+	// The result of the expression is rounded up to an integer, for extended resources can only be whole numbers
+	// +optional
+	ExtendedResources map[corev1.ResourceName]string `json:"extendedResources,omitempty"`
 }
 
 // ResourceExprRequests defines expressions for calculating resource requests
@@ -263,6 +277,18 @@ type ResourceExprRequests struct {
 	// If matched containers don't have resources.requests configured, the corresponding resource value will be treated as 0
 	// +optional
 	Memory string `json:"memory,omitempty"`
+
+	// EphemeralStorage expression for calculating ephemeral-storage requests
+	// Support +, -, *, /, max(), min() and variable 'ephemeral_storage'
+	// Variable 'ephemeral_storage' represents the sum or max of resources.requests.ephemeral-storage of all matched containers
+	// If matched containers don't have resources.requests configured, the corresponding resource value will be treated as 0
+	// +optional
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+
+	// ExtendedResources contains expressions for calculating extended resource requests, keyed by resource name, e.g. nvidia.com/gpu
+	// Extended resources can't be overcommitted, so the expression must be the same as the one in limits if both are configured
+	// +optional
+	ExtendedResources map[corev1.ResourceName]string `json:"extendedResources,omitempty"`
 }
 
 type SidecarContainerUpgradeType string
@@ -373,8 +399,31 @@ type SidecarSetUpdateStrategy struct {
 	// - Note that pods will be scattered after priority sort. So, although priority strategy and scatter strategy can be applied together, we suggest to use either one of them.
 	// - If scatterStrategy is used, we suggest to just use one term. Otherwise, the update order can be hard to understand.
 	ScatterStrategy UpdateScatterStrategy `json:"scatterStrategy,omitempty"`
+	// FailurePolicy defines what to do with the updated pods that stay unready for too long.
+	// If not set, the update just stops progressing once MaxUnavailable is reached.
+	FailurePolicy *SidecarSetUpdateFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// SidecarSetUpdateFailurePolicy defines the policy for the failed updates of SidecarSet.
+type SidecarSetUpdateFailurePolicy struct {
+	// Type is the action for the failed updates, only Rollback is supported now.
+	// Rollback means the pods updated to the latest revision but unready for more than UnreadyThresholdSeconds
+	// will be rolled back in place to the previous revision, and the update of the latest revision is stopped
+	// until a new revision of SidecarSet is published.
+	// +kubebuilder:validation:Enum=Rollback
+	Type SidecarSetUpdateFailurePolicyType `json:"type,omitempty"`
+	// UnreadyThresholdSeconds is the time that an updated pod can stay unready before it is considered as failed.
+	// Defaults to 300.
+	// +optional
+	UnreadyThresholdSeconds *int32 `json:"unreadyThresholdSeconds,omitempty"`
 }
 
+type SidecarSetUpdateFailurePolicyType string
+
+const (
+	RollbackSidecarSetUpdateFailurePolicyType SidecarSetUpdateFailurePolicyType = "Rollback"
+)
+
 type SidecarSetUpdateStrategyType string
 
 const (
@@ -407,6 +456,33 @@ type SidecarSetStatus struct {
 	// uses this field as a collision avoidance mechanism when it needs to create the name for the
 	// newest ControllerRevision.
 	CollisionCount *int32 `json:"collisionCount,omitempty"`
+
+	// Conditions represents the latest available observations of a SidecarSet's current state.
+	// +optional
+	Conditions []SidecarSetCondition `json:"conditions,omitempty"`
+}
+
+// SidecarSetConditionType is the type of SidecarSet condition.
+type SidecarSetConditionType string
+
+const (
+	// SidecarSetConditionTypeRolloutFailed means the update of the latest revision failed and the failed pods
+	// have been rolled back to the previous revision.
+	SidecarSetConditionTypeRolloutFailed SidecarSetConditionType = "RolloutFailed"
+)
+
+// SidecarSetCondition describes the state of a SidecarSet at a certain point.
+type SidecarSetCondition struct {
+	// Type of SidecarSet condition.
+	Type SidecarSetConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
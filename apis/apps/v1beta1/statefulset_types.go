@@ -44,6 +44,31 @@ const (
 	// This strategy places full control of the update timing in the hands of the user, typically executed after ensuring data has been backed up or there are no data security concerns,
 	// allowing for storage resource management that aligns with specific user requirements and security policies.
 	OnPVCDeleteVolumeClaimUpdateStrategyType VolumeClaimUpdateStrategyType = "OnDelete"
+
+	// RecreateWithDataMigrationVolumeClaimUpdateStrategyType indicates that PVCs incompatible with the templates, such as
+	// those with another storage class, are recreated from the templates during pod rolling updates, and the data is copied
+	// from the old PVCs to the new ones by a user-defined job before the pods are recreated.
+	RecreateWithDataMigrationVolumeClaimUpdateStrategyType VolumeClaimUpdateStrategyType = "RecreateWithDataMigration"
+)
+
+// OldVolumeClaimPolicyType defines what to do with the old volumes after data migrated.
+// +enum
+type OldVolumeClaimPolicyType string
+
+const (
+	// RetainOldVolumeClaimPolicyType retains the PersistentVolumes of the old PVCs after the PVCs deleted.
+	RetainOldVolumeClaimPolicyType OldVolumeClaimPolicyType = "Retain"
+	// DeleteOldVolumeClaimPolicyType deletes the old PVCs with their PersistentVolumes reclaimed by their own policy.
+	DeleteOldVolumeClaimPolicyType OldVolumeClaimPolicyType = "Delete"
+)
+
+const (
+	// DataMigrationSourceVolumePrefix is the prefix of volumes in the migration job that mount the old PVCs,
+	// e.g. volume `source-data` mounts the old PVC of template `data`.
+	DataMigrationSourceVolumePrefix = "source-"
+	// DataMigrationTargetVolumePrefix is the prefix of volumes in the migration job that mount the new PVCs,
+	// e.g. volume `target-data` mounts the new PVC of template `data`.
+	DataMigrationTargetVolumePrefix = "target-"
 )
 
 // VolumeClaimStatus describes the status of a volume claim template.
@@ -83,6 +108,31 @@ type VolumeClaimUpdateStrategy struct {
 	// OnPodRollingUpdateVolumeClaimUpdateStrategyType: Apply the update strategy during pod rolling updates.
 	// OnPVCDeleteVolumeClaimUpdateStrategyType: Apply the update strategy when a PersistentVolumeClaim is deleted.
 	Type VolumeClaimUpdateStrategyType `json:"type,omitempty"`
+
+	// DataMigration describes how to copy data from the old PVCs to the new ones.
+	// It is required when Type is RecreateWithDataMigration.
+	// +optional
+	DataMigration *VolumeClaimDataMigration `json:"dataMigration,omitempty"`
+}
+
+// VolumeClaimDataMigration defines the job to copy data between the old and new PVCs of a pod.
+type VolumeClaimDataMigration struct {
+	// Template is the pod template of the job to copy data. The old and new PVCs are added to it as volumes
+	// named with DataMigrationSourceVolumePrefix and DataMigrationTargetVolumePrefix, which should be mounted
+	// by its containers.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Template v1.PodTemplateSpec `json:"template"`
+
+	// BackoffLimit is the number of retries before marking the job failed.
+	// Defaults to 3.
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// OldVolumeClaimPolicy indicates what to do with the old volumes after data migrated, Retain or Delete.
+	// Defaults to Retain.
+	// +optional
+	OldVolumeClaimPolicy OldVolumeClaimPolicyType `json:"oldVolumeClaimPolicy,omitempty"`
 }
 
 // RollingUpdateStatefulSetStrategy is used to communicate parameter for RollingUpdateStatefulSetStrategyType.
@@ -122,8 +172,68 @@ type RollingUpdateStatefulSetStrategy struct {
 	// Default value is 0, max is 300.
 	// +optional
 	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// RoleAwareUpdate contains strategies to update pods by their roles.
+	// If it is not nil, the leader will be updated after all the other pods and a switchover.
+	// +optional
+	RoleAwareUpdate *RoleAwareUpdateStrategy `json:"roleAwareUpdate,omitempty"`
+	// SnapshotBeforeUpdate indicates to create VolumeSnapshots for the PVCs of each pod before it is updated.
+	// The pod is updated only after all its VolumeSnapshots are ready to use, and the rollout is blocked if any of them failed.
+	// It requires the VolumeSnapshot CRD of snapshot.storage.k8s.io/v1 installed.
+	// +optional
+	SnapshotBeforeUpdate *SnapshotBeforeUpdateStrategy `json:"snapshotBeforeUpdate,omitempty"`
+}
+
+// SnapshotBeforeUpdateStrategy defines how to create VolumeSnapshots before updating pods.
+type SnapshotBeforeUpdateStrategy struct {
+	// VolumeSnapshotClassName is the class of VolumeSnapshots to create.
+	// Defaults to the default VolumeSnapshotClass.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// RoleAwareUpdateStrategy defines strategies to update pods with leader and follower roles.
+type RoleAwareUpdateStrategy struct {
+	// RoleLabelKey is the label key of pod role, which is usually marked by PodProbeMarker.
+	RoleLabelKey string `json:"roleLabelKey"`
+	// LeaderRoleValue is the label value of the leader role.
+	// Defaults to leader.
+	// +optional
+	LeaderRoleValue string `json:"leaderRoleValue,omitempty"`
+	// Switchover is the action to transfer leadership away from the leader before it is updated.
+	// If it is nil, the leader is just updated after the other pods.
+	// +optional
+	Switchover *RoleSwitchoverAction `json:"switchover,omitempty"`
+	// SwitchoverTimeoutSeconds is how long to wait for the role label to move after a switchover,
+	// the switchover will be called again after timeout.
+	// Defaults to 60.
+	// +optional
+	SwitchoverTimeoutSeconds *int32 `json:"switchoverTimeoutSeconds,omitempty"`
+	// MaxSwitchoverAttempts is the max number of switchover called for a leader whose role never moves,
+	// such as there is no follower eligible to take over. The leader is updated without switchover after that.
+	// Defaults to 3.
+	// +optional
+	MaxSwitchoverAttempts *int32 `json:"maxSwitchoverAttempts,omitempty"`
 }
 
+// RoleSwitchoverAction is the action to transfer leadership away from the leader pod.
+type RoleSwitchoverAction struct {
+	// HTTPPost is the request POSTed to the leader pod, which has the same fields as httpGet of probes.
+	// Host must be empty, the request is always sent to the pod IP.
+	HTTPPost *v1.HTTPGetAction `json:"httpPost,omitempty"`
+}
+
+const (
+	// DefaultLeaderRoleValue is the default label value of the leader role in RoleAwareUpdateStrategy.
+	DefaultLeaderRoleValue = "leader"
+	// RoleSwitchoverTimestampAnnotation is the time of the last switchover called for the leader pod.
+	RoleSwitchoverTimestampAnnotation = "apps.kruise.io/role-switchover-timestamp"
+	// RoleSwitchoverAttemptsAnnotation is the number of switchover called for the leader pod in its current revision,
+	// in the format of <revision>/<attempts>.
+	RoleSwitchoverAttemptsAnnotation = "apps.kruise.io/role-switchover-attempts"
+	// VolumeSnapshotsAnnotation records the VolumeSnapshots created before the pod is updated to a revision.
+	VolumeSnapshotsAnnotation = "apps.kruise.io/volume-snapshots"
+)
+
 // UnorderedUpdateStrategy defines strategies for non-ordered update.
 type UnorderedUpdateStrategy struct {
 	// Priorities are the rules for calculating the priority of updating pods.
@@ -375,6 +485,8 @@ type StatefulSetStatus struct {
 const (
 	FailedCreatePod apps.StatefulSetConditionType = "FailedCreatePod"
 	FailedUpdatePod apps.StatefulSetConditionType = "FailedUpdatePod"
+	// LifecycleHookFailed indicates some pods timed out in lifecycle hook with MarkFailed action.
+	LifecycleHookFailed apps.StatefulSetConditionType = "LifecycleHookFailed"
 )
 
 // +genclient
@@ -89,6 +89,36 @@ func (in *AdvancedCronJobList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedCronJobRunRecord) DeepCopyInto(out *AdvancedCronJobRunRecord) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	out.Object = in.Object
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedCronJobRunRecord.
+func (in *AdvancedCronJobRunRecord) DeepCopy() *AdvancedCronJobRunRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedCronJobRunRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedCronJobSpec) DeepCopyInto(out *AdvancedCronJobSpec) {
 	*out = *in
@@ -118,6 +148,16 @@ func (in *AdvancedCronJobSpec) DeepCopyInto(out *AdvancedCronJobSpec) {
 		**out = **in
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.MissedSchedulePolicy != nil {
+		in, out := &in.MissedSchedulePolicy, &out.MissedSchedulePolicy
+		*out = new(MissedSchedulePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunHistoryLimit != nil {
+		in, out := &in.RunHistoryLimit, &out.RunHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedCronJobSpec.
@@ -142,6 +182,17 @@ func (in *AdvancedCronJobStatus) DeepCopyInto(out *AdvancedCronJobStatus) {
 		in, out := &in.LastScheduleTime, &out.LastScheduleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastSkippedScheduleTime != nil {
+		in, out := &in.LastSkippedScheduleTime, &out.LastSkippedScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RunHistory != nil {
+		in, out := &in.RunHistory, &out.RunHistory
+		*out = make([]AdvancedCronJobRunRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedCronJobStatus.
@@ -213,6 +264,105 @@ func (in *BroadcastJobList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastJobNodeParam) DeepCopyInto(out *BroadcastJobNodeParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobNodeParam.
+func (in *BroadcastJobNodeParam) DeepCopy() *BroadcastJobNodeParam {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastJobNodeParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastJobNodeParameters) DeepCopyInto(out *BroadcastJobNodeParameters) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]BroadcastJobNodeParam, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobNodeParameters.
+func (in *BroadcastJobNodeParameters) DeepCopy() *BroadcastJobNodeParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastJobNodeParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastJobNodeResult) DeepCopyInto(out *BroadcastJobNodeResult) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobNodeResult.
+func (in *BroadcastJobNodeResult) DeepCopy() *BroadcastJobNodeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastJobNodeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastJobNodeResultsPolicy) DeepCopyInto(out *BroadcastJobNodeResultsPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobNodeResultsPolicy.
+func (in *BroadcastJobNodeResultsPolicy) DeepCopy() *BroadcastJobNodeResultsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastJobNodeResultsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastJobRollingStrategy) DeepCopyInto(out *BroadcastJobRollingStrategy) {
+	*out = *in
+	if in.Batches != nil {
+		in, out := &in.Batches, &out.Batches
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSuccessPercent != nil {
+		in, out := &in.MinSuccessPercent, &out.MinSuccessPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobRollingStrategy.
+func (in *BroadcastJobRollingStrategy) DeepCopy() *BroadcastJobRollingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastJobRollingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BroadcastJobSpec) DeepCopyInto(out *BroadcastJobSpec) {
 	*out = *in
@@ -223,7 +373,27 @@ func (in *BroadcastJobSpec) DeepCopyInto(out *BroadcastJobSpec) {
 	}
 	in.Template.DeepCopyInto(&out.Template)
 	in.CompletionPolicy.DeepCopyInto(&out.CompletionPolicy)
-	out.FailurePolicy = in.FailurePolicy
+	in.FailurePolicy.DeepCopyInto(&out.FailurePolicy)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeParameters != nil {
+		in, out := &in.NodeParameters, &out.NodeParameters
+		*out = new(BroadcastJobNodeParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeResults != nil {
+		in, out := &in.NodeResults, &out.NodeResults
+		*out = new(BroadcastJobNodeResultsPolicy)
+		**out = **in
+	}
+	if in.RollingStrategy != nil {
+		in, out := &in.RollingStrategy, &out.RollingStrategy
+		*out = new(BroadcastJobRollingStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobSpec.
@@ -254,6 +424,13 @@ func (in *BroadcastJobStatus) DeepCopyInto(out *BroadcastJobStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.NodeResults != nil {
+		in, out := &in.NodeResults, &out.NodeResults
+		*out = make([]BroadcastJobNodeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastJobStatus.
@@ -365,6 +542,29 @@ func (in *DaemonSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetContainerProfile) DeepCopyInto(out *DaemonSetContainerProfile) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetContainerProfile.
+func (in *DaemonSetContainerProfile) DeepCopy() *DaemonSetContainerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetContainerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetList) DeepCopyInto(out *DaemonSetList) {
 	*out = *in
@@ -397,6 +597,33 @@ func (in *DaemonSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetNodeProfile) DeepCopyInto(out *DaemonSetNodeProfile) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]DaemonSetContainerProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetNodeProfile.
+func (in *DaemonSetNodeProfile) DeepCopy() *DaemonSetNodeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetNodeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetScaleStrategy) DeepCopyInto(out *DaemonSetScaleStrategy) {
 	*out = *in
@@ -442,6 +669,13 @@ func (in *DaemonSetSpec) DeepCopyInto(out *DaemonSetSpec) {
 		*out = new(DaemonSetScaleStrategy)
 		**out = **in
 	}
+	if in.NodeProfiles != nil {
+		in, out := &in.NodeProfiles, &out.NodeProfiles
+		*out = make([]DaemonSetNodeProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetSpec.
@@ -469,6 +703,13 @@ func (in *DaemonSetStatus) DeepCopyInto(out *DaemonSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]DaemonSetWaveStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetStatus.
@@ -481,6 +722,26 @@ func (in *DaemonSetStatus) DeepCopy() *DaemonSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetUnschedulableNodesStrategy) DeepCopyInto(out *DaemonSetUnschedulableNodesStrategy) {
+	*out = *in
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]UnschedulableNodeTaint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUnschedulableNodesStrategy.
+func (in *DaemonSetUnschedulableNodesStrategy) DeepCopy() *DaemonSetUnschedulableNodesStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUnschedulableNodesStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetUpdateStrategy) DeepCopyInto(out *DaemonSetUpdateStrategy) {
 	*out = *in
@@ -501,6 +762,50 @@ func (in *DaemonSetUpdateStrategy) DeepCopy() *DaemonSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetUpdateWave) DeepCopyInto(out *DaemonSetUpdateWave) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUpdateWave.
+func (in *DaemonSetUpdateWave) DeepCopy() *DaemonSetUpdateWave {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUpdateWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetWaveStatus) DeepCopyInto(out *DaemonSetWaveStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetWaveStatus.
+func (in *DaemonSetWaveStatus) DeepCopy() *DaemonSetWaveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetWaveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedImageStatus) DeepCopyInto(out *FailedImageStatus) {
 	*out = *in
@@ -519,6 +824,16 @@ func (in *FailedImageStatus) DeepCopy() *FailedImageStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
 	*out = *in
+	if in.BackoffLimitPerNode != nil {
+		in, out := &in.BackoffLimitPerNode, &out.BackoffLimitPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
@@ -888,6 +1203,26 @@ func (in *JobCondition) DeepCopy() *JobCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MissedSchedulePolicy) DeepCopyInto(out *MissedSchedulePolicy) {
+	*out = *in
+	if in.MaxBackfill != nil {
+		in, out := &in.MaxBackfill, &out.MaxBackfill
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MissedSchedulePolicy.
+func (in *MissedSchedulePolicy) DeepCopy() *MissedSchedulePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MissedSchedulePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeImage) DeepCopyInto(out *NodeImage) {
 	*out = *in
@@ -1042,12 +1377,12 @@ func (in *ResourceExpr) DeepCopyInto(out *ResourceExpr) {
 	if in.Limits != nil {
 		in, out := &in.Limits, &out.Limits
 		*out = new(ResourceExprLimits)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Requests != nil {
 		in, out := &in.Requests, &out.Requests
 		*out = new(ResourceExprRequests)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1064,6 +1399,13 @@ func (in *ResourceExpr) DeepCopy() *ResourceExpr {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceExprLimits) DeepCopyInto(out *ResourceExprLimits) {
 	*out = *in
+	if in.ExtendedResources != nil {
+		in, out := &in.ExtendedResources, &out.ExtendedResources
+		*out = make(map[corev1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceExprLimits.
@@ -1079,6 +1421,13 @@ func (in *ResourceExprLimits) DeepCopy() *ResourceExprLimits {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceExprRequests) DeepCopyInto(out *ResourceExprRequests) {
 	*out = *in
+	if in.ExtendedResources != nil {
+		in, out := &in.ExtendedResources, &out.ExtendedResources
+		*out = make(map[corev1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceExprRequests.
@@ -1107,6 +1456,56 @@ func (in *ResourcesPolicy) DeepCopy() *ResourcesPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAwareUpdateStrategy) DeepCopyInto(out *RoleAwareUpdateStrategy) {
+	*out = *in
+	if in.Switchover != nil {
+		in, out := &in.Switchover, &out.Switchover
+		*out = new(RoleSwitchoverAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SwitchoverTimeoutSeconds != nil {
+		in, out := &in.SwitchoverTimeoutSeconds, &out.SwitchoverTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSwitchoverAttempts != nil {
+		in, out := &in.MaxSwitchoverAttempts, &out.MaxSwitchoverAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAwareUpdateStrategy.
+func (in *RoleAwareUpdateStrategy) DeepCopy() *RoleAwareUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAwareUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSwitchoverAction) DeepCopyInto(out *RoleSwitchoverAction) {
+	*out = *in
+	if in.HTTPPost != nil {
+		in, out := &in.HTTPPost, &out.HTTPPost
+		*out = new(corev1.HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSwitchoverAction.
+func (in *RoleSwitchoverAction) DeepCopy() *RoleSwitchoverAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSwitchoverAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdateDaemonSet) DeepCopyInto(out *RollingUpdateDaemonSet) {
 	*out = *in
@@ -1135,6 +1534,18 @@ func (in *RollingUpdateDaemonSet) DeepCopyInto(out *RollingUpdateDaemonSet) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]DaemonSetUpdateWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnschedulableNodes != nil {
+		in, out := &in.UnschedulableNodes, &out.UnschedulableNodes
+		*out = new(DaemonSetUnschedulableNodesStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateDaemonSet.
@@ -1175,6 +1586,16 @@ func (in *RollingUpdateStatefulSetStrategy) DeepCopyInto(out *RollingUpdateState
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RoleAwareUpdate != nil {
+		in, out := &in.RoleAwareUpdate, &out.RoleAwareUpdate
+		*out = new(RoleAwareUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotBeforeUpdate != nil {
+		in, out := &in.SnapshotBeforeUpdate, &out.SnapshotBeforeUpdate
+		*out = new(SnapshotBeforeUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStatefulSetStrategy.
@@ -1308,6 +1729,22 @@ func (in *SidecarSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetCondition) DeepCopyInto(out *SidecarSetCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetCondition.
+func (in *SidecarSetCondition) DeepCopy() *SidecarSetCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSetInjectRevision) DeepCopyInto(out *SidecarSetInjectRevision) {
 	*out = *in
@@ -1480,6 +1917,13 @@ func (in *SidecarSetStatus) DeepCopyInto(out *SidecarSetStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]SidecarSetCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetStatus.
@@ -1492,6 +1936,26 @@ func (in *SidecarSetStatus) DeepCopy() *SidecarSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSetUpdateFailurePolicy) DeepCopyInto(out *SidecarSetUpdateFailurePolicy) {
+	*out = *in
+	if in.UnreadyThresholdSeconds != nil {
+		in, out := &in.UnreadyThresholdSeconds, &out.UnreadyThresholdSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetUpdateFailurePolicy.
+func (in *SidecarSetUpdateFailurePolicy) DeepCopy() *SidecarSetUpdateFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSetUpdateFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSetUpdateStrategy) DeepCopyInto(out *SidecarSetUpdateStrategy) {
 	*out = *in
@@ -1520,6 +1984,11 @@ func (in *SidecarSetUpdateStrategy) DeepCopyInto(out *SidecarSetUpdateStrategy)
 		*out = make(UpdateScatterStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(SidecarSetUpdateFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSetUpdateStrategy.
@@ -1532,6 +2001,26 @@ func (in *SidecarSetUpdateStrategy) DeepCopy() *SidecarSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotBeforeUpdateStrategy) DeepCopyInto(out *SnapshotBeforeUpdateStrategy) {
+	*out = *in
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotBeforeUpdateStrategy.
+func (in *SnapshotBeforeUpdateStrategy) DeepCopy() *SnapshotBeforeUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotBeforeUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceContainerNameSource) DeepCopyInto(out *SourceContainerNameSource) {
 	*out = *in
@@ -1682,7 +2171,7 @@ func (in *StatefulSetSpec) DeepCopyInto(out *StatefulSetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.VolumeClaimUpdateStrategy = in.VolumeClaimUpdateStrategy
+	in.VolumeClaimUpdateStrategy.DeepCopyInto(&out.VolumeClaimUpdateStrategy)
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
 	if in.RevisionHistoryLimit != nil {
 		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
@@ -1839,6 +2328,21 @@ func (in *UnorderedUpdateStrategy) DeepCopy() *UnorderedUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnschedulableNodeTaint) DeepCopyInto(out *UnschedulableNodeTaint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnschedulableNodeTaint.
+func (in *UnschedulableNodeTaint) DeepCopy() *UnschedulableNodeTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(UnschedulableNodeTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in UpdateScatterStrategy) DeepCopyInto(out *UpdateScatterStrategy) {
 	{
@@ -1873,6 +2377,27 @@ func (in *UpdateScatterTerm) DeepCopy() *UpdateScatterTerm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimDataMigration) DeepCopyInto(out *VolumeClaimDataMigration) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimDataMigration.
+func (in *VolumeClaimDataMigration) DeepCopy() *VolumeClaimDataMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimDataMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeClaimStatus) DeepCopyInto(out *VolumeClaimStatus) {
 	*out = *in
@@ -1891,6 +2416,11 @@ func (in *VolumeClaimStatus) DeepCopy() *VolumeClaimStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeClaimUpdateStrategy) DeepCopyInto(out *VolumeClaimUpdateStrategy) {
 	*out = *in
+	if in.DataMigration != nil {
+		in, out := &in.DataMigration, &out.DataMigration
+		*out = new(VolumeClaimDataMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimUpdateStrategy.
@@ -70,6 +70,38 @@ type PodUnavailableBudgetSpec struct {
 	// Delete pod, evict pod or update pod specification is allowed if at least "minAvailable" pods selected by
 	// "selector" or "targetRef" will still be available after the above operation for pod.
 	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// TopologyKey is the key of node labels. If it is not empty, pods are grouped into topology domains
+	// by the label value of the nodes they are running on, e.g. topology.kubernetes.io/zone, and "maxUnavailable"
+	// or "minAvailable" is enforced in each domain instead of all pods selected by "selector" or "targetRef".
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaintenanceWindows declares the time windows in which pods are allowed to be disrupted within
+	// "maxUnavailable" or "minAvailable". If it is not empty, the stricter "outOfWindowMaxUnavailable"
+	// takes effect outside the windows.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// OutOfWindowMaxUnavailable is the max number of pods that can be unavailable outside the maintenance windows.
+	// It only takes effect when maintenanceWindows is not empty, and defaults to 0, which means no pod is
+	// allowed to be disrupted outside the maintenance windows.
+	// +optional
+	OutOfWindowMaxUnavailable *intstr.IntOrString `json:"outOfWindowMaxUnavailable,omitempty"`
+}
+
+// MaintenanceWindow declares a recurring time window starting at the schedule and lasting for the duration.
+type MaintenanceWindow struct {
+	// Schedule is the start time of the window in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	Schedule string `json:"schedule"`
+
+	// Duration of the window, e.g. 2h.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone of the schedule, defaults to the time zone of kruise-manager.
+	// The name must be an explicit time zone as defined in https://www.iana.org/time-zones, e.g. Asia/Shanghai.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // TargetReference contains enough information to let you identify an workload for PodUnavailableBudget
@@ -110,6 +142,47 @@ type PodUnavailableBudgetStatus struct {
 
 	// TotalReplicas total number of pods counted by this unavailable budget
 	TotalReplicas int32 `json:"totalReplicas"`
+
+	// TopologyDomains contains the budget of each topology domain, only when topologyKey is set.
+	// Pods that are not scheduled yet are not counted in any domain, and unavailableAllowed is capped by the sum of the domains.
+	// +optional
+	TopologyDomains []PubTopologyDomainStatus `json:"topologyDomains,omitempty"`
+
+	// CurrentMaintenanceWindow is the maintenance window in effect,
+	// nil means that pods are protected by outOfWindowMaxUnavailable now.
+	// +optional
+	CurrentMaintenanceWindow *MaintenanceWindowStatus `json:"currentMaintenanceWindow,omitempty"`
+
+	// NextMaintenanceWindow is the next maintenance window after the current one.
+	// +optional
+	NextMaintenanceWindow *MaintenanceWindowStatus `json:"nextMaintenanceWindow,omitempty"`
+}
+
+// PubTopologyDomainStatus is the budget of pods in a topology domain.
+type PubTopologyDomainStatus struct {
+	// Domain is the value of topologyKey in node labels, empty for the nodes without the label.
+	Domain string `json:"domain"`
+
+	// UnavailableAllowed number of pod unavailable that are currently allowed in the domain
+	UnavailableAllowed int32 `json:"unavailableAllowed"`
+
+	// CurrentAvailable current number of available pods in the domain
+	CurrentAvailable int32 `json:"currentAvailable"`
+
+	// DesiredAvailable minimum desired number of available pods in the domain
+	DesiredAvailable int32 `json:"desiredAvailable"`
+
+	// TotalReplicas total number of pods in the domain, including the pods deleted from the domain and not replaced yet
+	TotalReplicas int32 `json:"totalReplicas"`
+}
+
+// MaintenanceWindowStatus is an occurrence of the maintenance windows.
+type MaintenanceWindowStatus struct {
+	// StartTime of the window.
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime of the window.
+	EndTime metav1.Time `json:"endTime"`
 }
 
 // +genclient
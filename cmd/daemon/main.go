@@ -34,6 +34,7 @@ import (
 
 	"github.com/openkruise/kruise/pkg/client"
 	"github.com/openkruise/kruise/pkg/daemon"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
 	"github.com/openkruise/kruise/pkg/features"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/secret"
@@ -85,6 +86,7 @@ func main() {
 		err = plugin.RegisterCredentialProviderPlugins(*pluginConfigFile, *pluginBinDir)
 		if err != nil {
 			klog.ErrorS(err, "Failed to register credential provider plugins")
+			daemonutil.RecordSecretLookupFailure(daemonutil.SecretLookupSourceCredentialPlugin)
 		}
 	} else if os.IsNotExist(err) {
 		klog.InfoS("No plugin config file found, skipping", "configFile", *pluginConfigFile)
@@ -42,6 +42,9 @@ type pubControl interface {
 	// 1. podList
 	// 2. expectedCount, the default is workload.Replicas
 	GetPodsForPub(pub *policyv1alpha1.PodUnavailableBudget) ([]*corev1.Pod, int32, error)
+	// GetPodTopologyDomain returns the topology domain of pod, i.e. the value of pub.spec.topologyKey in node labels.
+	// scheduled is false if the pod is not scheduled to any node yet.
+	GetPodTopologyDomain(pub *policyv1alpha1.PodUnavailableBudget, pod *corev1.Pod) (domain string, scheduled bool, err error)
 
 	// webhook
 	// determine if this change to pod might cause unavailability
@@ -340,6 +340,21 @@ func (c *commonControl) GetPubForPod(pod *corev1.Pod) (*policyv1alpha1.PodUnavai
 	return pub, nil
 }
 
+func (c *commonControl) GetPodTopologyDomain(pub *policyv1alpha1.PodUnavailableBudget, pod *corev1.Pod) (string, bool, error) {
+	if pod.Spec.NodeName == "" {
+		return "", false, nil
+	}
+	node := &corev1.Node{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) {
+			klog.InfoS("Pod node was NotFound", "pod", klog.KObj(pod), "nodeName", pod.Spec.NodeName)
+			return "", true, nil
+		}
+		return "", false, err
+	}
+	return node.Labels[pub.Spec.TopologyKey], true, nil
+}
+
 func (c *commonControl) GetPodControllerOf(pod *corev1.Pod) *metav1.OwnerReference {
 	return metav1.GetControllerOf(pod)
 }
@@ -91,6 +91,16 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1alpha1.P
 		klog.V(3).InfoS("Pod was already recorded in pub", "pod", klog.KObj(pod), "pub", klog.KObj(pub))
 		return true, "", nil
 	}
+	// the topology domain of pod, only when pub.spec.topologyKey is set
+	var domain *string
+	if pub.Spec.TopologyKey != "" {
+		podDomain, scheduled, err := PubControl.GetPodTopologyDomain(pub, pod)
+		if err != nil {
+			return false, "", err
+		} else if scheduled {
+			domain = &podDomain
+		}
+	}
 	// check and decrement pub quota
 	var conflictTimes int
 	var costOfGet, costOfUpdate time.Duration
@@ -138,7 +148,7 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1alpha1.P
 
 		// Try to verify-and-decrement
 		// If it was false already, or if it becomes false during the course of our retries,
-		err = checkAndDecrement(pod.Name, pubClone, operation)
+		err = checkAndDecrement(pod.Name, domain, pubClone, operation)
 		if err != nil {
 			var kind, namespace, name string
 			if ref := PubControl.GetPodControllerOf(pod); ref != nil {
@@ -201,15 +211,28 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1alpha1.P
 	return true, "", nil
 }
 
-func checkAndDecrement(podName string, pub *policyv1alpha1.PodUnavailableBudget, operation policyv1alpha1.PubOperation) error {
+func checkAndDecrement(podName string, domain *string, pub *policyv1alpha1.PodUnavailableBudget, operation policyv1alpha1.PubOperation) error {
 	if pub.Status.UnavailableAllowed <= 0 {
 		return errors.NewForbidden(policyv1alpha1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("pub unavailable allowed is negative"))
 	}
+	var domainStatus *policyv1alpha1.PubTopologyDomainStatus
+	if domain != nil {
+		domainStatus = getTopologyDomainStatus(pub, *domain)
+		if domainStatus == nil || domainStatus.UnavailableAllowed <= 0 {
+			return errors.NewForbidden(policyv1alpha1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("pub unavailable allowed in topology domain %q is negative", *domain))
+		}
+	}
+	if err := checkMaintenanceWindow(pub, time.Now()); err != nil {
+		return errors.NewForbidden(policyv1alpha1.Resource("podunavailablebudget"), pub.Name, err)
+	}
 	if len(pub.Status.DisruptedPods)+len(pub.Status.UnavailablePods) > MaxUnavailablePodSize {
 		return errors.NewForbidden(policyv1alpha1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("DisruptedPods and UnavailablePods map too big - too many unavailable not confirmed by PUB controller"))
 	}
 
 	pub.Status.UnavailableAllowed--
+	if domainStatus != nil {
+		domainStatus.UnavailableAllowed--
+	}
 
 	if pub.Status.DisruptedPods == nil {
 		pub.Status.DisruptedPods = make(map[string]metav1.Time)
@@ -228,6 +251,15 @@ func checkAndDecrement(podName string, pub *policyv1alpha1.PodUnavailableBudget,
 	return nil
 }
 
+func getTopologyDomainStatus(pub *policyv1alpha1.PodUnavailableBudget, domain string) *policyv1alpha1.PubTopologyDomainStatus {
+	for i := range pub.Status.TopologyDomains {
+		if pub.Status.TopologyDomains[i].Domain == domain {
+			return &pub.Status.TopologyDomains[i]
+		}
+	}
+	return nil
+}
+
 func isPodRecordedInPub(podName string, pub *policyv1alpha1.PodUnavailableBudget) bool {
 	if _, ok := pub.Status.UnavailablePods[podName]; ok {
 		return true
@@ -37,6 +37,7 @@ import (
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	policyv1alpha1 "github.com/openkruise/kruise/apis/policy/v1alpha1"
 	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
 	"github.com/openkruise/kruise/pkg/util/controllerfinder"
 	"github.com/openkruise/kruise/pkg/util/feature"
 )
@@ -223,6 +224,27 @@ func TestPodUnavailableBudgetValidatePod(t *testing.T) {
 				return pubStatus
 			},
 		},
+		{
+			name: "valid update pod, maintenance window ended, reject",
+			getPod: func() *corev1.Pod {
+				pod := podDemo.DeepCopy()
+				return pod
+			},
+			getPub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				pub.Status.UnavailableAllowed = 1
+				pub.Status.CurrentMaintenanceWindow = &policyv1alpha1.MaintenanceWindowStatus{
+					StartTime: metav1.NewTime(time.Now().Add(-time.Hour - time.Minute)),
+					EndTime:   metav1.NewTime(time.Now().Add(-time.Minute)),
+				}
+				return pub
+			},
+			operation:   policyv1alpha1.PubUpdateOperation,
+			expectAllow: false,
+		},
 		{
 			name: "valid update pod, pod deletion, ignore",
 			getPod: func() *corev1.Pod {
@@ -607,3 +629,60 @@ func TestIsNeedPubProtection(t *testing.T) {
 		})
 	}
 }
+
+func TestPodUnavailableBudgetValidatePodWithTopologyKey(t *testing.T) {
+	cases := []struct {
+		name        string
+		nodeZone    string
+		expectAllow bool
+	}{
+		{
+			name:        "unavailable allowed in topology domain, allow",
+			nodeZone:    "zone-a",
+			expectAllow: true,
+		},
+		{
+			name:        "no unavailable allowed in topology domain, reject",
+			nodeZone:    "zone-b",
+			expectAllow: false,
+		},
+		{
+			name:        "topology domain not observed yet, reject",
+			nodeZone:    "zone-c",
+			expectAllow: false,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub := pubDemo.DeepCopy()
+			pub.Spec.TopologyKey = corev1.LabelTopologyZone
+			pub.Status.UnavailableAllowed = 1
+			pub.Status.TopologyDomains = []policyv1alpha1.PubTopologyDomainStatus{
+				{Domain: "zone-a", UnavailableAllowed: 1, CurrentAvailable: 3, DesiredAvailable: 2, TotalReplicas: 3},
+				{Domain: "zone-b", UnavailableAllowed: 0, CurrentAvailable: 2, DesiredAvailable: 2, TotalReplicas: 3},
+			}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: map[string]string{corev1.LabelTopologyZone: cs.nodeZone},
+			}}
+			pod := podDemo.DeepCopy()
+			pod.Spec.NodeName = node.Name
+			// drop the pub cached by other cases
+			_ = util.GlobalCache.Delete(pub)
+			defer func() { _ = util.GlobalCache.Delete(pub) }()
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pub, node).
+				WithStatusSubresource(&policyv1alpha1.PodUnavailableBudget{}).Build()
+			finder := &controllerfinder.ControllerFinder{Client: fakeClient}
+			InitPubControl(fakeClient, finder, record.NewFakeRecorder(10))
+			allow, _, err := PodUnavailableBudgetValidatePod(pod, policyv1alpha1.PubDeleteOperation, "fake-user", false)
+			if err != nil {
+				t.Fatalf("PodUnavailableBudgetValidatePod failed: %s", err.Error())
+			}
+			if cs.expectAllow != allow {
+				t.Fatalf("expect allow %v, but get %v", cs.expectAllow, allow)
+			}
+		})
+	}
+}
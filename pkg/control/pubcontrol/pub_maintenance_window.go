@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	policyv1alpha1 "github.com/openkruise/kruise/apis/policy/v1alpha1"
+)
+
+// ParseMaintenanceWindowSchedule parses the cron schedule of the maintenance window in its time zone.
+func ParseMaintenanceWindowSchedule(window *policyv1alpha1.MaintenanceWindow) (sched cron.Schedule, err error) {
+	schedule := window.Schedule
+	if window.TimeZone != nil {
+		if _, err = time.LoadLocation(*window.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %s: %v", *window.TimeZone, err)
+		}
+		schedule = fmt.Sprintf("TZ=%s %s", *window.TimeZone, window.Schedule)
+	}
+	// robfig/cron may panic on some malformed schedules
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid cron schedule %s: %v", window.Schedule, r)
+		}
+	}()
+	return cron.ParseStandard(schedule)
+}
+
+// GetMaintenanceWindows returns the maintenance window of pub in effect at now, and the next window after it.
+// Both of them are nil if pub has no maintenance windows.
+func GetMaintenanceWindows(pub *policyv1alpha1.PodUnavailableBudget, now time.Time) (current, next *policyv1alpha1.MaintenanceWindowStatus, err error) {
+	for i := range pub.Spec.MaintenanceWindows {
+		window := &pub.Spec.MaintenanceWindows[i]
+		sched, err := ParseMaintenanceWindowSchedule(window)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the first start in (now-duration, now] means the window is open now
+		start := sched.Next(now.Add(-window.Duration.Duration))
+		if !start.After(now) {
+			end := start.Add(window.Duration.Duration)
+			if current == nil || end.After(current.EndTime.Time) {
+				current = &policyv1alpha1.MaintenanceWindowStatus{StartTime: metav1.NewTime(start), EndTime: metav1.NewTime(end)}
+			}
+		}
+	}
+
+	// the next window starts after the current one ends
+	after := now
+	if current != nil {
+		after = current.EndTime.Time
+	}
+	for i := range pub.Spec.MaintenanceWindows {
+		window := &pub.Spec.MaintenanceWindows[i]
+		sched, _ := ParseMaintenanceWindowSchedule(window)
+		start := sched.Next(after)
+		if start.IsZero() {
+			continue
+		}
+		if next == nil || start.Before(next.StartTime.Time) {
+			next = &policyv1alpha1.MaintenanceWindowStatus{StartTime: metav1.NewTime(start), EndTime: metav1.NewTime(start.Add(window.Duration.Duration))}
+		}
+	}
+	return current, next, nil
+}
+
+// GetOutOfWindowMaxUnavailable returns the budget of pub outside the maintenance windows.
+func GetOutOfWindowMaxUnavailable(pub *policyv1alpha1.PodUnavailableBudget) *intstr.IntOrString {
+	if pub.Spec.OutOfWindowMaxUnavailable != nil {
+		return pub.Spec.OutOfWindowMaxUnavailable
+	}
+	maxUnavailable := intstr.FromInt32(0)
+	return &maxUnavailable
+}
+
+// checkMaintenanceWindow forbids disruptions when the maintenance window recorded in pub status has ended,
+// since unavailableAllowed in status is still calculated with the budget inside the window until pub is reconciled.
+func checkMaintenanceWindow(pub *policyv1alpha1.PodUnavailableBudget, now time.Time) error {
+	if len(pub.Spec.MaintenanceWindows) == 0 || pub.Status.CurrentMaintenanceWindow == nil {
+		return nil
+	}
+	if end := pub.Status.CurrentMaintenanceWindow.EndTime; !now.Before(end.Time) {
+		return fmt.Errorf("pub maintenance window has ended at %s", end.Format(time.RFC3339))
+	}
+	return nil
+}
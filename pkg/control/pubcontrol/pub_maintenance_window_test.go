@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	policyv1alpha1 "github.com/openkruise/kruise/apis/policy/v1alpha1"
+)
+
+func TestGetMaintenanceWindows(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	cases := []struct {
+		name          string
+		windows       []policyv1alpha1.MaintenanceWindow
+		now           time.Time
+		expectCurrent *policyv1alpha1.MaintenanceWindowStatus
+		expectNext    *policyv1alpha1.MaintenanceWindowStatus
+		expectErr     bool
+	}{
+		{
+			name: "no maintenance windows",
+			now:  time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "in maintenance window",
+			windows: []policyv1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: ptr.To("UTC")},
+			},
+			now: time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC),
+			expectCurrent: &policyv1alpha1.MaintenanceWindowStatus{
+				StartTime: metav1.NewTime(time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC)),
+				EndTime:   metav1.NewTime(time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC)),
+			},
+			expectNext: &policyv1alpha1.MaintenanceWindowStatus{
+				StartTime: metav1.NewTime(time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC)),
+				EndTime:   metav1.NewTime(time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "out of maintenance window",
+			windows: []policyv1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: ptr.To("UTC")},
+			},
+			now: time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC),
+			expectNext: &policyv1alpha1.MaintenanceWindowStatus{
+				StartTime: metav1.NewTime(time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC)),
+				EndTime:   metav1.NewTime(time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "multiple maintenance windows in time zone",
+			windows: []policyv1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: ptr.To("Asia/Shanghai")},
+				{Schedule: "30 2 * * 0", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: ptr.To("Asia/Shanghai")},
+			},
+			// Sunday
+			now: time.Date(2025, 6, 1, 2, 45, 0, 0, shanghai),
+			expectCurrent: &policyv1alpha1.MaintenanceWindowStatus{
+				StartTime: metav1.NewTime(time.Date(2025, 6, 1, 2, 30, 0, 0, shanghai)),
+				EndTime:   metav1.NewTime(time.Date(2025, 6, 1, 5, 30, 0, 0, shanghai)),
+			},
+			expectNext: &policyv1alpha1.MaintenanceWindowStatus{
+				StartTime: metav1.NewTime(time.Date(2025, 6, 2, 2, 0, 0, 0, shanghai)),
+				EndTime:   metav1.NewTime(time.Date(2025, 6, 2, 3, 0, 0, 0, shanghai)),
+			},
+		},
+		{
+			name: "invalid schedule",
+			windows: []policyv1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			now:       time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub := pubDemo.DeepCopy()
+			pub.Spec.MaintenanceWindows = cs.windows
+			current, next, err := GetMaintenanceWindows(pub, cs.now)
+			if (err != nil) != cs.expectErr {
+				t.Fatalf("expect error %v, but got %v", cs.expectErr, err)
+			}
+			if !isMaintenanceWindowEqual(cs.expectCurrent, current) {
+				t.Fatalf("expect current window %v, but got %v", cs.expectCurrent, current)
+			}
+			if !isMaintenanceWindowEqual(cs.expectNext, next) {
+				t.Fatalf("expect next window %v, but got %v", cs.expectNext, next)
+			}
+		})
+	}
+}
+
+func isMaintenanceWindowEqual(expect, actual *policyv1alpha1.MaintenanceWindowStatus) bool {
+	if expect == nil || actual == nil {
+		return expect == actual
+	}
+	return expect.StartTime.Equal(&actual.StartTime) && expect.EndTime.Equal(&actual.EndTime)
+}
@@ -623,3 +623,46 @@ func listSidecarNameInSidecarSet(sidecarSet *appsv1beta1.SidecarSet) sets.String
 	}
 	return sidecarList
 }
+
+var nonIdentifierCharRegexp = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// GetResourceExpressionVariable returns the variable name referring to the aggregated value of the resource
+// in resourcesPolicy expressions, e.g. cpu, ephemeral_storage, nvidia_com_gpu.
+func GetResourceExpressionVariable(name corev1.ResourceName) string {
+	return strings.ToLower(nonIdentifierCharRegexp.ReplaceAllString(string(name), "_"))
+}
+
+// GetResourceExprLimits returns the non-empty limits expressions of resourcesPolicy keyed by resource name.
+func GetResourceExprLimits(limits *appsv1beta1.ResourceExprLimits) map[corev1.ResourceName]string {
+	if limits == nil {
+		return nil
+	}
+	return buildResourceExpressions(limits.CPU, limits.Memory, limits.EphemeralStorage, limits.ExtendedResources)
+}
+
+// GetResourceExprRequests returns the non-empty requests expressions of resourcesPolicy keyed by resource name.
+func GetResourceExprRequests(requests *appsv1beta1.ResourceExprRequests) map[corev1.ResourceName]string {
+	if requests == nil {
+		return nil
+	}
+	return buildResourceExpressions(requests.CPU, requests.Memory, requests.EphemeralStorage, requests.ExtendedResources)
+}
+
+func buildResourceExpressions(cpu, memory, ephemeralStorage string, extended map[corev1.ResourceName]string) map[corev1.ResourceName]string {
+	expressions := make(map[corev1.ResourceName]string, 3+len(extended))
+	for name, expr := range extended {
+		if expr != "" {
+			expressions[name] = expr
+		}
+	}
+	for name, expr := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:              cpu,
+		corev1.ResourceMemory:           memory,
+		corev1.ResourceEphemeralStorage: ephemeralStorage,
+	} {
+		if expr != "" {
+			expressions[name] = expr
+		}
+	}
+	return expressions
+}
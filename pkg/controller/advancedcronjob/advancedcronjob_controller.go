@@ -179,19 +179,19 @@ func (r *ReconcileAdvancedCronJob) updateAdvancedJobStatus(request reconcile.Req
 	klog.V(1).InfoS("Updating job status", "advancedCronJob", klog.KObj(advancedCronJob), "status", advancedCronJob.Status)
 	advancedCronJobCopy := advancedCronJob.DeepCopy()
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		err := r.Status().Update(context.TODO(), advancedCronJobCopy)
-		if err == nil {
+		updateErr := r.Status().Update(context.TODO(), advancedCronJobCopy)
+		if updateErr == nil {
 			return nil
 		}
 
 		updated := &appsv1beta1.AdvancedCronJob{}
-		err = r.Get(context.TODO(), request.NamespacedName, updated)
-		if err == nil {
+		if err := r.Get(context.TODO(), request.NamespacedName, updated); err == nil {
 			advancedCronJobCopy = updated
 			advancedCronJobCopy.Status = advancedCronJob.Status
 		} else {
 			utilruntime.HandleError(fmt.Errorf("error getting updated advancedCronJob %s/%s from lister: %v", advancedCronJob.Namespace, advancedCronJob.Name, err))
 		}
-		return err
+		// return the error of update, so that it will be retried on conflict
+		return updateErr
 	})
 }
@@ -20,7 +20,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -97,15 +96,19 @@ func (r *ReconcileAdvancedCronJob) reconcileJob(ctx context.Context, req ctrl.Re
 
 	// +kubebuilder:docs-gen:collapse=getScheduledTimeForJob
 
+	var runs []appsv1beta1.AdvancedCronJobRunRecord
 	for i, job := range childJobs.Items {
 		_, finishedType := isJobFinished(&job)
+		runPhase := appsv1beta1.AdvancedCronJobRunRunning
 		switch finishedType {
 		case "": // ongoing
 			activeJobs = append(activeJobs, &childJobs.Items[i])
 		case batchv1.JobFailed:
 			failedJobs = append(failedJobs, &childJobs.Items[i])
+			runPhase = appsv1beta1.AdvancedCronJobRunFailed
 		case batchv1.JobComplete:
 			successfulJobs = append(successfulJobs, &childJobs.Items[i])
+			runPhase = appsv1beta1.AdvancedCronJobRunSucceeded
 		}
 
 		// We'll store the launch time in an annotation, so we'll reconstitute that from
@@ -121,6 +124,13 @@ func (r *ReconcileAdvancedCronJob) reconcileJob(ctx context.Context, req ctrl.Re
 			} else if mostRecentTime.Before(*scheduledTimeForJob) {
 				mostRecentTime = scheduledTimeForJob
 			}
+
+			record, err := r.newRunRecord(&childJobs.Items[i], *scheduledTimeForJob, runPhase, job.Status.StartTime, getJobCompletionTime(&childJobs.Items[i]))
+			if err != nil {
+				klog.ErrorS(err, "Unable to make run record for child Job", "job", klog.KObj(&job), "advancedCronJob", req)
+				continue
+			}
+			runs = append(runs, *record)
 		}
 	}
 
@@ -140,6 +150,7 @@ func (r *ReconcileAdvancedCronJob) reconcileJob(ctx context.Context, req ctrl.Re
 		advancedCronJob.Status.Active = append(advancedCronJob.Status.Active, *jobRef)
 	}
 
+	updateRunHistory(&advancedCronJob, runs)
 	klog.V(1).InfoS("Job count", "activeJobCount", len(activeJobs), "successfulJobCount", len(successfulJobs), "failedJobCount", len(failedJobs), "advancedCronJob", req)
 	if err := r.updateAdvancedJobStatus(req, &advancedCronJob); err != nil {
 		klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
@@ -217,67 +228,13 @@ func (r *ReconcileAdvancedCronJob) reconcileJob(ctx context.Context, req ctrl.Re
 		of the CronJob if we can't find a last run.
 		If there are too many missed runs and we don't have any deadlines set, we'll
 		bail so that we don't cause issues on controller restarts or wedges.
-		Otherwise, we'll just return the missed runs (of which we'll just use the latest),
-		and the next run, so that we can know when it's time to reconcile again.
+		Otherwise, we'll return the missed runs, of which we'll pick one according to the
+		missed schedule policy, and the next run, so that we can know when it's time to reconcile again.
 	*/
-	getNextSchedule := func(cronJob *appsv1beta1.AdvancedCronJob, now time.Time) (lastMissed time.Time, next time.Time, err error) {
-		sched, err := cron.ParseStandard(formatSchedule(cronJob))
-		if err != nil {
-			return time.Time{}, time.Time{}, fmt.Errorf("unparsable schedule %q: %v", cronJob.Spec.Schedule, err)
-		}
-
-		// for optimization purposes, cheat a bit and start from our last observed run time
-		// we could reconstitute this here, but there's not much point, since we've
-		// just updated it.
-		var earliestTime time.Time
-		if cronJob.Status.LastScheduleTime != nil {
-			earliestTime = cronJob.Status.LastScheduleTime.Time
-		} else {
-			earliestTime = cronJob.ObjectMeta.CreationTimestamp.Time
-		}
-		if cronJob.Spec.StartingDeadlineSeconds != nil {
-			// controller is not going to schedule anything below this point
-			schedulingDeadline := now.Add(-time.Second * time.Duration(*cronJob.Spec.StartingDeadlineSeconds))
-
-			if schedulingDeadline.After(earliestTime) {
-				earliestTime = schedulingDeadline
-			}
-		}
-		if earliestTime.After(now) {
-			return time.Time{}, sched.Next(now), nil
-		}
-
-		starts := 0
-		for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
-			lastMissed = t
-			// An object might miss several starts. For example, if
-			// controller gets wedged on Friday at 5:01pm when everyone has
-			// gone home, and someone comes in on Tuesday AM and discovers
-			// the problem and restarts the controller, then all the hourly
-			// jobs, more than 80 of them for one hourly scheduledJob, should
-			// all start running with no further intervention (if the scheduledJob
-			// allows concurrency and late starts).
-			//
-			// However, if there is a bug somewhere, or incorrect clock
-			// on controller's server or apiservers (for setting creationTimestamp)
-			// then there could be so many missed start times (it could be off
-			// by decades or more), that it would eat up all the CPU and memory
-			// of this controller. In that case, we want to not try to list
-			// all the missed start times.
-			starts++
-			if starts > 100 {
-				// We can't get the most recent times so just return an empty slice
-				return time.Time{}, time.Time{}, fmt.Errorf("too many missed start times (> 100). Set or decrease .spec.startingDeadlineSeconds or check clock skew")
-			}
-		}
-		return lastMissed, sched.Next(now), nil
-	}
-	// +kubebuilder:docs-gen:collapse=getNextSchedule
-
 	// figure out the next times that we need to create
 	// jobs at (or anything we missed).
 	now := realClock{}.Now()
-	missedRun, nextRun, err := getNextSchedule(&advancedCronJob, now)
+	missedRuns, nextRun, err := getNextSchedule(&advancedCronJob, now)
 	if err != nil {
 		klog.ErrorS(err, "Unable to figure out CronJob schedule", "advancedCronJob", req)
 		// we don't really care about requeuing until we get an update that
@@ -291,6 +248,16 @@ func (r *ReconcileAdvancedCronJob) reconcileJob(ctx context.Context, req ctrl.Re
 	*/
 	scheduledResult := ctrl.Result{RequeueAfter: nextRun.Sub(now)} // save this so we can re-use it elsewhere
 
+	// the missed schedule policy decides which one to run, or skip them all
+	missedRun, skippedRuns := selectScheduledTime(&advancedCronJob, missedRuns, now)
+	if len(skippedRuns) > 0 {
+		klog.V(1).InfoS("Skipped missed schedules", "skippedCount", len(skippedRuns), "advancedCronJob", req)
+		if err := r.skipMissedSchedules(req, &advancedCronJob, skippedRuns); err != nil {
+			klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
+			return ctrl.Result{}, err
+		}
+	}
+
 	/*
 		### 6: Run a new job if it's on schedule, not past the deadline, and not blocked by our concurrency policy
 		If we've missed a run, and we're still within the deadline to start it, we'll need to run a job.
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// getNextSchedule returns the scheduled times not run yet in order, and the next scheduled time.
+// It starts from the last run or skipped schedule, or the creation of the CronJob if nothing found.
+func getNextSchedule(cronJob *appsv1beta1.AdvancedCronJob, now time.Time) (missedRuns []time.Time, next time.Time, err error) {
+	sched, err := cron.ParseStandard(formatSchedule(cronJob))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unparsable schedule %q: %v", cronJob.Spec.Schedule, err)
+	}
+
+	// for optimization purposes, cheat a bit and start from our last observed run time
+	// we could reconstitute this here, but there's not much point, since we've
+	// just updated it.
+	var earliestTime time.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		earliestTime = cronJob.Status.LastScheduleTime.Time
+	} else {
+		earliestTime = cronJob.ObjectMeta.CreationTimestamp.Time
+	}
+	if cronJob.Status.LastSkippedScheduleTime != nil && cronJob.Status.LastSkippedScheduleTime.Time.After(earliestTime) {
+		// the schedules skipped by the missed schedule policy will not be run any more
+		earliestTime = cronJob.Status.LastSkippedScheduleTime.Time
+	}
+	if cronJob.Spec.StartingDeadlineSeconds != nil {
+		// controller is not going to schedule anything below this point
+		schedulingDeadline := now.Add(-time.Second * time.Duration(*cronJob.Spec.StartingDeadlineSeconds))
+
+		if schedulingDeadline.After(earliestTime) {
+			earliestTime = schedulingDeadline
+		}
+	}
+	if earliestTime.After(now) {
+		return nil, sched.Next(now), nil
+	}
+
+	for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		missedRuns = append(missedRuns, t)
+		// An object might miss several starts. For example, if
+		// controller gets wedged on Friday at 5:01pm when everyone has
+		// gone home, and someone comes in on Tuesday AM and discovers
+		// the problem and restarts the controller, then all the hourly
+		// jobs, more than 80 of them for one hourly scheduledJob, should
+		// all start running with no further intervention (if the scheduledJob
+		// allows concurrency and late starts).
+		//
+		// However, if there is a bug somewhere, or incorrect clock
+		// on controller's server or apiservers (for setting creationTimestamp)
+		// then there could be so many missed start times (it could be off
+		// by decades or more), that it would eat up all the CPU and memory
+		// of this controller. In that case, we want to not try to list
+		// all the missed start times.
+		if len(missedRuns) > 100 {
+			// We can't get the most recent times so just return an empty slice
+			return nil, time.Time{}, fmt.Errorf("too many missed start times (> 100). Set or decrease .spec.startingDeadlineSeconds or check clock skew")
+		}
+	}
+	return missedRuns, sched.Next(now), nil
+}
+
+// defaultMissedScheduleTolerance is how late the only missed schedule can still be run by the Skip policy,
+// if startingDeadlineSeconds is not set.
+const defaultMissedScheduleTolerance = time.Minute
+
+// selectScheduledTime returns the scheduled time to run from the missed ones according to the missed schedule policy,
+// and the ones to skip. The zero time means nothing to run.
+func selectScheduledTime(cronJob *appsv1beta1.AdvancedCronJob, missedRuns []time.Time, now time.Time) (time.Time, []time.Time) {
+	if len(missedRuns) == 0 {
+		return time.Time{}, nil
+	}
+	policy := cronJob.Spec.MissedSchedulePolicy
+	if policy == nil {
+		return missedRuns[len(missedRuns)-1], nil
+	}
+
+	switch policy.Type {
+	case appsv1beta1.MissedSchedulePolicySkip:
+		tolerance := defaultMissedScheduleTolerance
+		if cronJob.Spec.StartingDeadlineSeconds != nil {
+			tolerance = time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second
+		}
+		if len(missedRuns) > 1 || now.Sub(missedRuns[0]) > tolerance {
+			return time.Time{}, missedRuns
+		}
+	case appsv1beta1.MissedSchedulePolicyBackfill:
+		maxBackfill := 1
+		if policy.MaxBackfill != nil {
+			maxBackfill = int(*policy.MaxBackfill)
+		}
+		// the older ones beyond maxBackfill are skipped, and the rest will be run one by one from the oldest
+		var skipped []time.Time
+		if len(missedRuns) > maxBackfill+1 {
+			skipped = missedRuns[:len(missedRuns)-maxBackfill-1]
+			missedRuns = missedRuns[len(missedRuns)-maxBackfill-1:]
+		}
+		return missedRuns[0], skipped
+	}
+	return missedRuns[len(missedRuns)-1], nil
+}
+
+// skipMissedSchedules records the latest skipped schedule in status, so that the skipped ones will not be considered again.
+func (r *ReconcileAdvancedCronJob) skipMissedSchedules(req ctrl.Request, cronJob *appsv1beta1.AdvancedCronJob, skipped []time.Time) error {
+	latest := skipped[len(skipped)-1]
+	cronJob.Status.LastSkippedScheduleTime = &metav1.Time{Time: latest}
+	r.recorder.Eventf(cronJob, corev1.EventTypeNormal, "MissedSchedulesSkipped",
+		"Skipped %d missed schedules, the latest at %s", len(skipped), latest.Format(time.RFC3339))
+	return r.updateAdvancedJobStatus(req, cronJob)
+}
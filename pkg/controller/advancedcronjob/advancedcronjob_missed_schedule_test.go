@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestGetNextSchedule(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	acj := createJob("job1", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(now.Add(-12 * time.Minute))
+
+	missedRuns, next, err := getNextSchedule(acj, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(-10 * time.Minute), now.Add(-5 * time.Minute), now}, missedRuns)
+	assert.Equal(t, now.Add(5*time.Minute), next)
+
+	acj.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	acj.Status.LastSkippedScheduleTime = &metav1.Time{Time: now.Add(-5 * time.Minute)}
+	missedRuns, _, err = getNextSchedule(acj, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{now}, missedRuns)
+
+	acj.CreationTimestamp = metav1.NewTime(now.Add(-24 * time.Hour))
+	acj.Status = appsv1beta1.AdvancedCronJobStatus{}
+	_, _, err = getNextSchedule(acj, now)
+	assert.Error(t, err)
+}
+
+func TestSelectScheduledTime(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	missedRuns := []time.Time{now.Add(-15 * time.Minute), now.Add(-10 * time.Minute), now.Add(-5 * time.Minute), now}
+
+	tests := []struct {
+		name            string
+		policy          *appsv1beta1.MissedSchedulePolicy
+		deadlineSeconds *int64
+		missedRuns      []time.Time
+		expectRun       time.Time
+		expectSkipped   []time.Time
+	}{
+		{
+			name:       "nothing missed",
+			policy:     &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			missedRuns: nil,
+		},
+		{
+			name:       "run the latest without policy",
+			missedRuns: missedRuns,
+			expectRun:  now,
+		},
+		{
+			name:       "run the latest for run once",
+			policy:     &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyRunOnce},
+			missedRuns: missedRuns,
+			expectRun:  now,
+		},
+		{
+			name:       "run the only one for skip",
+			policy:     &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			missedRuns: missedRuns[3:],
+			expectRun:  now,
+		},
+		{
+			name:          "skip the only late one for skip",
+			policy:        &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			missedRuns:    missedRuns[2:3],
+			expectSkipped: missedRuns[2:3],
+		},
+		{
+			name:            "run the only one within starting deadline for skip",
+			policy:          &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			deadlineSeconds: ptr.To(int64(600)),
+			missedRuns:      missedRuns[2:3],
+			expectRun:       now.Add(-5 * time.Minute),
+		},
+		{
+			name:          "skip all for skip",
+			policy:        &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			missedRuns:    missedRuns,
+			expectSkipped: missedRuns,
+		},
+		{
+			name:          "backfill from the oldest within max backfill",
+			policy:        &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: ptr.To(int32(2))},
+			missedRuns:    missedRuns,
+			expectRun:     now.Add(-10 * time.Minute),
+			expectSkipped: missedRuns[:1],
+		},
+		{
+			name:       "backfill from the oldest",
+			policy:     &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: ptr.To(int32(10))},
+			missedRuns: missedRuns,
+			expectRun:  now.Add(-15 * time.Minute),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			acj := createJob("job1", jobTemplate())
+			acj.Spec.MissedSchedulePolicy = tc.policy
+			acj.Spec.StartingDeadlineSeconds = tc.deadlineSeconds
+			run, skipped := selectScheduledTime(acj, tc.missedRuns, now)
+			assert.Equal(t, tc.expectRun, run)
+			assert.Equal(t, tc.expectSkipped, skipped)
+		})
+	}
+}
+
+// Test scenario:
+// the last run was 25 minutes ago and 5 schedules missed since then
+// Skip policy creates nothing, RunOnce policy creates the latest one, and Backfill policy creates the oldest one within maxBackfill
+// and skips the older ones
+// the run history records the last run, and marks the running one no longer existing as deleted
+func TestReconcileAdvancedJobMissedSchedules(t *testing.T) {
+	tests := []struct {
+		name                  string
+		policy                *appsv1beta1.MissedSchedulePolicy
+		expectCreatedDiff     int
+		expectSkipped         bool
+		expectLastSkippedDiff *int
+	}{
+		{
+			name:          "skip",
+			policy:        &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+			expectSkipped: true,
+		},
+		{
+			name:              "run once",
+			policy:            &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyRunOnce},
+			expectCreatedDiff: 0,
+		},
+		{
+			name:                  "backfill",
+			policy:                &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: ptr.To(int32(2))},
+			expectCreatedDiff:     -10,
+			expectLastSkippedDiff: ptr.To(-15),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			now := fakeClock.Now()
+			acj := createJob("job1", imageListPullJobTemplate())
+			acj.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+			acj.Spec.MissedSchedulePolicy = tc.policy
+			acj.Spec.RunHistoryLimit = ptr.To(int32(5))
+			acj.Status.RunHistory = []appsv1beta1.AdvancedCronJobRunRecord{{
+				ScheduledTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+				Object:        v1.ObjectReference{Kind: "ImageListPullJob", Namespace: "default", Name: "job1-old"},
+				Phase:         appsv1beta1.AdvancedCronJobRunRunning,
+			}}
+			lastJob := createImageListPullJob(-25, 2, 2, 2, acj)
+
+			reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, acj, lastJob)
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job1", Namespace: "default"}}
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+
+			retrievedJob := &appsv1beta1.AdvancedCronJob{}
+			assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+			assert.Equal(t, 2, len(retrievedJob.Status.RunHistory))
+			assert.Equal(t, lastJob.Name, retrievedJob.Status.RunHistory[0].Object.Name)
+			assert.Equal(t, appsv1beta1.AdvancedCronJobRunSucceeded, retrievedJob.Status.RunHistory[0].Phase)
+			assert.Equal(t, &metav1.Duration{}, retrievedJob.Status.RunHistory[0].Duration)
+			assert.Equal(t, "job1-old", retrievedJob.Status.RunHistory[1].Object.Name)
+			assert.Equal(t, appsv1beta1.AdvancedCronJobRunDeleted, retrievedJob.Status.RunHistory[1].Phase)
+
+			jobList := &appsv1beta1.ImageListPullJobList{}
+			assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+			if tc.expectSkipped {
+				assert.Equal(t, 1, len(jobList.Items))
+				assert.NotNil(t, retrievedJob.Status.LastSkippedScheduleTime)
+				assert.True(t, now.Equal(retrievedJob.Status.LastSkippedScheduleTime.Time))
+				return
+			}
+			if tc.expectLastSkippedDiff != nil {
+				assert.NotNil(t, retrievedJob.Status.LastSkippedScheduleTime)
+				assert.True(t, now.Add(time.Duration(*tc.expectLastSkippedDiff)*time.Minute).Equal(retrievedJob.Status.LastSkippedScheduleTime.Time))
+			} else {
+				assert.Nil(t, retrievedJob.Status.LastSkippedScheduleTime)
+			}
+			assert.Equal(t, 2, len(jobList.Items))
+			expectName := fmt.Sprintf("job1-%d", now.Add(time.Duration(tc.expectCreatedDiff)*time.Minute).Unix())
+			var created bool
+			for _, job := range jobList.Items {
+				if job.Name == expectName {
+					created = true
+				}
+			}
+			assert.True(t, created, "%s not created", expectName)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ref "k8s.io/client-go/tools/reference"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// newRunRecord returns the record of run for the object created by the CronJob.
+func (r *ReconcileAdvancedCronJob) newRunRecord(obj runtime.Object, scheduledTime time.Time, phase appsv1beta1.AdvancedCronJobRunPhase,
+	startTime, completionTime *metav1.Time) (*appsv1beta1.AdvancedCronJobRunRecord, error) {
+	objRef, err := ref.GetReference(r.scheme, obj)
+	if err != nil {
+		return nil, err
+	}
+	record := &appsv1beta1.AdvancedCronJobRunRecord{
+		ScheduledTime: metav1.Time{Time: scheduledTime},
+		Object:        *objRef,
+		Phase:         phase,
+		StartTime:     startTime,
+	}
+	if phase != appsv1beta1.AdvancedCronJobRunRunning {
+		record.CompletionTime = completionTime
+	}
+	if record.StartTime != nil && record.CompletionTime != nil {
+		record.Duration = &metav1.Duration{Duration: record.CompletionTime.Sub(record.StartTime.Time)}
+	}
+	return record, nil
+}
+
+// getJobCompletionTime returns the time at which the Job completed or failed.
+func getJobCompletionTime(job *batchv1.Job) *metav1.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime
+	}
+	for i := range job.Status.Conditions {
+		c := &job.Status.Conditions[i]
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return &c.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// updateRunHistory merges the records of current objects into the run history, the newest first and bounded
+// by spec.runHistoryLimit. The records of objects no longer existing are kept, and marked as deleted if not finished.
+func updateRunHistory(cronJob *appsv1beta1.AdvancedCronJob, runs []appsv1beta1.AdvancedCronJobRunRecord) {
+	if cronJob.Spec.RunHistoryLimit == nil || *cronJob.Spec.RunHistoryLimit <= 0 {
+		cronJob.Status.RunHistory = nil
+		return
+	}
+
+	current := make(map[string]struct{}, len(runs))
+	history := make([]appsv1beta1.AdvancedCronJobRunRecord, 0, len(runs)+len(cronJob.Status.RunHistory))
+	for _, run := range runs {
+		current[run.Object.Name] = struct{}{}
+		history = append(history, run)
+	}
+	for _, record := range cronJob.Status.RunHistory {
+		if _, ok := current[record.Object.Name]; ok {
+			continue
+		}
+		if record.Phase == appsv1beta1.AdvancedCronJobRunRunning {
+			record.Phase = appsv1beta1.AdvancedCronJobRunDeleted
+		}
+		history = append(history, record)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[j].ScheduledTime.Before(&history[i].ScheduledTime)
+	})
+	if limit := int(*cronJob.Spec.RunHistoryLimit); len(history) > limit {
+		history = history[:limit]
+	}
+	cronJob.Status.RunHistory = history
+}
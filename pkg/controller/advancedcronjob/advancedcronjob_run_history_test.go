@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestNewRunRecord(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	r := &ReconcileAdvancedCronJob{scheme: scheme}
+
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	startTime := metav1.NewTime(now.Add(time.Second))
+	failedTime := metav1.NewTime(now.Add(time.Minute))
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job1-1", UID: "uid1"},
+		Status: batchv1.JobStatus{
+			StartTime:  &startTime,
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, LastTransitionTime: failedTime}},
+		},
+	}
+
+	record, err := r.newRunRecord(job, now, appsv1beta1.AdvancedCronJobRunFailed, job.Status.StartTime, getJobCompletionTime(job))
+	assert.NoError(t, err)
+	assert.Equal(t, "Job", record.Object.Kind)
+	assert.Equal(t, "job1-1", record.Object.Name)
+	assert.Equal(t, &failedTime, record.CompletionTime)
+	assert.Equal(t, &metav1.Duration{Duration: 59 * time.Second}, record.Duration)
+
+	record, err = r.newRunRecord(job, now, appsv1beta1.AdvancedCronJobRunRunning, job.Status.StartTime, getJobCompletionTime(job))
+	assert.NoError(t, err)
+	assert.Nil(t, record.CompletionTime)
+	assert.Nil(t, record.Duration)
+}
+
+func TestUpdateRunHistory(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	newRecord := func(minutes int, phase appsv1beta1.AdvancedCronJobRunPhase) appsv1beta1.AdvancedCronJobRunRecord {
+		return appsv1beta1.AdvancedCronJobRunRecord{
+			ScheduledTime: metav1.NewTime(now.Add(time.Duration(minutes) * time.Minute)),
+			Object:        v1.ObjectReference{Name: fmt.Sprintf("job-%d", minutes)},
+			Phase:         phase,
+		}
+	}
+
+	acj := createJob("job1", jobTemplate())
+	acj.Status.RunHistory = []appsv1beta1.AdvancedCronJobRunRecord{
+		newRecord(-5, appsv1beta1.AdvancedCronJobRunRunning),
+		newRecord(-10, appsv1beta1.AdvancedCronJobRunRunning),
+		newRecord(-15, appsv1beta1.AdvancedCronJobRunSucceeded),
+		newRecord(-20, appsv1beta1.AdvancedCronJobRunFailed),
+	}
+	updateRunHistory(acj, nil)
+	assert.Nil(t, acj.Status.RunHistory)
+
+	acj.Status.RunHistory = []appsv1beta1.AdvancedCronJobRunRecord{
+		newRecord(-5, appsv1beta1.AdvancedCronJobRunRunning),
+		newRecord(-10, appsv1beta1.AdvancedCronJobRunRunning),
+		newRecord(-15, appsv1beta1.AdvancedCronJobRunSucceeded),
+		newRecord(-20, appsv1beta1.AdvancedCronJobRunFailed),
+	}
+	acj.Spec.RunHistoryLimit = ptr.To(int32(3))
+	updateRunHistory(acj, []appsv1beta1.AdvancedCronJobRunRecord{
+		newRecord(-5, appsv1beta1.AdvancedCronJobRunSucceeded),
+		newRecord(0, appsv1beta1.AdvancedCronJobRunRunning),
+	})
+	assert.Equal(t, []appsv1beta1.AdvancedCronJobRunRecord{
+		newRecord(0, appsv1beta1.AdvancedCronJobRunRunning),
+		newRecord(-5, appsv1beta1.AdvancedCronJobRunSucceeded),
+		newRecord(-10, appsv1beta1.AdvancedCronJobRunDeleted),
+	}, acj.Status.RunHistory)
+}
@@ -152,6 +152,7 @@ type ReconcileBroadcastJob struct {
 
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=broadcastjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=broadcastjobs/status,verbs=get;update;patch
@@ -241,6 +242,12 @@ func (r *ReconcileBroadcastJob) Reconcile(_ context.Context, request reconcile.R
 		}
 	}
 
+	// Only the pods of the latest attempts on nodes are considered, if retry on nodes enabled
+	var previousPods []*corev1.Pod
+	if isNodeRetryEnabled(job) {
+		pods, previousPods = filterLatestAttempts(pods)
+	}
+
 	// Get the map (nodeName -> Pod) for pods with node assigned
 	existingNodeToPodMap := r.getNodeToPodMap(pods, job)
 	// list all nodes in cluster
@@ -253,6 +260,8 @@ func (r *ReconcileBroadcastJob) Reconcile(_ context.Context, request reconcile.R
 
 	// Get active, failed, succeeded pods
 	activePods, failedPods, succeededPods := filterPods(job.Spec.FailurePolicy.RestartLimit, pods)
+	// The pods to retry on their nodes are not counted as failed
+	retryTimes, failedPods := splitPodsToRetry(job, failedPods)
 	active := int32(len(activePods))
 	failed := int32(len(failedPods))
 	succeeded := int32(len(succeededPods))
@@ -275,6 +284,25 @@ func (r *ReconcileBroadcastJob) Reconcile(_ context.Context, request reconcile.R
 		job.Status.Phase = appsv1beta1.PhasePaused
 		return reconcile.Result{RequeueAfter: requeueAfter}, r.updateJobStatus(request, job)
 	}
+	// Evaluate the batches of rolling strategy, the job is paused as the Pause failure policy does
+	// if the success ratio of the finished batches is below the threshold
+	maxNodesToRun := -1
+	if job.Spec.RollingStrategy != nil {
+		state, err := calculateBatchState(job, desiredNodes, retryTimes)
+		if err != nil {
+			klog.ErrorS(err, "Failed to calculate batch state for BroadcastJob", "broadcastJob", klog.KObj(job))
+			return reconcile.Result{}, err
+		}
+		job.Status.CurrentBatch = state.currentBatch
+		if state.paused {
+			r.recorder.Event(job, corev1.EventTypeWarning, "Paused", fmt.Sprintf("job is paused, due to %s", state.message))
+			job.Spec.Paused = true
+			job.Status.Phase = appsv1beta1.PhasePaused
+			return reconcile.Result{RequeueAfter: requeueAfter}, r.updateJobStatus(request, job)
+		}
+		maxNodesToRun = state.maxNodesToRun
+	}
+
 	if !job.Spec.Paused && job.Status.Phase == appsv1beta1.PhasePaused {
 		job.Status.Phase = appsv1beta1.PhaseRunning
 		r.recorder.Event(job, corev1.EventTypeNormal, "Continue", "continue to process job")
@@ -321,15 +349,30 @@ func (r *ReconcileBroadcastJob) Reconcile(_ context.Context, request reconcile.R
 			}
 		}
 
+		// Retry the failed pods on nodes after backoff
+		nodesToRetry, retryAfter := getNodesToRetry(retryTimes, nodes, desiredNodes, time.Now())
+		if retryAfter > 0 && (requeueAfter == 0 || retryAfter < requeueAfter) {
+			requeueAfter = retryAfter
+		}
+		nodeAttempts := make(map[string]int32, len(nodesToRetry))
+		for _, node := range nodesToRetry {
+			nodeAttempts[node.Name] = getPodAttempt(desiredNodes[node.Name])
+		}
+
 		// DeletionTimestamp is not set and more nodes to run pod
-		if job.DeletionTimestamp == nil && len(restNodesToRunPod) > 0 {
-			active, err = r.reconcilePods(job, restNodesToRunPod, active, desired)
+		if job.DeletionTimestamp == nil && len(restNodesToRunPod)+len(nodesToRetry) > 0 {
+			nodeIndexes := getNodeIndexes(desiredNodes)
+			newTemplate := func(node *corev1.Node) *corev1.PodTemplateSpec {
+				return newPodTemplateForNode(job, node, nodeIndexes[node.Name], nodeAttempts[node.Name]+1)
+			}
+			nodesToRun := append(limitNodesToRun(restNodesToRunPod, maxNodesToRun), nodesToRetry...)
+			active, err = r.reconcilePods(job, nodesToRun, newTemplate, active, desired)
 			if err != nil {
 				klog.ErrorS(err, "Failed to reconcile Pods for BroadcastJob", "broadcastJob", klog.KObj(job))
 			}
 		}
 
-		if isJobComplete(job, desiredNodes) {
+		if len(retryTimes) == 0 && isJobComplete(job, desiredNodes) {
 			message := fmt.Sprintf("Job completed, %d pods succeeded, %d pods failed", succeeded, failed)
 			job.Status.Phase = appsv1beta1.PhaseCompleted
 			requeueAfter = finishJob(job, appsv1beta1.JobComplete, message)
@@ -340,6 +383,10 @@ func (r *ReconcileBroadcastJob) Reconcile(_ context.Context, request reconcile.R
 	klog.InfoS("After broadcastjob reconcile, with desired, active and failed counts",
 		"broadcastJob", klog.KObj(job), "desiredCount", desired, "activeCount", active, "failedCount", failed)
 
+	if err := r.reportNodeResults(job, calculateNodeResults(job, desiredNodes, previousPods, retryTimes)); err != nil {
+		klog.ErrorS(err, "Failed to report node results for BroadcastJob", "broadcastJob", klog.KObj(job))
+	}
+
 	// update the status
 	job.Status.Failed = failed
 	job.Status.Active = active
@@ -401,7 +448,7 @@ func addLabelToPodTemplate(job *appsv1beta1.BroadcastJob) {
 }
 
 func (r *ReconcileBroadcastJob) reconcilePods(job *appsv1beta1.BroadcastJob,
-	restNodesToRunPod []*corev1.Node, active, desired int32) (int32, error) {
+	restNodesToRunPod []*corev1.Node, newTemplate func(node *corev1.Node) *corev1.PodTemplateSpec, active, desired int32) (int32, error) {
 
 	// max concurrent running pods
 	parallelismInt, err := intstr.GetValueFromIntOrPercent(intstr.ValueOrDefault(job.Spec.Parallelism, intstr.FromInt(1<<31-1)), int(desired), true)
@@ -442,11 +489,12 @@ func (r *ReconcileBroadcastJob) reconcilePods(job *appsv1beta1.BroadcastJob,
 			// create pod concurrently in each batch by go routine
 			curBatchNodes := restNodesToRunPod[startIndex : startIndex+batchSize]
 			for _, node := range curBatchNodes {
-				go func(nodeName string) {
+				go func(node *corev1.Node) {
 					defer wait.Done()
 					// parallelize pod creation
+					nodeName := node.Name
 					klog.InfoS("Creating pod on node", "nodeName", nodeName)
-					err := r.createPodOnNode(nodeName, job.Namespace, &job.Spec.Template, job, asOwner(job))
+					err := r.createPodOnNode(nodeName, job.Namespace, newTemplate(node), job, asOwner(job))
 					if err != nil && errors.IsTimeout(err) {
 						// Pod is created but its initialization has timed out.
 						// If the initialization is successful eventually, the
@@ -465,7 +513,7 @@ func (r *ReconcileBroadcastJob) reconcilePods(job *appsv1beta1.BroadcastJob,
 					activeLock.Lock()
 					active++
 					activeLock.Unlock()
-				}(node.Name)
+				}(node)
 			}
 			// wait for all pods created
 			wait.Wait()
@@ -525,7 +573,7 @@ func isJobFailed(job *appsv1beta1.BroadcastJob, pods []*corev1.Pod) (bool, strin
 
 // getNodesToRunPod returns
 // * desiredNodes : the nodes desired to run pods including node with or without running pods
-// * restNodesToRunPod:  the nodes do not have pods running yet, excluding the nodes not satisfying constraints such as affinity, taints
+// * restNodesToRunPod:  the nodes do not have pods running yet, excluding the nodes not satisfying constraints such as nodeSelector, affinity, taints
 // * podsToDelete: the pods that do not satisfy the node constraint any more
 func getNodesToRunPod(nodes *corev1.NodeList, job *appsv1beta1.BroadcastJob,
 	existingNodeToPodMap map[string]*corev1.Pod) (map[string]*corev1.Pod, []*corev1.Node, []*corev1.Pod) {
@@ -539,7 +587,7 @@ func getNodesToRunPod(nodes *corev1.NodeList, job *appsv1beta1.BroadcastJob,
 		var err error
 		// there's pod existing on the node
 		if pod, ok := existingNodeToPodMap[node.Name]; ok {
-			canFit, err = checkNodeFitness(pod, &node)
+			canFit, err = checkJobNodeFitness(job, pod, &node)
 			if !canFit && pod.DeletionTimestamp == nil {
 				klog.ErrorS(err, "Pod did not fit on node", "pod", klog.KObj(pod), "nodeName", node.Name)
 				podsToDelete = append(podsToDelete, pod)
@@ -550,7 +598,7 @@ func getNodesToRunPod(nodes *corev1.NodeList, job *appsv1beta1.BroadcastJob,
 			// no pod exists, mock a pod to check if the pod can fit on the node,
 			// considering nodeName, label affinity and taints
 			mockPod := NewMockPod(job, node.Name)
-			canFit, err = checkNodeFitness(mockPod, &node)
+			canFit, err = checkJobNodeFitness(job, mockPod, &node)
 			if !canFit {
 				klog.InfoS("Pod did not fit on node", "nodeName", node.Name, "err", err)
 				continue
@@ -90,7 +90,7 @@ func (p *enqueueBroadcastJobForNode) addNode(q workqueue.TypedRateLimitingInterf
 	}
 	for _, bcj := range jobList.Items {
 		mockPod := NewMockPod(&bcj, node.Name)
-		canFit, err := checkNodeFitness(mockPod, node)
+		canFit, err := checkJobNodeFitness(&bcj, mockPod, node)
 		if !canFit {
 			klog.ErrorS(err, "BroadcastJob did not fit on node", "broadcastJob", klog.KObj(&bcj), "nodeName", node.Name)
 			continue
@@ -117,8 +117,8 @@ func (p *enqueueBroadcastJobForNode) updateNode(q workqueue.TypedRateLimitingInt
 	}
 	for _, bcj := range jobList.Items {
 		mockPod := NewMockPod(&bcj, oldNode.Name)
-		canOldNodeFit, _ := checkNodeFitness(mockPod, oldNode)
-		canCurNodeFit, _ := checkNodeFitness(mockPod, curNode)
+		canOldNodeFit, _ := checkJobNodeFitness(&bcj, mockPod, oldNode)
+		canCurNodeFit, _ := checkJobNodeFitness(&bcj, mockPod, curNode)
 
 		if canOldNodeFit != canCurNodeFit {
 			// enqueue the broadcast job for matching node
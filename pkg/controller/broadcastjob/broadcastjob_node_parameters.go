@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+const (
+	// NodeParamsVolumeName is the name of volume to mount the node parameters as files.
+	NodeParamsVolumeName = "broadcastjob-node-params"
+)
+
+// matchesNodeSelector returns true if the job has no node selector or the node matches it.
+func matchesNodeSelector(job *appsv1beta1.BroadcastJob, node *corev1.Node) (bool, error) {
+	if job.Spec.NodeSelector == nil {
+		return true, nil
+	}
+	selector, err := util.ValidatedLabelSelectorAsSelector(job.Spec.NodeSelector)
+	if err != nil {
+		return false, err
+	}
+	if !selector.Matches(labels.Set(node.Labels)) {
+		return false, fmt.Errorf("node %s does not match the nodeSelector of job", node.Name)
+	}
+	return true, nil
+}
+
+// checkJobNodeFitness checks if the node is selected by the job, and the pod can fit on the node.
+func checkJobNodeFitness(job *appsv1beta1.BroadcastJob, pod *corev1.Pod, node *corev1.Node) (bool, error) {
+	if matched, err := matchesNodeSelector(job, node); !matched {
+		return false, err
+	}
+	return checkNodeFitness(pod, node)
+}
+
+// getNodeIndexes returns the index of each desired node, which is the position of node sorted by name.
+func getNodeIndexes(desiredNodes map[string]*corev1.Pod) map[string]int {
+	names := make([]string, 0, len(desiredNodes))
+	for name := range desiredNodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	indexes := make(map[string]int, len(names))
+	for i, name := range names {
+		indexes[name] = i
+	}
+	return indexes
+}
+
+// getNodeParams returns the parameters of node in order, including the node name and index.
+func getNodeParams(params *appsv1beta1.BroadcastJobNodeParameters, node *corev1.Node, index int) []corev1.EnvVar {
+	nodeParams := []corev1.EnvVar{
+		{Name: appsv1beta1.BroadcastJobNodeNameParam, Value: node.Name},
+		{Name: appsv1beta1.BroadcastJobNodeIndexParam, Value: strconv.Itoa(index)},
+	}
+	for _, param := range params.Params {
+		var value string
+		if param.NodeLabel != "" {
+			value = node.Labels[param.NodeLabel]
+		} else if param.NodeAnnotation != "" {
+			value = node.Annotations[param.NodeAnnotation]
+		}
+		nodeParams = append(nodeParams, corev1.EnvVar{Name: param.Name, Value: value})
+	}
+	return nodeParams
+}
+
+// ApplyNodeParameters passes the parameters of node into the pod template, as env vars of containers
+// or files mounted from the annotations of pod by downward API.
+func ApplyNodeParameters(template *corev1.PodTemplateSpec, params *appsv1beta1.BroadcastJobNodeParameters, node *corev1.Node, index int) {
+	if params == nil {
+		return
+	}
+	nodeParams := getNodeParams(params, node, index)
+
+	if params.InjectEnv {
+		for i := range template.Spec.InitContainers {
+			template.Spec.InitContainers[i].Env = mergeNodeParamEnvs(template.Spec.InitContainers[i].Env, nodeParams)
+		}
+		for i := range template.Spec.Containers {
+			template.Spec.Containers[i].Env = mergeNodeParamEnvs(template.Spec.Containers[i].Env, nodeParams)
+		}
+	}
+
+	if params.MountPath != "" {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		volume := corev1.Volume{
+			Name:         NodeParamsVolumeName,
+			VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{}},
+		}
+		for _, param := range nodeParams {
+			key := appsv1beta1.BroadcastJobNodeParamAnnotationPrefix + param.Name
+			template.Annotations[key] = param.Value
+			volume.DownwardAPI.Items = append(volume.DownwardAPI.Items, corev1.DownwardAPIVolumeFile{
+				Path:     param.Name,
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", key)},
+			})
+		}
+		template.Spec.Volumes = append(template.Spec.Volumes, volume)
+		volumeMount := corev1.VolumeMount{Name: NodeParamsVolumeName, MountPath: params.MountPath, ReadOnly: true}
+		for i := range template.Spec.InitContainers {
+			template.Spec.InitContainers[i].VolumeMounts = append(template.Spec.InitContainers[i].VolumeMounts, volumeMount)
+		}
+		for i := range template.Spec.Containers {
+			template.Spec.Containers[i].VolumeMounts = append(template.Spec.Containers[i].VolumeMounts, volumeMount)
+		}
+	}
+}
+
+// mergeNodeParamEnvs appends the parameters to envs, the envs defined in template take precedence.
+func mergeNodeParamEnvs(envs []corev1.EnvVar, nodeParams []corev1.EnvVar) []corev1.EnvVar {
+	existing := make(map[string]struct{}, len(envs))
+	for _, env := range envs {
+		existing[env.Name] = struct{}{}
+	}
+	for _, param := range nodeParams {
+		if _, ok := existing[param.Name]; !ok {
+			envs = append(envs, param)
+		}
+	}
+	return envs
+}
+
+// newPodTemplateForNode returns the pod template to create pod on the node, with the node parameters applied
+// and the attempt recorded if retry on nodes enabled.
+func newPodTemplateForNode(job *appsv1beta1.BroadcastJob, node *corev1.Node, index int, attempt int32) *corev1.PodTemplateSpec {
+	if job.Spec.NodeParameters == nil && !isNodeRetryEnabled(job) {
+		return &job.Spec.Template
+	}
+	template := job.Spec.Template.DeepCopy()
+	ApplyNodeParameters(template, job.Spec.NodeParameters, node, index)
+	if isNodeRetryEnabled(job) {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[appsv1beta1.BroadcastJobAttemptAnnotation] = strconv.Itoa(int(attempt))
+	}
+	return template
+}
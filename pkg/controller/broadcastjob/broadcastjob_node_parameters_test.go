@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// Test scenario:
+// 3 nodes, 2 of them in zone-a selected by nodeSelector
+// 2 pods created with the node name, index and zone passed as env vars and files
+func TestReconcileJobWithNodeParameters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	job1 := createJob("job-params", intstr.FromInt(10))
+	job1.Spec.Template.Spec.Containers = []v1.Container{{
+		Name:  "main",
+		Image: "busybox",
+		Env:   []v1.EnvVar{{Name: "ZONE", Value: "overridden"}},
+	}}
+	job1.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "zone-a"}}
+	job1.Spec.NodeParameters = &appsv1beta1.BroadcastJobNodeParameters{
+		Params: []appsv1beta1.BroadcastJobNodeParam{
+			{Name: "RACK", NodeLabel: "rack"},
+			{Name: "ZONE", NodeLabel: "zone"},
+			{Name: "MAINTENANCE", NodeAnnotation: "maintenance"},
+		},
+		InjectEnv: true,
+		MountPath: "/etc/node-params",
+	}
+
+	node1 := createNode("node1")
+	node1.Labels = map[string]string{"zone": "zone-a", "rack": "rack-1"}
+	node1.Annotations = map[string]string{"maintenance": "reboot"}
+	node2 := createNode("node2")
+	node2.Labels = map[string]string{"zone": "zone-b", "rack": "rack-2"}
+	node3 := createNode("node3")
+	node3.Labels = map[string]string{"zone": "zone-a", "rack": "rack-3"}
+
+	reconcileJob := createReconcileJob(scheme, job1, node1, node2, node3)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job-params", Namespace: "default"}}
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	retrievedJob := &appsv1beta1.BroadcastJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+	assert.Equal(t, int32(2), retrievedJob.Status.Desired)
+
+	podList := &v1.PodList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), podList, client.InNamespace(request.Namespace)))
+	assert.Equal(t, 2, len(podList.Items))
+
+	expectedParams := map[string]map[string]string{
+		"node1": {"BROADCASTJOB_NODE_NAME": "node1", "BROADCASTJOB_NODE_INDEX": "0", "RACK": "rack-1", "ZONE": "zone-a", "MAINTENANCE": "reboot"},
+		"node3": {"BROADCASTJOB_NODE_NAME": "node3", "BROADCASTJOB_NODE_INDEX": "1", "RACK": "rack-3", "ZONE": "zone-a", "MAINTENANCE": ""},
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		params, ok := expectedParams[getAssignedNode(pod)]
+		if !ok {
+			t.Fatalf("unexpected pod %s created on node %s", pod.Name, getAssignedNode(pod))
+		}
+
+		envs := map[string]string{}
+		for _, env := range pod.Spec.Containers[0].Env {
+			envs[env.Name] = env.Value
+		}
+		for name, value := range params {
+			assert.Equal(t, value, pod.Annotations[appsv1beta1.BroadcastJobNodeParamAnnotationPrefix+name])
+			if name == "ZONE" {
+				// the env defined in template takes precedence
+				value = "overridden"
+			}
+			assert.Equal(t, value, envs[name])
+		}
+
+		assert.Equal(t, 1, len(pod.Spec.Volumes))
+		assert.Equal(t, NodeParamsVolumeName, pod.Spec.Volumes[0].Name)
+		assert.Equal(t, len(params), len(pod.Spec.Volumes[0].DownwardAPI.Items))
+		assert.Equal(t, []v1.VolumeMount{{Name: NodeParamsVolumeName, MountPath: "/etc/node-params", ReadOnly: true}},
+			pod.Spec.Containers[0].VolumeMounts)
+	}
+}
+
+func TestGetNodesToRunPodWithNodeSelector(t *testing.T) {
+	job1 := createJob("job1", intstr.FromInt(10))
+	job1.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "zone-a"}}
+
+	node1 := createNode("node1")
+	node1.Labels = map[string]string{"zone": "zone-a"}
+	node2 := createNode("node2")
+	node2.Labels = map[string]string{"zone": "zone-b"}
+	node3 := createNode("node3")
+	node3.Labels = map[string]string{"zone": "zone-b"}
+	pod := createPod(job1, "job1pod1node2", "node2", v1.PodRunning)
+	nodes := &v1.NodeList{Items: []v1.Node{*node1, *node2, *node3}}
+
+	desiredNodes, restNodesToRunPod, podsToDelete := getNodesToRunPod(nodes, job1, map[string]*v1.Pod{"node2": pod})
+	assert.Equal(t, map[string]*v1.Pod{"node1": nil}, desiredNodes)
+	assert.Equal(t, 1, len(restNodesToRunPod))
+	assert.Equal(t, "node1", restNodesToRunPod[0].Name)
+	// the pod on node no longer selected should be deleted
+	assert.Equal(t, []*v1.Pod{pod}, podsToDelete)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// maxNodeResultsConfigMapSize is the max total size of keys and values in the ConfigMap of node results.
+const maxNodeResultsConfigMapSize = corev1.MaxSecretSize
+
+// getNodeResultsConfigMapName returns the name of ConfigMap keeping the results of nodes.
+func getNodeResultsConfigMapName(job *appsv1beta1.BroadcastJob) string {
+	return job.Name + "-node-results"
+}
+
+// getTerminatedContainer returns the terminated state of container, the failed one comes first.
+func getTerminatedContainer(pod *corev1.Pod) (string, *corev1.ContainerStateTerminated) {
+	var name string
+	var terminated *corev1.ContainerStateTerminated
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for i := range statuses {
+			state := statuses[i].State.Terminated
+			if state == nil {
+				state = statuses[i].LastTerminationState.Terminated
+			}
+			if state == nil {
+				continue
+			}
+			if state.ExitCode != 0 {
+				return statuses[i].Name, state
+			}
+			if terminated == nil {
+				name, terminated = statuses[i].Name, state
+			}
+		}
+	}
+	return name, terminated
+}
+
+// getPodError returns the reason why the pod failed.
+func getPodError(restartLimit int32, pod *corev1.Pod) string {
+	if pod.Status.Phase != corev1.PodFailed && !isPodFailed(restartLimit, pod) {
+		return ""
+	}
+	if name, terminated := getTerminatedContainer(pod); terminated != nil && terminated.ExitCode != 0 {
+		return fmt.Sprintf("container %s terminated with exit code %d, reason: %s", name, terminated.ExitCode, terminated.Reason)
+	}
+	if pod.Status.Phase != corev1.PodFailed {
+		return fmt.Sprintf("restarts exceed the restart limit %d", restartLimit)
+	}
+	if pod.Status.Message != "" {
+		return pod.Status.Message
+	}
+	return pod.Status.Reason
+}
+
+// calculateNodeResults returns the results of the latest pods on the desired nodes sorted by node name.
+// The last error is from the previous attempts, if the latest pod has not failed.
+func calculateNodeResults(job *appsv1beta1.BroadcastJob, desiredNodes map[string]*corev1.Pod, previousPods []*corev1.Pod,
+	retryTimes map[string]time.Time) []appsv1beta1.BroadcastJobNodeResult {
+	restartLimit := job.Spec.FailurePolicy.RestartLimit
+	previousErrors := map[string]*corev1.Pod{}
+	for _, pod := range previousPods {
+		if getPodError(restartLimit, pod) == "" {
+			continue
+		}
+		nodeName := getAssignedNode(pod)
+		if cur, ok := previousErrors[nodeName]; !ok || getPodAttempt(cur) < getPodAttempt(pod) {
+			previousErrors[nodeName] = pod
+		}
+	}
+
+	var results []appsv1beta1.BroadcastJobNodeResult
+	for nodeName, pod := range desiredNodes {
+		if pod == nil {
+			continue
+		}
+		result := appsv1beta1.BroadcastJobNodeResult{
+			NodeName: nodeName,
+			Phase:    pod.Status.Phase,
+			Attempts: getPodAttempt(pod),
+		}
+		if isPodFailed(restartLimit, pod) {
+			result.Phase = corev1.PodFailed
+		}
+		if _, terminated := getTerminatedContainer(pod); terminated != nil {
+			exitCode := terminated.ExitCode
+			result.ExitCode = &exitCode
+			result.Message = terminated.Message
+		}
+		result.LastError = getPodError(restartLimit, pod)
+		if previous, ok := previousErrors[nodeName]; ok && result.LastError == "" {
+			result.LastError = getPodError(restartLimit, previous)
+		}
+		if retryTime, ok := retryTimes[nodeName]; ok {
+			result.NextRetryTime = &metav1.Time{Time: retryTime.Truncate(time.Second)}
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].NodeName < results[j].NodeName })
+	return results
+}
+
+// getNodeResultsData returns the results keyed by node name within the size limit of ConfigMap,
+// and the number of results dropped. The results of failed nodes are kept first.
+func getNodeResultsData(results []appsv1beta1.BroadcastJobNodeResult) (map[string]string, int, error) {
+	sorted := make([]*appsv1beta1.BroadcastJobNodeResult, 0, len(results))
+	for i := range results {
+		sorted = append(sorted, &results[i])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Phase == corev1.PodFailed && sorted[j].Phase != corev1.PodFailed
+	})
+
+	data := make(map[string]string, len(results))
+	var size, dropped int
+	for _, result := range sorted {
+		value, err := json.Marshal(result)
+		if err != nil {
+			return nil, 0, err
+		}
+		if size+len(result.NodeName)+len(value) > maxNodeResultsConfigMapSize {
+			dropped++
+			continue
+		}
+		size += len(result.NodeName) + len(value)
+		data[result.NodeName] = string(value)
+	}
+	return data, dropped, nil
+}
+
+// setNodeResultsTruncatedCondition sets the NodeResultsTruncated condition with the message, or removes it if the message is empty.
+func setNodeResultsTruncatedCondition(job *appsv1beta1.BroadcastJob, message string) {
+	var conditions []appsv1beta1.JobCondition
+	for _, c := range job.Status.Conditions {
+		if c.Type != appsv1beta1.JobNodeResultsTruncated {
+			conditions = append(conditions, c)
+		} else if c.Message == message {
+			return
+		}
+	}
+	if message != "" {
+		conditions = append(conditions, newCondition(appsv1beta1.JobNodeResultsTruncated, "ConfigMapSizeExceeded", message))
+	}
+	job.Status.Conditions = conditions
+}
+
+// reportNodeResults reports the results of nodes into status or ConfigMap, according to spec.nodeResults.
+func (r *ReconcileBroadcastJob) reportNodeResults(job *appsv1beta1.BroadcastJob, results []appsv1beta1.BroadcastJobNodeResult) error {
+	job.Status.NodeResults = nil
+	job.Status.NodeResultsConfigMap = ""
+	if job.Spec.NodeResults == nil || job.Spec.NodeResults.Type != appsv1beta1.BroadcastJobNodeResultsConfigMap {
+		setNodeResultsTruncatedCondition(job, "")
+		if job.Spec.NodeResults != nil {
+			job.Status.NodeResults = results
+		}
+		return nil
+	}
+
+	data, dropped, err := getNodeResultsData(results)
+	if err != nil {
+		return err
+	}
+	name := getNodeResultsConfigMapName(job)
+	job.Status.NodeResultsConfigMap = name
+	var message string
+	if dropped > 0 {
+		message = fmt.Sprintf("%d of %d node results are not reported in ConfigMap %s due to its size limit", dropped, len(results), name)
+	}
+	setNodeResultsTruncatedCondition(job, message)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: name}, cm)
+	if errors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       job.Namespace,
+				Name:            name,
+				Labels:          labelsAsMap(job),
+				OwnerReferences: []metav1.OwnerReference{*asOwner(job)},
+			},
+			Data: data,
+		}
+		klog.V(4).InfoS("Creating ConfigMap for results of BroadcastJob", "broadcastJob", klog.KObj(job), "configMap", name)
+		return r.Create(context.TODO(), cm)
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(cm, job) {
+		return fmt.Errorf("ConfigMap %s/%s already exists and is not controlled by BroadcastJob", job.Namespace, name)
+	}
+	if apiequality.Semantic.DeepEqual(cm.Data, data) {
+		return nil
+	}
+	cm = cm.DeepCopy()
+	cm.Data = data
+	return r.Update(context.TODO(), cm)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	defaultNodeBackoffSeconds = 10
+	// maxNodeBackoff is the max backoff to retry on each node, same as the max backoff of Job.
+	maxNodeBackoff = 6 * time.Minute
+)
+
+func isNodeRetryEnabled(job *appsv1beta1.BroadcastJob) bool {
+	return job.Spec.FailurePolicy.BackoffLimitPerNode != nil
+}
+
+// getPodAttempt returns the attempt of pod on its node, which is 1 if not recorded.
+func getPodAttempt(pod *corev1.Pod) int32 {
+	if attempt, err := strconv.ParseInt(pod.Annotations[appsv1beta1.BroadcastJobAttemptAnnotation], 10, 32); err == nil && attempt > 0 {
+		return int32(attempt)
+	}
+	return 1
+}
+
+// filterLatestAttempts returns the pod of the latest attempt on each node, and the pods of previous attempts.
+// The pods without node assigned are all regarded as the latest.
+func filterLatestAttempts(pods []*corev1.Pod) ([]*corev1.Pod, []*corev1.Pod) {
+	latestPods := make(map[string]*corev1.Pod, len(pods))
+	var latest, previous []*corev1.Pod
+	for _, pod := range pods {
+		nodeName := getAssignedNode(pod)
+		if nodeName == "" {
+			latest = append(latest, pod)
+			continue
+		}
+		if cur, ok := latestPods[nodeName]; ok {
+			if getPodAttempt(cur) > getPodAttempt(pod) ||
+				(getPodAttempt(cur) == getPodAttempt(pod) && !cur.CreationTimestamp.Before(&pod.CreationTimestamp)) {
+				previous = append(previous, pod)
+				continue
+			}
+			previous = append(previous, cur)
+		}
+		latestPods[nodeName] = pod
+	}
+	for _, pod := range pods {
+		if nodeName := getAssignedNode(pod); nodeName != "" && latestPods[nodeName] == pod {
+			latest = append(latest, pod)
+		}
+	}
+	return latest, previous
+}
+
+// getPodFinishedTime returns the time when the pod finished, which is the latest time containers terminated.
+func getPodFinishedTime(pod *corev1.Pod) time.Time {
+	var finishedTime time.Time
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for i := range statuses {
+			if terminated := statuses[i].State.Terminated; terminated != nil && terminated.FinishedAt.After(finishedTime) {
+				finishedTime = terminated.FinishedAt.Time
+			}
+		}
+	}
+	if finishedTime.IsZero() {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.LastTransitionTime.After(finishedTime) {
+				finishedTime = c.LastTransitionTime.Time
+			}
+		}
+	}
+	if finishedTime.IsZero() {
+		finishedTime = pod.CreationTimestamp.Time
+	}
+	return finishedTime
+}
+
+// getNodeBackoff returns the backoff to retry after the attempt failed on node.
+func getNodeBackoff(job *appsv1beta1.BroadcastJob, attempt int32) time.Duration {
+	backoffSeconds := int32(defaultNodeBackoffSeconds)
+	if job.Spec.FailurePolicy.BackoffSeconds != nil {
+		backoffSeconds = *job.Spec.FailurePolicy.BackoffSeconds
+	}
+	backoff := time.Duration(backoffSeconds) * time.Second
+	for i := int32(1); i < attempt && backoff < maxNodeBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxNodeBackoff {
+		backoff = maxNodeBackoff
+	}
+	return backoff
+}
+
+// splitPodsToRetry splits the failed pods into the pods to retry on their nodes keyed by node name with the time to retry,
+// and the pods failed exceeding the backoff limit per node. Only the pods in Failed phase can be retried.
+func splitPodsToRetry(job *appsv1beta1.BroadcastJob, failedPods []*corev1.Pod) (map[string]time.Time, []*corev1.Pod) {
+	if !isNodeRetryEnabled(job) {
+		return nil, failedPods
+	}
+	retryTimes := map[string]time.Time{}
+	var exhaustedPods []*corev1.Pod
+	for _, pod := range failedPods {
+		attempt := getPodAttempt(pod)
+		nodeName := getAssignedNode(pod)
+		if pod.Status.Phase != corev1.PodFailed || nodeName == "" || attempt > *job.Spec.FailurePolicy.BackoffLimitPerNode {
+			exhaustedPods = append(exhaustedPods, pod)
+			continue
+		}
+		retryTimes[nodeName] = getPodFinishedTime(pod).Add(getNodeBackoff(job, attempt))
+	}
+	return retryTimes, exhaustedPods
+}
+
+// getNodesToRetry returns the nodes to retry now, and the duration to wait for the next retry.
+func getNodesToRetry(retryTimes map[string]time.Time, nodes *corev1.NodeList,
+	desiredNodes map[string]*corev1.Pod, now time.Time) ([]*corev1.Node, time.Duration) {
+	var nodesToRetry []*corev1.Node
+	var wait time.Duration
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		retryTime, ok := retryTimes[node.Name]
+		if !ok {
+			continue
+		}
+		// the node does not fit the job any more
+		if _, ok := desiredNodes[node.Name]; !ok {
+			continue
+		}
+		if left := retryTime.Sub(now); left > 0 {
+			if wait == 0 || left < wait {
+				wait = left
+			}
+			continue
+		}
+		nodesToRetry = append(nodesToRetry, node)
+	}
+	return nodesToRetry, wait
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func createFailedPod(job *appsv1beta1.BroadcastJob, podName, nodeName string, attempt int, finishedAt time.Time) *v1.Pod {
+	pod := createPod(job, podName, nodeName, v1.PodFailed)
+	pod.Annotations = map[string]string{appsv1beta1.BroadcastJobAttemptAnnotation: strconv.Itoa(attempt)}
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name: "main",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			ExitCode:   2,
+			Reason:     "Error",
+			Message:    "disk not found",
+			FinishedAt: metav1.Time{Time: finishedAt},
+		}},
+	}}
+	return pod
+}
+
+func TestGetNodeBackoff(t *testing.T) {
+	job := createJob("job1", intstr.FromInt(1))
+	job.Spec.FailurePolicy.BackoffLimitPerNode = ptr.To(int32(10))
+	assert.Equal(t, 10*time.Second, getNodeBackoff(job, 1))
+	assert.Equal(t, 40*time.Second, getNodeBackoff(job, 3))
+	assert.Equal(t, maxNodeBackoff, getNodeBackoff(job, 10))
+
+	job.Spec.FailurePolicy.BackoffSeconds = ptr.To(int32(1))
+	assert.Equal(t, 2*time.Second, getNodeBackoff(job, 2))
+}
+
+func TestFilterLatestAttempts(t *testing.T) {
+	job := createJob("job1", intstr.FromInt(1))
+	now := time.Now()
+	pod1 := createFailedPod(job, "pod1", "node1", 1, now)
+	pod2 := createFailedPod(job, "pod2", "node1", 2, now)
+	pod3 := createPod(job, "pod3", "node2", v1.PodRunning)
+
+	latest, previous := filterLatestAttempts([]*v1.Pod{pod2, pod1, pod3})
+	assert.Equal(t, []*v1.Pod{pod2, pod3}, latest)
+	assert.Equal(t, []*v1.Pod{pod1}, previous)
+}
+
+func TestGetNodeResultsData(t *testing.T) {
+	message := strings.Repeat("x", 400*1024)
+	results := []appsv1beta1.BroadcastJobNodeResult{
+		{NodeName: "node-a", Phase: v1.PodSucceeded, Message: message},
+		{NodeName: "node-b", Phase: v1.PodSucceeded, Message: message},
+		{NodeName: "node-c", Phase: v1.PodFailed, Message: message},
+	}
+	data, dropped, err := getNodeResultsData(results)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.Contains(t, data, "node-a")
+	assert.Contains(t, data, "node-c")
+
+	data, dropped, err = getNodeResultsData(results[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 2, len(data))
+}
+
+func TestSetNodeResultsTruncatedCondition(t *testing.T) {
+	job := createJob("job1", intstr.FromInt(1))
+	job.Status.Conditions = []appsv1beta1.JobCondition{newCondition(appsv1beta1.JobComplete, "", "")}
+
+	setNodeResultsTruncatedCondition(job, "1 of 3 node results are not reported")
+	assert.Equal(t, 2, len(job.Status.Conditions))
+	condition := job.Status.Conditions[1]
+	assert.Equal(t, appsv1beta1.JobNodeResultsTruncated, condition.Type)
+
+	setNodeResultsTruncatedCondition(job, "1 of 3 node results are not reported")
+	assert.Equal(t, condition, job.Status.Conditions[1])
+
+	setNodeResultsTruncatedCondition(job, "")
+	assert.Equal(t, 1, len(job.Status.Conditions))
+	assert.Equal(t, appsv1beta1.JobComplete, job.Status.Conditions[0].Type)
+}
+
+// Test scenario:
+// node1 has a failed pod whose backoff passed, a new pod is created on it
+// node2 has a failed pod in backoff, the job requeues for it
+// neither of them counts as failed for the FailFast policy
+func TestReconcileJobRetryOnNode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	job := createJob("job-retry", intstr.FromInt(10))
+	job.Spec.FailurePolicy = appsv1beta1.FailurePolicy{
+		Type:                appsv1beta1.FailurePolicyTypeFailFast,
+		BackoffLimitPerNode: ptr.To(int32(2)),
+		BackoffSeconds:      ptr.To(int32(60)),
+	}
+	job.Spec.NodeResults = &appsv1beta1.BroadcastJobNodeResultsPolicy{Type: appsv1beta1.BroadcastJobNodeResultsConfigMap}
+	now := time.Now()
+	pod1 := createFailedPod(job, "pod1", "node1", 1, now.Add(-2*time.Minute))
+	pod2 := createFailedPod(job, "pod2", "node2", 1, now)
+
+	reconcileJob := createReconcileJob(scheme, job, pod1, pod2, createNode("node1"), createNode("node2"))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job-retry", Namespace: "default"}}
+	result, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= time.Minute)
+
+	retrievedJob := &appsv1beta1.BroadcastJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+	assert.Equal(t, appsv1beta1.PhaseRunning, retrievedJob.Status.Phase)
+	assert.Equal(t, int32(0), retrievedJob.Status.Failed)
+	assert.Equal(t, int32(1), retrievedJob.Status.Active)
+	assert.Equal(t, "job-retry-node-results", retrievedJob.Status.NodeResultsConfigMap)
+
+	podList := &v1.PodList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), podList, client.InNamespace(request.Namespace)))
+	assert.Equal(t, 3, len(podList.Items))
+	var retried bool
+	for _, pod := range podList.Items {
+		if pod.Name != "pod1" && pod.Name != "pod2" {
+			retried = true
+			assert.Equal(t, "node1", getAssignedNode(&pod))
+			assert.Equal(t, "2", pod.Annotations[appsv1beta1.BroadcastJobAttemptAnnotation])
+		}
+	}
+	assert.True(t, retried)
+
+	cm := &v1.ConfigMap{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "job-retry-node-results"}, cm))
+	assert.Equal(t, 2, len(cm.Data))
+	nodeResult := appsv1beta1.BroadcastJobNodeResult{}
+	assert.NoError(t, json.Unmarshal([]byte(cm.Data["node2"]), &nodeResult))
+	assert.Equal(t, v1.PodFailed, nodeResult.Phase)
+	assert.Equal(t, int32(1), nodeResult.Attempts)
+	assert.Equal(t, ptr.To(int32(2)), nodeResult.ExitCode)
+	assert.Equal(t, "disk not found", nodeResult.Message)
+	assert.Equal(t, "container main terminated with exit code 2, reason: Error", nodeResult.LastError)
+	assert.NotNil(t, nodeResult.NextRetryTime)
+}
+
+// Test scenario:
+// node1 has a failed pod exceeding the backoff limit per node, the job fails for the FailFast policy
+func TestReconcileJobRetryOnNodeExhausted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	job := createJob("job-retry-exhausted", intstr.FromInt(10))
+	job.Spec.FailurePolicy = appsv1beta1.FailurePolicy{
+		Type:                appsv1beta1.FailurePolicyTypeFailFast,
+		BackoffLimitPerNode: ptr.To(int32(2)),
+	}
+	job.Spec.NodeResults = &appsv1beta1.BroadcastJobNodeResultsPolicy{Type: appsv1beta1.BroadcastJobNodeResultsStatus}
+	now := time.Now()
+	pod1 := createFailedPod(job, "pod1", "node1", 2, now.Add(-time.Hour))
+	pod2 := createFailedPod(job, "pod2", "node1", 3, now.Add(-time.Hour))
+	pod2.Status.ContainerStatuses[0].State.Terminated.Message = ""
+
+	reconcileJob := createReconcileJob(scheme, job, pod1, pod2, createNode("node1"))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job-retry-exhausted", Namespace: "default"}}
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	retrievedJob := &appsv1beta1.BroadcastJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+	assert.Equal(t, appsv1beta1.PhaseFailed, retrievedJob.Status.Phase)
+	assert.Equal(t, int32(1), retrievedJob.Status.Failed)
+	assert.Equal(t, 1, len(retrievedJob.Status.NodeResults))
+	assert.Equal(t, "node1", retrievedJob.Status.NodeResults[0].NodeName)
+	assert.Equal(t, int32(3), retrievedJob.Status.NodeResults[0].Attempts)
+	assert.Nil(t, retrievedJob.Status.NodeResults[0].NextRetryTime)
+
+	podList := &v1.PodList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), podList, client.InNamespace(request.Namespace)))
+	assert.Equal(t, 2, len(podList.Items))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// batchState is the state of the batch in running for rolling strategy.
+type batchState struct {
+	currentBatch int32
+	// maxNodesToRun is the max number of nodes to run new pods in the current batch, -1 means no limit.
+	maxNodesToRun int
+	// paused means the success ratio of finished pods is below the threshold.
+	paused  bool
+	message string
+}
+
+// getBatchTargets returns the cumulative number of nodes of each batch.
+func getBatchTargets(strategy *appsv1beta1.BroadcastJobRollingStrategy, desired int) ([]int, error) {
+	targets := make([]int, len(strategy.Batches))
+	for i := range strategy.Batches {
+		target, err := intstr.GetScaledValueFromIntOrPercent(&strategy.Batches[i], desired, true)
+		if err != nil {
+			return nil, err
+		}
+		if target < 1 {
+			target = 1
+		}
+		if target > desired {
+			target = desired
+		}
+		targets[i] = target
+	}
+	return targets, nil
+}
+
+// calculateBatchState returns the state of the batch in running. The next batch starts only if all pods of the started
+// batches finished, and the success ratio of them is not below the threshold. The ratio is not evaluated after the last batch.
+func calculateBatchState(job *appsv1beta1.BroadcastJob, desiredNodes map[string]*corev1.Pod, retryTimes map[string]time.Time) (*batchState, error) {
+	strategy := job.Spec.RollingStrategy
+	targets, err := getBatchTargets(strategy, len(desiredNodes))
+	if err != nil {
+		return nil, err
+	}
+	minSuccessPercent := int32(100)
+	if strategy.MinSuccessPercent != nil {
+		minSuccessPercent = *strategy.MinSuccessPercent
+	}
+
+	var started, running, succeeded, failed int
+	for nodeName, pod := range desiredNodes {
+		if pod == nil {
+			continue
+		}
+		started++
+		if _, ok := retryTimes[nodeName]; ok {
+			running++
+		} else if pod.Status.Phase == corev1.PodSucceeded {
+			succeeded++
+		} else if pod.Status.Phase == corev1.PodFailed || isPodFailed(job.Spec.FailurePolicy.RestartLimit, pod) {
+			failed++
+		} else {
+			running++
+		}
+	}
+
+	// next is the first batch not fully started
+	next := len(targets)
+	for i, target := range targets {
+		if started < target {
+			next = i
+			break
+		}
+	}
+	if len(targets) == 0 {
+		return &batchState{maxNodesToRun: -1}, nil
+	}
+	if next == 0 {
+		return &batchState{currentBatch: 0, maxNodesToRun: targets[0] - started}, nil
+	}
+	if running > 0 {
+		return &batchState{currentBatch: int32(next - 1)}, nil
+	}
+	// the pods of the last batch are left to the completion and failure policy as usual
+	if next == len(targets) {
+		return &batchState{currentBatch: int32(next), maxNodesToRun: -1}, nil
+	}
+	if finished := succeeded + failed; finished > 0 && succeeded*100 < int(minSuccessPercent)*finished {
+		return &batchState{
+			currentBatch: int32(next - 1),
+			paused:       true,
+			message: fmt.Sprintf("batch %d finished with %d pods succeeded and %d pods failed, below the success threshold %d%%",
+				next-1, succeeded, failed, minSuccessPercent),
+		}, nil
+	}
+	return &batchState{currentBatch: int32(next), maxNodesToRun: targets[next] - started}, nil
+}
+
+// limitNodesToRun returns at most limit nodes in order of name, -1 means no limit.
+func limitNodesToRun(nodes []*corev1.Node, limit int) []*corev1.Node {
+	if limit < 0 || len(nodes) <= limit {
+		return nodes
+	}
+	sorted := make([]*corev1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted[:limit]
+}
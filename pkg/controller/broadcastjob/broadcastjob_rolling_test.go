@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcastjob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestCalculateBatchState(t *testing.T) {
+	newDesiredNodes := func(job *appsv1beta1.BroadcastJob, phases ...v1.PodPhase) map[string]*v1.Pod {
+		desiredNodes := map[string]*v1.Pod{}
+		for i := 0; i < 10; i++ {
+			nodeName := fmt.Sprintf("node%d", i)
+			desiredNodes[nodeName] = nil
+			if i < len(phases) {
+				desiredNodes[nodeName] = createPod(job, "pod-"+nodeName, nodeName, phases[i])
+			}
+		}
+		return desiredNodes
+	}
+
+	tests := []struct {
+		name        string
+		phases      []v1.PodPhase
+		retryNodes  []string
+		expectState *batchState
+	}{
+		{
+			name:        "start the first batch",
+			expectState: &batchState{currentBatch: 0, maxNodesToRun: 1},
+		},
+		{
+			name:        "wait for the first batch running",
+			phases:      []v1.PodPhase{v1.PodRunning},
+			expectState: &batchState{currentBatch: 0},
+		},
+		{
+			name:        "wait for the first batch retrying",
+			phases:      []v1.PodPhase{v1.PodFailed},
+			retryNodes:  []string{"node0"},
+			expectState: &batchState{currentBatch: 0},
+		},
+		{
+			name:        "start the second batch after the first succeeded",
+			phases:      []v1.PodPhase{v1.PodSucceeded},
+			expectState: &batchState{currentBatch: 1, maxNodesToRun: 4},
+		},
+		{
+			name:   "pause at the second batch below the threshold",
+			phases: []v1.PodPhase{v1.PodSucceeded, v1.PodSucceeded, v1.PodFailed, v1.PodFailed, v1.PodSucceeded},
+			expectState: &batchState{currentBatch: 1, paused: true,
+				message: "batch 1 finished with 3 pods succeeded and 2 pods failed, below the success threshold 80%"},
+		},
+		{
+			name: "run the rest nodes after all batches passed",
+			phases: []v1.PodPhase{v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded, v1.PodFailed, v1.PodSucceeded,
+				v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded},
+			expectState: &batchState{currentBatch: 3, maxNodesToRun: -1},
+		},
+		{
+			name: "not pause after the last batch below the threshold",
+			phases: []v1.PodPhase{v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded,
+				v1.PodFailed, v1.PodFailed, v1.PodFailed},
+			expectState: &batchState{currentBatch: 3, maxNodesToRun: -1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := createJob("job1", intstr.FromInt(10))
+			job.Spec.RollingStrategy = &appsv1beta1.BroadcastJobRollingStrategy{
+				Batches:           []intstr.IntOrString{intstr.FromInt32(1), intstr.FromString("50%"), intstr.FromString("80%")},
+				MinSuccessPercent: ptr.To(int32(80)),
+			}
+			retryTimes := map[string]time.Time{}
+			for _, nodeName := range tc.retryNodes {
+				retryTimes[nodeName] = time.Now()
+			}
+			state, err := calculateBatchState(job, newDesiredNodes(job, tc.phases...), retryTimes)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectState, state)
+		})
+	}
+}
+
+// Test scenario:
+// 10 nodes with batches 20% and 100%
+// the first batch runs on 2 nodes, the job is paused if any of them failed, or runs on the rest 8 nodes if all succeeded
+// the failed pods of the last batch are left to the Continue failure policy
+func TestReconcileJobRollingBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		podPhases   []v1.PodPhase
+		expectPods  int
+		expectPhase appsv1beta1.BroadcastJobPhase
+		expectBatch int32
+	}{
+		{
+			name:        "run the first batch",
+			expectPods:  2,
+			expectPhase: appsv1beta1.PhaseRunning,
+		},
+		{
+			name:        "pause for the first batch failed",
+			podPhases:   []v1.PodPhase{v1.PodSucceeded, v1.PodFailed},
+			expectPods:  2,
+			expectPhase: appsv1beta1.PhasePaused,
+		},
+		{
+			name:        "run the second batch for the first batch succeeded",
+			podPhases:   []v1.PodPhase{v1.PodSucceeded, v1.PodSucceeded},
+			expectPods:  10,
+			expectPhase: appsv1beta1.PhaseRunning,
+			expectBatch: 1,
+		},
+		{
+			name: "complete for the last batch finished",
+			podPhases: []v1.PodPhase{v1.PodSucceeded, v1.PodSucceeded, v1.PodFailed, v1.PodFailed, v1.PodSucceeded,
+				v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded, v1.PodSucceeded},
+			expectPods:  10,
+			expectPhase: appsv1beta1.PhaseCompleted,
+			expectBatch: 2,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			jobName := fmt.Sprintf("job-rolling-%d", i)
+			job := createJob(jobName, intstr.FromInt(10))
+			job.Spec.FailurePolicy.Type = appsv1beta1.FailurePolicyTypeContinue
+			job.Spec.RollingStrategy = &appsv1beta1.BroadcastJobRollingStrategy{
+				Batches: []intstr.IntOrString{intstr.FromString("20%"), intstr.FromString("100%")},
+			}
+			objs := []client.Object{job}
+			for n := 0; n < 10; n++ {
+				objs = append(objs, createNode(fmt.Sprintf("node%d", n)))
+			}
+			for n, phase := range tc.podPhases {
+				objs = append(objs, createPod(job, fmt.Sprintf("pod%d", n), fmt.Sprintf("node%d", n), phase))
+			}
+
+			reconcileJob := createReconcileJob(scheme, objs...)
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: jobName, Namespace: "default"}}
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+
+			retrievedJob := &appsv1beta1.BroadcastJob{}
+			assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+			assert.Equal(t, tc.expectPhase, retrievedJob.Status.Phase)
+			assert.Equal(t, tc.expectBatch, retrievedJob.Status.CurrentBatch)
+
+			podList := &v1.PodList{}
+			assert.NoError(t, reconcileJob.List(context.TODO(), podList, client.InNamespace(request.Namespace)))
+			assert.Equal(t, tc.expectPods, len(podList.Items))
+			for _, pod := range podList.Items {
+				if tc.expectBatch == 0 {
+					nodeName := getAssignedNode(&pod)
+					assert.True(t, nodeName == "node0" || nodeName == "node1", "unexpected pod on %s", nodeName)
+				}
+			}
+		})
+	}
+}
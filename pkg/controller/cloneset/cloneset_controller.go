@@ -61,6 +61,7 @@ import (
 	"github.com/openkruise/kruise/pkg/util/fieldindex"
 	historyutil "github.com/openkruise/kruise/pkg/util/history"
 	imagejobutilfunc "github.com/openkruise/kruise/pkg/util/imagejob/utilfunction"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 	"github.com/openkruise/kruise/pkg/util/refmanager"
 	"github.com/openkruise/kruise/pkg/util/volumeclaimtemplate"
@@ -188,6 +189,7 @@ type ReconcileCloneSet struct {
 // +kubebuilder:rbac:groups=core,resources=pods/resize,verbs=get;patch;update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets/status,verbs=get;update;patch
@@ -210,6 +212,7 @@ func (r *ReconcileCloneSet) doReconcile(request reconcile.Request) (res reconcil
 			}
 		} else {
 			klog.ErrorS(retErr, "Failed syncing CloneSet", "cloneSet", request)
+			workloadmetrics.RecordReconcileError("cloneset", retErr)
 		}
 		// clean the duration store
 		_ = clonesetutils.DurationStore.Pop(request.String())
@@ -224,6 +227,7 @@ func (r *ReconcileCloneSet) doReconcile(request reconcile.Request) (res reconcil
 			// For additional cleanup logic use finalizers.
 			klog.V(3).InfoS("CloneSet has been deleted", "cloneSet", request)
 			clonesetutils.ScaleExpectations.DeleteExpectations(request.String())
+			workloadmetrics.DeleteStatus(workloadmetrics.KindCloneSet, request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -377,10 +381,22 @@ func (r *ReconcileCloneSet) doReconcile(request reconcile.Request) (res reconcil
 
 	// scale and update pods
 	syncErr := r.syncCloneSet(instance, &newStatus, currentRevision, updateRevision, revisions, filteredPods, filteredPVCs)
+	if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetAutoResizePVCGate) {
+		newStatus.VolumeClaims = synccontrol.CalculateVolumeClaimStatus(instance, filteredPods, filteredPVCs)
+	}
 	// update new status
 	if err = r.statusUpdater.UpdateCloneSetStatus(instance, &newStatus, filteredPods); err != nil {
 		return reconcile.Result{}, err
 	}
+	workloadmetrics.RecordStatus(workloadmetrics.KindCloneSet, instance.Namespace, instance.Name, workloadmetrics.Status{
+		Desired:         *instance.Spec.Replicas,
+		Updated:         newStatus.UpdatedReplicas,
+		Ready:           newStatus.ReadyReplicas,
+		Available:       &newStatus.AvailableReplicas,
+		CurrentRevision: newStatus.CurrentRevision,
+		UpdateRevision:  newStatus.UpdateRevision,
+		Paused:          instance.Spec.UpdateStrategy.Paused,
+	})
 
 	if err = r.truncatePodsToDelete(instance, filteredPods); err != nil {
 		klog.ErrorS(err, "Failed to truncate podsToDelete for CloneSet", "cloneSet", request)
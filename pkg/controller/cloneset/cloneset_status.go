@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -33,6 +34,7 @@ import (
 	"github.com/openkruise/kruise/pkg/controller/cloneset/sync"
 	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
 	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/lifecycle"
 )
 
 var (
@@ -89,7 +91,9 @@ func (r *realStatusUpdater) inconsistentStatus(cs *appsv1alpha1.CloneSet, newSta
 		newStatus.UpdateRevision != oldStatus.UpdateRevision ||
 		newStatus.CurrentRevision != oldStatus.CurrentRevision ||
 		newStatus.LabelSelector != oldStatus.LabelSelector ||
-		hasProgressingConditionChanged(cs.Status, *newStatus)
+		!apiequality.Semantic.DeepEqual(newStatus.VolumeClaims, oldStatus.VolumeClaims) ||
+		hasProgressingConditionChanged(cs.Status, *newStatus) ||
+		hasLifecycleHookConditionChanged(cs.Status, *newStatus)
 }
 
 func (r *realStatusUpdater) calculateStatus(cs *appsv1alpha1.CloneSet, newStatus *appsv1alpha1.CloneSetStatus, pods []*v1.Pod) {
@@ -121,10 +125,41 @@ func (r *realStatusUpdater) calculateStatus(cs *appsv1alpha1.CloneSet, newStatus
 		newStatus.ExpectedUpdatedReplicas = *cs.Spec.Replicas - int32(partition)
 	}
 
+	calculateLifecycleHookStatus(cs, newStatus, pods)
+
 	duration := r.calculateProgressingStatus(cs, newStatus)
 	clonesetutils.DurationStore.Push(clonesetutils.GetControllerKey(cs), duration)
 }
 
+// calculateLifecycleHookStatus lists the pods timed out in lifecycle hook with MarkFailed action in LifecycleHookFailed condition.
+func calculateLifecycleHookStatus(cs *appsv1alpha1.CloneSet, newStatus *appsv1alpha1.CloneSetStatus, pods []*v1.Pod) {
+	failedPods := lifecycle.GetPodsFailedInHook(cs.Spec.Lifecycle, pods)
+	if len(failedPods) == 0 {
+		clonesetutils.RemoveCloneSetCondition(newStatus, appsv1alpha1.CloneSetConditionLifecycleHookFailed)
+		return
+	}
+	condition := clonesetutils.NewCloneSetCondition(appsv1alpha1.CloneSetConditionLifecycleHookFailed, v1.ConditionTrue,
+		appsv1alpha1.CloneSetLifecycleHookTimeout, lifecycle.GetLifecycleHookFailedMessage(failedPods), timer.Now())
+	if oldCondition := clonesetutils.GetCloneSetCondition(*newStatus, condition.Type); oldCondition != nil {
+		if oldCondition.Message == condition.Message {
+			return
+		}
+		condition.LastTransitionTime = oldCondition.LastTransitionTime
+	}
+	// the message changes with failed pods, so replace the condition instead of SetCloneSetCondition
+	clonesetutils.RemoveCloneSetCondition(newStatus, condition.Type)
+	newStatus.Conditions = append(newStatus.Conditions, *condition)
+}
+
+func hasLifecycleHookConditionChanged(oldStatus appsv1alpha1.CloneSetStatus, newStatus appsv1alpha1.CloneSetStatus) bool {
+	oldCond := clonesetutils.GetCloneSetCondition(oldStatus, appsv1alpha1.CloneSetConditionLifecycleHookFailed)
+	newCond := clonesetutils.GetCloneSetCondition(newStatus, appsv1alpha1.CloneSetConditionLifecycleHookFailed)
+	if oldCond == nil || newCond == nil {
+		return oldCond != newCond
+	}
+	return oldCond.Message != newCond.Message
+}
+
 func (r *realStatusUpdater) calculateProgressingStatus(cs *appsv1alpha1.CloneSet, newStatus *appsv1alpha1.CloneSetStatus) time.Duration {
 	if !clonesetutils.HasProgressDeadline(cs) {
 		clonesetutils.RemoveCloneSetCondition(newStatus, appsv1alpha1.CloneSetConditionTypeProgressing)
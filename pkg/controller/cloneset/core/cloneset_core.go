@@ -150,6 +150,11 @@ func (c *commonControl) GetUpdateOptions() *inplaceupdate.UpdateOptions {
 	if c.Spec.UpdateStrategy.Type == appsv1alpha1.InPlaceOnlyCloneSetUpdateStrategyType {
 		opts.IgnoreVolumeClaimTemplatesHashDiff = true
 	}
+	// PVCs are resized in-place, so no need to recreate pods for changes of VolumeClaimTemplates.
+	if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetAutoResizePVCGate) &&
+		c.Spec.VolumeClaimUpdateStrategy.Type == appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType {
+		opts.IgnoreVolumeClaimTemplatesHashDiff = true
+	}
 	return opts
 }
 
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/pvc"
+)
+
+const (
+	// resizePVCRequeueDuration is the interval to check the PVCs in resizing, for PVC events do not trigger CloneSet reconcile.
+	resizePVCRequeueDuration = 5 * time.Second
+	// resizePVCFailedRequeueDuration is the interval to retry the PVCs failed to resize, which may be fixed by users.
+	resizePVCFailedRequeueDuration = time.Minute
+)
+
+// IsAutoResizePVCEnabled returns true if existing PVCs of cs should be expanded when its volumeClaimTemplates grow.
+func IsAutoResizePVCEnabled(cs *appsv1alpha1.CloneSet) bool {
+	return utilfeature.DefaultFeatureGate.Enabled(features.CloneSetAutoResizePVCGate) &&
+		cs.Spec.VolumeClaimUpdateStrategy.Type == appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType
+}
+
+// getPodClaims returns the existing PVCs of pod indexed by the names of their templates.
+func getPodClaims(cs *appsv1alpha1.CloneSet, pod *v1.Pod, pvcs []*v1.PersistentVolumeClaim) map[string]*v1.PersistentVolumeClaim {
+	pvcByName := make(map[string]*v1.PersistentVolumeClaim, len(pvcs))
+	for _, claim := range pvcs {
+		if clonesetutils.GetInstanceID(claim) == clonesetutils.GetInstanceID(pod) && claim.DeletionTimestamp == nil {
+			pvcByName[claim.Name] = claim
+		}
+	}
+	claims := make(map[string]*v1.PersistentVolumeClaim)
+	for templateName, expected := range clonesetutils.GetPersistentVolumeClaims(cs, pod) {
+		if claim, ok := pvcByName[expected.Name]; ok {
+			claims[templateName] = claim
+		}
+	}
+	return claims
+}
+
+func getVolumeClaimTemplate(cs *appsv1alpha1.CloneSet, name string) *v1.PersistentVolumeClaim {
+	for i := range cs.Spec.VolumeClaimTemplates {
+		if cs.Spec.VolumeClaimTemplates[i].Name == name {
+			return &cs.Spec.VolumeClaimTemplates[i]
+		}
+	}
+	return nil
+}
+
+// CalculateVolumeClaimStatus calculates the compatible and ready replicas of each volume claim template.
+func CalculateVolumeClaimStatus(cs *appsv1alpha1.CloneSet, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) []appsv1alpha1.CloneSetVolumeClaimStatus {
+	if len(cs.Spec.VolumeClaimTemplates) == 0 {
+		return nil
+	}
+	statuses := make([]appsv1alpha1.CloneSetVolumeClaimStatus, len(cs.Spec.VolumeClaimTemplates))
+	for i := range cs.Spec.VolumeClaimTemplates {
+		statuses[i].VolumeClaimName = cs.Spec.VolumeClaimTemplates[i].Name
+	}
+	for _, pod := range pods {
+		claims := getPodClaims(cs, pod, pvcs)
+		for i := range cs.Spec.VolumeClaimTemplates {
+			claim, ok := claims[cs.Spec.VolumeClaimTemplates[i].Name]
+			if !ok {
+				continue
+			}
+			if compatible, ready := pvc.IsPVCCompatibleAndReady(claim, &cs.Spec.VolumeClaimTemplates[i]); compatible {
+				statuses[i].CompatibleReplicas++
+				if ready {
+					statuses[i].CompatibleReadyReplicas++
+				}
+			}
+		}
+	}
+	return statuses
+}
+
+// resizePVCs expands the PVCs whose storage requests are less than their templates.
+// Pods with PVCs in resizing, including those waiting for FileSystemResizePending to clear,
+// are limited by maxUnavailable of the update strategy. The PVCs failed to resize are skipped
+// with events recorded and retried later, so that they never block the update of pods.
+func (c *realControl) resizePVCs(cs *appsv1alpha1.CloneSet, coreControl clonesetcore.Control, pods []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) {
+	if !IsAutoResizePVCEnabled(cs) || len(cs.Spec.VolumeClaimTemplates) == 0 {
+		return
+	}
+
+	maxUnavailable, _ := intstrutil.GetValueFromIntOrPercent(
+		intstrutil.ValueOrDefault(cs.Spec.UpdateStrategy.MaxUnavailable, intstrutil.FromString(appsv1alpha1.DefaultCloneSetMaxUnavailable)), int(*cs.Spec.Replicas), true)
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+
+	var resizingCount int
+	var waitResizeIndexes []int
+	for i, pod := range pods {
+		var resizing, needExpand bool
+		for templateName, claim := range getPodClaims(cs, pod, pvcs) {
+			template := getVolumeClaimTemplate(cs, templateName)
+			if matched, expand := pvc.CompareWithCheckFn(claim, template, pvc.IsPVCNeedExpand); expand {
+				needExpand = true
+			} else if matched {
+				if _, ready := pvc.IsPVCCompatibleAndReady(claim, template); !ready {
+					resizing = true
+				}
+			}
+		}
+		if resizing {
+			resizingCount++
+		} else if needExpand {
+			waitResizeIndexes = append(waitResizeIndexes, i)
+		}
+	}
+	// resize not-ready pods first
+	sort.SliceStable(waitResizeIndexes, coreControl.GetPodsSortFunc(pods, waitResizeIndexes))
+
+	var failedCount int
+	for _, idx := range waitResizeIndexes {
+		if resizingCount >= maxUnavailable {
+			klog.V(4).InfoS("CloneSet was waiting for PVCs in resizing", "cloneSet", klog.KObj(cs), "resizing", resizingCount)
+			break
+		}
+		pod := pods[idx]
+		var resized bool
+		for templateName, claim := range getPodClaims(cs, pod, pvcs) {
+			template := getVolumeClaimTemplate(cs, templateName)
+			if _, expand := pvc.CompareWithCheckFn(claim, template, pvc.IsPVCNeedExpand); !expand {
+				continue
+			}
+			if err := c.resizeClaim(cs, claim, template); err != nil {
+				klog.ErrorS(err, "CloneSet failed to resize PVC", "cloneSet", klog.KObj(cs), "pvc", klog.KObj(claim))
+				c.recorder.Eventf(cs, v1.EventTypeWarning, "FailedResizePVC", "failed to resize pvc %s of pod %s: %v", claim.Name, pod.Name, err)
+				failedCount++
+				continue
+			}
+			c.recorder.Eventf(cs, v1.EventTypeNormal, "SuccessfulResizePVC", "successfully resize pvc %s of pod %s", claim.Name, pod.Name)
+			resized = true
+		}
+		if resized {
+			resizingCount++
+		}
+	}
+
+	if resizingCount > 0 {
+		clonesetutils.DurationStore.Push(clonesetutils.GetControllerKey(cs), resizePVCRequeueDuration)
+	} else if failedCount > 0 {
+		clonesetutils.DurationStore.Push(clonesetutils.GetControllerKey(cs), resizePVCFailedRequeueDuration)
+	}
+}
+
+func (c *realControl) resizeClaim(cs *appsv1alpha1.CloneSet, claim, template *v1.PersistentVolumeClaim) error {
+	if claim.Spec.StorageClassName != nil {
+		sc := &storagev1.StorageClass{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Name: *claim.Spec.StorageClassName}, sc); err != nil {
+			return fmt.Errorf("could not get storage class %s: %v", *claim.Spec.StorageClassName, err)
+		}
+		if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+			return fmt.Errorf("storage class %s does not support volume expansion", sc.Name)
+		}
+	}
+
+	claimClone := claim.DeepCopy()
+	claimClone.Spec.Resources = template.Spec.Resources
+	klog.V(2).InfoS("CloneSet resizing PVC", "cloneSet", klog.KObj(cs), "pvc", klog.KObj(claim),
+		"from", claim.Spec.Resources.Requests.Storage(), "to", template.Spec.Resources.Requests.Storage())
+	return c.Client.Update(context.TODO(), claimClone)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func newResizeTestCloneSet(size string, maxUnavailable int) *appsv1alpha1.CloneSet {
+	return &appsv1alpha1.CloneSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "demo"},
+		Spec: appsv1alpha1.CloneSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: v1.PersistentVolumeClaimSpec{
+					StorageClassName: ptr.To("expandable"),
+					AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+					Resources: v1.VolumeResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+					},
+				},
+			}},
+			VolumeClaimUpdateStrategy: appsv1alpha1.CloneSetVolumeClaimUpdateStrategy{
+				Type: appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType,
+			},
+			UpdateStrategy: appsv1alpha1.CloneSetUpdateStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(int32(maxUnavailable)))},
+		},
+	}
+}
+
+func newResizeTestPodAndClaim(id, request, capacity string) (*v1.Pod, *v1.PersistentVolumeClaim) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "demo-" + id,
+		Labels:    map[string]string{"app": "demo", appsv1alpha1.CloneSetInstanceID: id},
+	}}
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      fmt.Sprintf("data-demo-%s", id),
+			Labels:    map[string]string{"app": "demo", appsv1alpha1.CloneSetInstanceID: id},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("expandable"),
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(request)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+	return pod, claim
+}
+
+func TestCalculateVolumeClaimStatus(t *testing.T) {
+	cs := newResizeTestCloneSet("20Gi", 1)
+	var pods []*v1.Pod
+	var claims []*v1.PersistentVolumeClaim
+	for _, c := range []struct{ id, request, capacity string }{
+		{"a", "20Gi", "20Gi"},
+		{"b", "20Gi", "10Gi"},
+		{"c", "10Gi", "10Gi"},
+	} {
+		pod, claim := newResizeTestPodAndClaim(c.id, c.request, c.capacity)
+		pods = append(pods, pod)
+		claims = append(claims, claim)
+	}
+
+	expected := []appsv1alpha1.CloneSetVolumeClaimStatus{{VolumeClaimName: "data", CompatibleReplicas: 2, CompatibleReadyReplicas: 1}}
+	if got := CalculateVolumeClaimStatus(cs, pods, claims); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestResizePVCs(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.CloneSetAutoResizePVCGate, true)()
+
+	cases := []struct {
+		name               string
+		maxUnavailable     int
+		allowExpansion     bool
+		claims             [][3]string
+		expectEvents       []string
+		expectResizedPVCs  []string
+		expectUnchangedPVC []string
+	}{
+		{
+			name:               "resize pvcs limited by maxUnavailable",
+			maxUnavailable:     2,
+			allowExpansion:     true,
+			claims:             [][3]string{{"a", "10Gi", "10Gi"}, {"b", "10Gi", "10Gi"}, {"c", "10Gi", "10Gi"}},
+			expectResizedPVCs:  []string{"data-demo-a", "data-demo-b"},
+			expectUnchangedPVC: []string{"data-demo-c"},
+		},
+		{
+			name:               "wait for pvcs in resizing",
+			maxUnavailable:     1,
+			allowExpansion:     true,
+			claims:             [][3]string{{"a", "20Gi", "10Gi"}, {"b", "10Gi", "10Gi"}, {"c", "10Gi", "10Gi"}},
+			expectUnchangedPVC: []string{"data-demo-b", "data-demo-c"},
+		},
+		{
+			name:               "storage class not allow expansion",
+			maxUnavailable:     1,
+			claims:             [][3]string{{"a", "10Gi", "10Gi"}, {"b", "10Gi", "10Gi"}},
+			expectEvents:       []string{"FailedResizePVC", "FailedResizePVC"},
+			expectUnchangedPVC: []string{"data-demo-a", "data-demo-b"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := newResizeTestCloneSet("20Gi", tc.maxUnavailable)
+			objs := []client.Object{&storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+				AllowVolumeExpansion: ptr.To(tc.allowExpansion),
+			}}
+			var pods []*v1.Pod
+			var claims []*v1.PersistentVolumeClaim
+			for _, c := range tc.claims {
+				pod, claim := newResizeTestPodAndClaim(c[0], c[1], c[2])
+				pods = append(pods, pod)
+				claims = append(claims, claim)
+				objs = append(objs, claim)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+			recorder := record.NewFakeRecorder(10)
+			ctrl := &realControl{Client: fakeClient, recorder: recorder}
+
+			ctrl.resizePVCs(cs, clonesetcore.New(cs), pods, claims)
+			for _, reason := range tc.expectEvents {
+				if event := <-recorder.Events; !strings.Contains(event, reason) {
+					t.Fatalf("expected event %s, got %s", reason, event)
+				}
+			}
+
+			getRequest := func(name string) string {
+				claim := &v1.PersistentVolumeClaim{}
+				if err := fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: name}, claim); err != nil {
+					t.Fatalf("failed to get pvc %s: %v", name, err)
+				}
+				return claim.Spec.Resources.Requests.Storage().String()
+			}
+			for _, name := range tc.expectResizedPVCs {
+				if got := getRequest(name); got != "20Gi" {
+					t.Fatalf("expected pvc %s resized to 20Gi, got %s", name, got)
+				}
+			}
+			for _, name := range tc.expectUnchangedPVC {
+				if got := getRequest(name); got != "10Gi" {
+					t.Fatalf("expected pvc %s unchanged, got %s", name, got)
+				}
+			}
+		})
+	}
+}
@@ -267,7 +267,7 @@ func (r *realControl) createOnePod(cs *appsv1alpha1.CloneSet, pod *v1.Pod, exist
 func (r *realControl) deletePods(cs *appsv1alpha1.CloneSet, podsToDelete []*v1.Pod, pvcs []*v1.PersistentVolumeClaim) (bool, error) {
 	var modified bool
 	for _, pod := range podsToDelete {
-		if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.PreDelete, pod) {
+		if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.PreDelete, pod, appspub.LifecycleStatePreparingDelete) {
 			markPodNotReady := cs.Spec.Lifecycle.PreDelete.MarkPodNotReady
 			if updated, gotPod, err := r.lifecycleControl.UpdatePodLifecycle(pod, appspub.LifecycleStatePreparingDelete, markPodNotReady); err != nil {
 				return false, err
@@ -280,6 +280,21 @@ func (r *realControl) deletePods(cs *appsv1alpha1.CloneSet, podsToDelete []*v1.P
 			continue
 		}
 
+		// the finalizers of PreDelete hook would block the deletion proceeding after timed out
+		if cs.Spec.Lifecycle != nil && lifecycle.GetPodLifecycleState(pod) == appspub.LifecycleStatePreparingDelete {
+			hook := cs.Spec.Lifecycle.PreDelete
+			if lifecycle.GetHookTimeoutAction(hook) == appspub.LifecycleHookTimeoutProceed && lifecycle.IsHookTimedOut(hook, pod) {
+				if updated, gotPod, err := r.lifecycleControl.RemovePodHookFinalizers(pod, hook); err != nil {
+					return modified, err
+				} else if updated {
+					klog.V(3).InfoS("CloneSet removed PreDelete finalizers of Pod timed out", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod))
+					modified = true
+					clonesetutils.ResourceVersionExpectations.Expect(gotPod)
+					pod = gotPod
+				}
+			}
+		}
+
 		clonesetutils.ScaleExpectations.ExpectScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
 		if err := r.Delete(context.TODO(), pod); err != nil {
 			clonesetutils.ScaleExpectations.ObserveScale(clonesetutils.GetControllerKey(cs), expectations.Delete, pod.Name)
@@ -385,6 +400,10 @@ func (r *realControl) choosePodsToDelete(cs *appsv1alpha1.CloneSet, totalDiff in
 					return IsPodAvailable(coreControl, pod, cs.Spec.MinReadySeconds)
 				},
 			})
+			if err := clonesetutils.SortPodsByScaleInRanker(cs, pods, diff); err != nil {
+				klog.ErrorS(err, "Failed to rank pods by scale-in ranker, fall back to built-in sorter", "cloneSet", klog.KObj(cs))
+				r.recorder.Eventf(cs, v1.EventTypeWarning, "ScaleInRankerFailed", "Failed to rank pods by scale-in ranker: %v", err)
+			}
 		} else if diff > len(pods) {
 			klog.InfoS("Diff > len(pods) in choosePodsToDelete func which is not expected")
 			return pods
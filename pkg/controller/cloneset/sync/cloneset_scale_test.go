@@ -46,6 +46,7 @@ import (
 	clonesetutils "github.com/openkruise/kruise/pkg/controller/cloneset/utils"
 	"github.com/openkruise/kruise/pkg/util"
 	"github.com/openkruise/kruise/pkg/util/expectations"
+	"github.com/openkruise/kruise/pkg/util/lifecycle"
 )
 
 var (
@@ -506,6 +507,51 @@ func TestDeletePods(t *testing.T) {
 	}
 }
 
+func TestDeletePodsWithPreDeleteHookTimedOut(t *testing.T) {
+	hook := &appspub.LifecycleHook{
+		FinalizersHandler: []string{"example.com/hook"},
+		TimeoutSeconds:    utilpointer.Int32(60),
+		OnTimeout:         appspub.LifecycleHookTimeoutProceed,
+	}
+	cs := &appsv1alpha1.CloneSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+		Spec:       appsv1alpha1.CloneSetSpec{Lifecycle: &appspub.Lifecycle{PreDelete: hook}},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "foo-id1",
+			Labels:     map[string]string{appspub.LifecycleStateKey: string(appspub.LifecycleStatePreparingDelete)},
+			Finalizers: []string{"example.com/hook", "example.com/other"},
+			Annotations: map[string]string{
+				appspub.LifecycleTimestampKey: time.Now().Add(-2 * time.Minute).Format(time.RFC3339),
+			},
+		},
+	}
+
+	ctrl := newFakeControl()
+	ctrl.lifecycleControl = lifecycle.New(ctrl.Client)
+	_ = ctrl.Create(context.TODO(), pod)
+
+	deleted, err := ctrl.deletePods(cs, []*v1.Pod{pod}, nil)
+	if err != nil {
+		t.Fatalf("failed to delete pods: %v", err)
+	} else if !deleted {
+		t.Fatalf("failed to delete pods: not deleted")
+	}
+
+	gotPod := &v1.Pod{}
+	if err := ctrl.Get(context.TODO(), client.ObjectKeyFromObject(pod), gotPod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if gotPod.DeletionTimestamp == nil {
+		t.Fatalf("expected pod deleting")
+	}
+	if !reflect.DeepEqual(gotPod.Finalizers, []string{"example.com/other"}) {
+		t.Fatalf("expected finalizers of hook removed, got %v", gotPod.Finalizers)
+	}
+}
+
 func TestGetOrGenAvailableIDs(t *testing.T) {
 	pods := []*v1.Pod{
 		{
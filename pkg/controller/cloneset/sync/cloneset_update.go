@@ -40,6 +40,7 @@ import (
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 	"github.com/openkruise/kruise/pkg/util/lifecycle"
+	"github.com/openkruise/kruise/pkg/util/requeueduration"
 	"github.com/openkruise/kruise/pkg/util/specifieddelete"
 	"github.com/openkruise/kruise/pkg/util/updatesort"
 )
@@ -83,6 +84,9 @@ func (c *realControl) Update(cs *appsv1alpha1.CloneSet,
 		return nil
 	}
 
+	// expand existing PVCs if the storage requests in volumeClaimTemplates grow
+	c.resizePVCs(cs, coreControl, pods, pvcs)
+
 	// 2. calculate update diff and the revision to update
 	diffRes := calculateDiffsWithExpectation(cs, pods, currentRevision.Name, updateRevision.Name, nil)
 	if diffRes.updateNum == 0 {
@@ -172,6 +176,31 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 		return false, 0, res.RefreshErr
 	}
 
+	hook := lifecycle.GetPodLifecycleHook(cs.Spec.Lifecycle, pod)
+	if marked, err := c.lifecycleControl.MarkPodHookTimedOut(pod, hook); err != nil {
+		klog.ErrorS(err, "CloneSet failed to mark pod lifecycle hook timed out", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod))
+		return false, 0, err
+	} else if marked {
+		c.recorder.Eventf(cs, v1.EventTypeWarning, "LifecycleHookTimeout", "pod %s timed out in lifecycle state %s, onTimeout %s",
+			pod.Name, lifecycle.GetPodLifecycleState(pod), lifecycle.GetHookTimeoutAction(hook))
+	}
+
+	hookUpdated, gotPod, hookRetryAfter, err := c.lifecycleControl.ExecuteHTTPHook(pod, hook)
+	if err != nil {
+		klog.ErrorS(err, "CloneSet failed to update pod lifecycle http hook status", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod))
+		return false, 0, err
+	} else if hookUpdated {
+		clonesetutils.ResourceVersionExpectations.Expect(gotPod)
+		klog.V(3).InfoS("CloneSet called pod lifecycle http hook", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod))
+		return true, hookRetryAfter, nil
+	}
+	delay := requeueduration.Duration{}
+	delay.Update(res.DelayDuration)
+	delay.Update(hookRetryAfter)
+	if remaining, ok := lifecycle.GetHookTimeoutRemaining(hook, pod); ok {
+		delay.Update(remaining)
+	}
+
 	var state appspub.LifecycleStateType
 	switch lifecycle.GetPodLifecycleState(pod) {
 	case appspub.LifecycleStatePreparingNormal:
@@ -184,7 +213,7 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 		if cs.Spec.Lifecycle == nil || cs.Spec.Lifecycle.PreNormal == nil {
 			shouldNormal = util.HasPodScheduled(pod)
 		} else {
-			shouldNormal = lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.PreNormal, pod)
+			shouldNormal = lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.PreNormal, pod, appspub.LifecycleStatePreparingNormal)
 		}
 
 		if shouldNormal {
@@ -195,7 +224,7 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 		// then rollback, do not need update pod inplace since it is the update revision,
 		// so just update pod lifecycle state. ref: https://github.com/openkruise/kruise/issues/1156
 		if clonesetutils.EqualToRevisionHash("", pod, updateRevision) {
-			if cs.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if cs.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 				state = appspub.LifecycleStateUpdated
 			} else {
 				state = appspub.LifecycleStateNormal
@@ -203,7 +232,7 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 		}
 	case appspub.LifecycleStateUpdating:
 		if opts.CheckPodUpdateCompleted(pod) == nil {
-			if cs.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if cs.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 				state = appspub.LifecycleStateUpdated
 			} else {
 				state = appspub.LifecycleStateNormal
@@ -212,7 +241,7 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 	case appspub.LifecycleStateUpdated:
 		if cs.Spec.Lifecycle == nil ||
 			cs.Spec.Lifecycle.InPlaceUpdate == nil ||
-			lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod) {
+			lifecycle.IsPodAllHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 			state = appspub.LifecycleStateNormal
 		}
 	}
@@ -227,11 +256,11 @@ func (c *realControl) refreshPodState(cs *appsv1alpha1.CloneSet, coreControl clo
 		} else if updated {
 			clonesetutils.ResourceVersionExpectations.Expect(gotPod)
 			klog.V(3).InfoS("CloneSet updated pod lifecycle", "cloneSet", klog.KObj(cs), "pod", klog.KObj(pod), "newState", state)
-			return true, res.DelayDuration, nil
+			return true, delay.Get(), nil
 		}
 	}
 
-	return false, res.DelayDuration, nil
+	return false, delay.Get(), nil
 }
 
 // fix the pod-template-hash label for old pods before v1.1
@@ -271,7 +300,7 @@ func (c *realControl) updatePod(cs *appsv1alpha1.CloneSet, coreControl clonesetc
 				var err error
 				var updated bool
 				var gotPod *v1.Pod
-				if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod) {
+				if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStatePreparingUpdate) {
 					markPodNotReady := cs.Spec.Lifecycle.InPlaceUpdate.MarkPodNotReady
 					if updated, gotPod, err = c.lifecycleControl.UpdatePodLifecycle(pod, appspub.LifecycleStatePreparingUpdate, markPodNotReady); err == nil && updated {
 						clonesetutils.ResourceVersionExpectations.Expect(gotPod)
@@ -293,7 +322,7 @@ func (c *realControl) updatePod(cs *appsv1alpha1.CloneSet, coreControl clonesetc
 				}
 				return 0, err
 			case appspub.LifecycleStatePreparingUpdate:
-				if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod) {
+				if cs.Spec.Lifecycle != nil && lifecycle.IsPodHooked(cs.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStatePreparingUpdate) {
 					return 0, nil
 				}
 			case appspub.LifecycleStateUpdating:
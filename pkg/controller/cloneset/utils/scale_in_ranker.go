@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+const (
+	defaultScaleInRankerTimeout = time.Second
+	maxScaleInRankerTimeout     = 10 * time.Second
+)
+
+// ScaleInRankerRequest is the body POSTed to the ScaleInRanker of CloneSet.
+type ScaleInRankerRequest struct {
+	Namespace string `json:"namespace"`
+	CloneSet  string `json:"cloneSet"`
+	// Count is the number of pods to delete among the ranked pods.
+	Count int                `json:"count"`
+	Pods  []ScaleInRankerPod `json:"pods"`
+}
+
+// ScaleInRankerPod is the state of a scheduled and ready pod to be ranked.
+type ScaleInRankerPod struct {
+	Name     string `json:"name"`
+	NodeName string `json:"nodeName"`
+	Revision string `json:"revision"`
+}
+
+// ScaleInRankerResponse is the body responded by the ScaleInRanker of CloneSet.
+type ScaleInRankerResponse struct {
+	// PodNames are the names of pods in the order to be deleted.
+	PodNames []string `json:"podNames"`
+}
+
+func getScaleInRankerTimeout(ranker *appsv1alpha1.CloneSetScaleInRanker) time.Duration {
+	if ranker.TimeoutSeconds == nil || *ranker.TimeoutSeconds <= 0 {
+		return defaultScaleInRankerTimeout
+	}
+	if timeout := time.Duration(*ranker.TimeoutSeconds) * time.Second; timeout < maxScaleInRankerTimeout {
+		return timeout
+	}
+	return maxScaleInRankerTimeout
+}
+
+// SortPodsByScaleInRanker reorders the pods sorted by built-in sorter with the order from ScaleInRanker of cs.
+// Unscheduled and not ready pods are always deleted first, so only the scheduled and ready pods are ranked.
+// Pods ranked by the endpoint come next, and the others keep their order.
+// Pods are not changed if it returns an error.
+func SortPodsByScaleInRanker(cs *appsv1alpha1.CloneSet, pods []*v1.Pod, count int) error {
+	ranker := cs.Spec.ScaleStrategy.ScaleInRanker
+	if ranker == nil || len(pods) == 0 {
+		return nil
+	}
+
+	sorted := make([]*v1.Pod, 0, len(pods))
+	podsByName := make(map[string]*v1.Pod, len(pods))
+	var rankerPods []ScaleInRankerPod
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || !podutil.IsPodReady(pod) {
+			sorted = append(sorted, pod)
+			continue
+		}
+		podsByName[pod.Name] = pod
+		rankerPods = append(rankerPods, ScaleInRankerPod{
+			Name:     pod.Name,
+			NodeName: pod.Spec.NodeName,
+			Revision: pod.Labels[apps.ControllerRevisionHashLabelKey],
+		})
+	}
+	if len(sorted) >= count || len(rankerPods) == 0 {
+		return nil
+	}
+
+	response := &ScaleInRankerResponse{}
+	err := httphook.Post(&httphook.Request{
+		URL: ranker.URL,
+		Body: &ScaleInRankerRequest{
+			Namespace: cs.Namespace,
+			CloneSet:  cs.Name,
+			Count:     count - len(sorted),
+			Pods:      rankerPods,
+		},
+		Timeout: getScaleInRankerTimeout(ranker),
+	}, response)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range response.PodNames {
+		if pod, ok := podsByName[name]; ok {
+			sorted = append(sorted, pod)
+			delete(podsByName, name)
+		}
+	}
+	for _, pod := range pods {
+		if _, ok := podsByName[pod.Name]; ok {
+			sorted = append(sorted, pod)
+		}
+	}
+	copy(pods, sorted)
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+func TestSortPodsByScaleInRanker(t *testing.T) {
+	allowedHosts := httphook.AllowedHosts
+	httphook.AllowedHosts = "127.0.0.1"
+	defer func() { httphook.AllowedHosts = allowedHosts }()
+
+	var gotRequest *ScaleInRankerRequest
+	var handler func(w http.ResponseWriter, r *http.Request)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = &ScaleInRankerRequest{}
+		_ = json.NewDecoder(r.Body).Decode(gotRequest)
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	respond := func(names ...string) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(&ScaleInRankerResponse{PodNames: names})
+		}
+	}
+	newPod := func(name, nodeName string, ready bool) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{apps.ControllerRevisionHashLabelKey: "rev"}},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+		}
+		return pod
+	}
+	readyPods := func() []*v1.Pod {
+		return []*v1.Pod{newPod("pod-a", "node-a", true), newPod("pod-b", "node-b", true), newPod("pod-c", "node-c", true)}
+	}
+
+	cases := []struct {
+		name          string
+		pods          []*v1.Pod
+		count         int
+		handler       func(w http.ResponseWriter, r *http.Request)
+		expectErr     bool
+		expectNoCall  bool
+		expectRequest *ScaleInRankerRequest
+		expected      []string
+	}{
+		{
+			name:    "ranked all pods",
+			pods:    readyPods(),
+			count:   2,
+			handler: respond("pod-c", "pod-a", "pod-b"),
+			expectRequest: &ScaleInRankerRequest{Namespace: "default", CloneSet: "demo", Count: 2, Pods: []ScaleInRankerPod{
+				{Name: "pod-a", NodeName: "node-a", Revision: "rev"},
+				{Name: "pod-b", NodeName: "node-b", Revision: "rev"},
+				{Name: "pod-c", NodeName: "node-c", Revision: "rev"},
+			}},
+			expected: []string{"pod-c", "pod-a", "pod-b"},
+		},
+		{
+			name:     "ranked part of pods with unknown and duplicated names",
+			pods:     readyPods(),
+			count:    2,
+			handler:  respond("pod-x", "pod-c", "pod-c"),
+			expected: []string{"pod-c", "pod-a", "pod-b"},
+		},
+		{
+			name:    "unscheduled and not ready pods first",
+			pods:    []*v1.Pod{newPod("pod-x", "", false), newPod("pod-y", "node-y", false), newPod("pod-a", "node-a", true), newPod("pod-b", "node-b", true)},
+			count:   3,
+			handler: respond("pod-b", "pod-x", "pod-a"),
+			expectRequest: &ScaleInRankerRequest{Namespace: "default", CloneSet: "demo", Count: 1, Pods: []ScaleInRankerPod{
+				{Name: "pod-a", NodeName: "node-a", Revision: "rev"},
+				{Name: "pod-b", NodeName: "node-b", Revision: "rev"},
+			}},
+			expected: []string{"pod-x", "pod-y", "pod-b", "pod-a"},
+		},
+		{
+			name:         "no need to rank",
+			pods:         []*v1.Pod{newPod("pod-x", "", false), newPod("pod-a", "node-a", true), newPod("pod-b", "node-b", true)},
+			count:        1,
+			handler:      respond("pod-b", "pod-a"),
+			expectNoCall: true,
+			expected:     []string{"pod-x", "pod-a", "pod-b"},
+		},
+		{
+			name: "error status",
+			pods: readyPods(),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			count:     2,
+			expectErr: true,
+			expected:  []string{"pod-a", "pod-b", "pod-c"},
+		},
+		{
+			name: "timeout",
+			pods: readyPods(),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+			count:     2,
+			expectErr: true,
+			expected:  []string{"pod-a", "pod-b", "pod-c"},
+		},
+	}
+
+	cs := &appsv1alpha1.CloneSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "demo"},
+		Spec: appsv1alpha1.CloneSetSpec{
+			ScaleStrategy: appsv1alpha1.CloneSetScaleStrategy{
+				ScaleInRanker: &appsv1alpha1.CloneSetScaleInRanker{URL: server.URL},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler = tc.handler
+			gotRequest = nil
+
+			err := SortPodsByScaleInRanker(cs, tc.pods, tc.count)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectNoCall && gotRequest != nil {
+				t.Fatalf("expected no call, got request %+v", gotRequest)
+			}
+			if tc.expectRequest != nil && !reflect.DeepEqual(gotRequest, tc.expectRequest) {
+				t.Fatalf("expected request %+v, got %+v", tc.expectRequest, gotRequest)
+			}
+			var names []string
+			for _, pod := range tc.pods {
+				names = append(names, pod.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlauchpriority
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+)
+
+// findDependencyKeys returns the barrier keys of all containers launched by dependencies in pod,
+// and the keys of those whose dependencies have been satisfied.
+func findDependencyKeys(pod *v1.Pod) (allKeys, satisfiedKeys []string) {
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if !utilcontainerlaunchpriority.HasDependencyBarrier(c) {
+			continue
+		}
+		key := utilcontainerlaunchpriority.GetDependencyKey(c.Name)
+		allKeys = append(allKeys, key)
+
+		dependencies, err := utilcontainerlaunchpriority.ParseContainerDependencies(c)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse container launch dependencies", "pod", klog.KObj(pod), "container", c.Name)
+			continue
+		}
+		satisfied := true
+		for _, dependency := range dependencies {
+			if !utilcontainerlaunchpriority.IsDependencySatisfied(pod, dependency) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			satisfiedKeys = append(satisfiedKeys, key)
+		}
+	}
+	return
+}
+
+func findMissingKeys(keys []string, barrier *v1.ConfigMap) (missing []string) {
+	for _, key := range keys {
+		if _, exists := barrier.Data[key]; !exists {
+			missing = append(missing, key)
+		}
+	}
+	return
+}
+
+func (r *ReconcileContainerLaunchPriority) handleDependencies(pod *v1.Pod, barrier *v1.ConfigMap, allKeys, satisfiedKeys []string) error {
+	if len(findMissingKeys(allKeys, barrier)) == 0 {
+		return r.patchCompleted(pod)
+	}
+
+	missing := findMissingKeys(satisfiedKeys, barrier)
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := r.addKeysIntoBarrier(barrier, missing); err != nil {
+		return err
+	}
+
+	// After adding the satisfied keys, if all containers have been released, mark as completed.
+	if len(findMissingKeys(allKeys, barrier)) == 0 {
+		return r.patchCompleted(pod)
+	}
+	return nil
+}
+
+func (r *ReconcileContainerLaunchPriority) addKeysIntoBarrier(barrier *v1.ConfigMap, keys []string) error {
+	klog.V(3).InfoS("Adding dependency keys into barrier", "keys", keys, "barrier", klog.KObj(barrier))
+	data := make(map[string]string, len(keys))
+	for _, key := range keys {
+		data[key] = "true"
+	}
+	body, _ := json.Marshal(map[string]interface{}{"data": data})
+	return r.Client.Patch(context.TODO(), barrier, client.RawPatch(types.StrategicMergePatchType, body))
+}
@@ -101,6 +101,14 @@ func shouldEnqueue(pod *v1.Pod, r client.Reader) bool {
 		return false
 	}
 
+	if allKeys, satisfiedKeys := findDependencyKeys(pod); len(allKeys) > 0 {
+		var barrier = &v1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: pod.GetNamespace(), Name: pod.Name + "-barrier"}, barrier); err != nil {
+			return true
+		}
+		return len(findMissingKeys(satisfiedKeys, barrier)) > 0 || len(findMissingKeys(allKeys, barrier)) == 0
+	}
+
 	nextPriorities := findNextPriorities(pod)
 	if len(nextPriorities) == 0 {
 		return false
@@ -183,6 +191,11 @@ func (r *ReconcileContainerLaunchPriority) Reconcile(_ context.Context, request
 }
 
 func (r *ReconcileContainerLaunchPriority) handle(pod *v1.Pod, barrier *v1.ConfigMap) error {
+	// Containers launched by dependencies are released once their dependencies are satisfied.
+	if allKeys, satisfiedKeys := findDependencyKeys(pod); len(allKeys) > 0 {
+		return r.handleDependencies(pod, barrier, allKeys, satisfiedKeys)
+	}
+
 	nextPriorities := findNextPriorities(pod)
 
 	// If there is no more priorities, or the lowest priority exists in barrier, mask as completed.
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -521,3 +522,103 @@ func TestShouldEnqueue(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDependencies(t *testing.T) {
+	namespace := "default"
+	podName := "fake-pod"
+	configMapName := "fake-pod-barrier"
+	dependsOn := func(value string) v1.EnvVar {
+		return v1.EnvVar{Name: appspub.ContainerLaunchDependenciesEnvName, Value: value}
+	}
+	newPod := func(statuses ...v1.ContainerStatus) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: podName},
+			Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "a", Env: []v1.EnvVar{dependsOn("b:Started,c"), utilcontainerlaunchpriority.GenerateDependencyEnv("a", podName)}},
+				{Name: "b"},
+				{Name: "c", Env: []v1.EnvVar{dependsOn("b:Started"), utilcontainerlaunchpriority.GenerateDependencyEnv("c", podName)}},
+				{Name: "d", Env: []v1.EnvVar{dependsOn("b"), utilcontainerlaunchpriority.GenerateDependencyEnv("d", podName)}},
+			}},
+			Status: v1.PodStatus{ContainerStatuses: statuses},
+		}
+	}
+	started := func(name string, ready bool) v1.ContainerStatus {
+		return v1.ContainerStatus{Name: name, Started: ptr.To(true), Ready: ready}
+	}
+	cases := []struct {
+		name              string
+		pod               *v1.Pod
+		existedKeys       []string
+		expectedKeys      []string
+		expectedEnqueue   bool
+		expectedCompleted bool
+	}{
+		{
+			name:         "no dependency satisfied",
+			pod:          newPod(),
+			expectedKeys: []string{},
+		},
+		{
+			name:            "started dependency satisfied",
+			pod:             newPod(started("b", false)),
+			expectedKeys:    []string{"d_c"},
+			expectedEnqueue: true,
+		},
+		{
+			name:              "started and ready dependencies satisfied",
+			pod:               newPod(started("b", true), started("c", true)),
+			existedKeys:       []string{"d_c"},
+			expectedKeys:      []string{"d_a", "d_c", "d_d"},
+			expectedEnqueue:   true,
+			expectedCompleted: true,
+		},
+		{
+			name:              "all released",
+			pod:               newPod(started("b", true), started("c", true)),
+			existedKeys:       []string{"d_a", "d_c", "d_d"},
+			expectedKeys:      []string{"d_a", "d_c", "d_d"},
+			expectedEnqueue:   true,
+			expectedCompleted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			barrier := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: configMapName},
+				Data:       map[string]string{},
+			}
+			for _, key := range tc.existedKeys {
+				barrier.Data[key] = "true"
+			}
+			cli := fake.NewClientBuilder().WithObjects(tc.pod, barrier).Build()
+			r := &ReconcileContainerLaunchPriority{Client: cli}
+
+			if got := shouldEnqueue(tc.pod, cli); got != tc.expectedEnqueue {
+				t.Fatalf("expected enqueue %v, got %v", tc.expectedEnqueue, got)
+			}
+			if err := r.handle(tc.pod, barrier); err != nil {
+				t.Fatal(err)
+			}
+			if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: configMapName}, barrier); err != nil {
+				t.Fatal(err)
+			}
+			if len(barrier.Data) != len(tc.expectedKeys) {
+				t.Fatalf("expected %v, got %v", tc.expectedKeys, barrier.Data)
+			}
+			for _, key := range tc.expectedKeys {
+				if barrier.Data[key] != "true" {
+					t.Fatalf("expected %v, got %v", tc.expectedKeys, barrier.Data)
+				}
+			}
+
+			gotPod := &v1.Pod{}
+			if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: podName}, gotPod); err != nil {
+				t.Fatal(err)
+			}
+			if gotCompleted := gotPod.Annotations[appspub.ContainerLaunchPriorityCompletedKey] == "true"; gotCompleted != tc.expectedCompleted {
+				t.Fatalf("expected completed %v, got %v", tc.expectedCompleted, gotCompleted)
+			}
+		})
+	}
+}
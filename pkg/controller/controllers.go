@@ -27,6 +27,7 @@ import (
 	containerlauchpriority "github.com/openkruise/kruise/pkg/controller/containerlaunchpriority"
 	"github.com/openkruise/kruise/pkg/controller/containerrecreaterequest"
 	"github.com/openkruise/kruise/pkg/controller/daemonset"
+	"github.com/openkruise/kruise/pkg/controller/enhancedlivenessprobe"
 	"github.com/openkruise/kruise/pkg/controller/ephemeraljob"
 	"github.com/openkruise/kruise/pkg/controller/imagelistpulljob"
 	"github.com/openkruise/kruise/pkg/controller/imagepulljob"
@@ -68,6 +69,7 @@ func init() {
 	controllerAddFuncs = append(controllerAddFuncs, podprobemarker.Add)
 	controllerAddFuncs = append(controllerAddFuncs, nodepodprobe.Add)
 	controllerAddFuncs = append(controllerAddFuncs, imagelistpulljob.Add)
+	controllerAddFuncs = append(controllerAddFuncs, enhancedlivenessprobe.Add)
 }
 
 func SetupWithManager(m manager.Manager) error {
@@ -51,6 +51,7 @@ import (
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
 	"k8s.io/kubernetes/pkg/controller/daemon/util"
 	"k8s.io/utils/integer"
+	"k8s.io/utils/ptr"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -76,6 +77,7 @@ import (
 	imagejobutilfunc "github.com/openkruise/kruise/pkg/util/imagejob/utilfunction"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 	"github.com/openkruise/kruise/pkg/util/lifecycle"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 	"github.com/openkruise/kruise/pkg/util/requeueduration"
 	"github.com/openkruise/kruise/pkg/util/revisionadapter"
@@ -322,6 +324,7 @@ func (dsc *ReconcileDaemonSet) Reconcile(ctx context.Context, request reconcile.
 			}
 		} else {
 			klog.ErrorS(retErr, "Failed syncing DaemonSet", "daemonSet", request)
+			workloadmetrics.RecordReconcileError("daemonset", retErr)
 		}
 		// clean the duration store
 		_ = durationStore.Pop(request.String())
@@ -374,6 +377,7 @@ func (dsc *ReconcileDaemonSet) syncDaemonSet(ctx context.Context, request reconc
 		if errors.IsNotFound(err) {
 			klog.V(4).InfoS("DaemonSet has been deleted", "daemonSet", request)
 			dsc.expectations.DeleteExpectations(logger, dsKey)
+			workloadmetrics.DeleteStatus(workloadmetrics.KindDaemonSet, request.Namespace, request.Name)
 			return nil
 		}
 		return fmt.Errorf("unable to retrieve DaemonSet %s from store: %v", dsKey, err)
@@ -448,6 +452,10 @@ func (dsc *ReconcileDaemonSet) syncDaemonSet(ctx context.Context, request reconc
 		return err
 	}
 
+	if err := dsc.syncNodeProfiles(ctx, ds, nodeList, hash); err != nil {
+		return err
+	}
+
 	// Process rolling updates if we're ready. For all kinds of update should not be executed if the update
 	// expectation is not satisfied.
 	if !isDaemonSetPaused(ds) {
@@ -524,6 +532,8 @@ func (dsc *ReconcileDaemonSet) updateDaemonSetStatus(ctx context.Context, ds *ap
 	}
 
 	var desiredNumberScheduled, currentNumberScheduled, numberMisscheduled, numberReady, updatedNumberScheduled, numberAvailable int
+	// the unavailable pods on unschedulable nodes are excluded from numberUnavailable
+	var numberUnavailableExcluded int
 	now := dsc.failedPodsBackoff.Clock.Now()
 	for _, node := range nodeList {
 		shouldRun, _ := nodeShouldRunDaemonPod(node, ds)
@@ -531,18 +541,27 @@ func (dsc *ReconcileDaemonSet) updateDaemonSetStatus(ctx context.Context, ds *ap
 
 		if shouldRun {
 			desiredNumberScheduled++
+			unschedulable := isNodeUnschedulableForUpdate(ds, node)
+			if unschedulable && !scheduled {
+				numberUnavailableExcluded++
+			}
 			if scheduled {
 				currentNumberScheduled++
 				// Sort the daemon pods by creation time, so that the oldest is first.
 				daemonPods := nodeToDaemonPods[node.Name]
 				sort.Sort(podByCreationTimestampAndPhase(daemonPods))
 				pod := daemonPods[0]
+				available := false
 				if podutil.IsPodReady(pod) {
 					numberReady++
 					if isDaemonPodAvailable(pod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}) {
 						numberAvailable++
+						available = true
 					}
 				}
+				if unschedulable && !available {
+					numberUnavailableExcluded++
+				}
 				// If the returned error is not nil we have a parse error.
 				// The controller handles this via the hash.
 				generation, err := GetTemplateGeneration(ds)
@@ -559,9 +578,22 @@ func (dsc *ReconcileDaemonSet) updateDaemonSetStatus(ctx context.Context, ds *ap
 			}
 		}
 	}
-	numberUnavailable := desiredNumberScheduled - numberAvailable
+	numberUnavailable := desiredNumberScheduled - numberAvailable - numberUnavailableExcluded
+
+	var waveStatuses []appsv1beta1.DaemonSetWaveStatus
+	var currentWave string
+	if waves := getUpdateWaves(ds); len(waves) > 0 {
+		nodeWaves, err := getNodeWaveIndexes(waves, nodeList)
+		if err != nil {
+			return fmt.Errorf("couldn't get node waves for DaemonSet %q: %v", ds.Name, err)
+		}
+		waveStatuses = calculateWaveStatuses(ds, nodeList, nodeToDaemonPods, nodeWaves, hash, now)
+		currentWave = getCurrentWaveName(ds, waveStatuses, now)
+	}
 
-	err = dsc.storeDaemonSetStatus(ctx, ds, desiredNumberScheduled, currentNumberScheduled, numberMisscheduled, numberReady, updatedNumberScheduled, numberAvailable, numberUnavailable, updateObservedGen, hash)
+	conditions := calculateLifecycleHookConditions(ds, nodeToDaemonPods)
+
+	err = dsc.storeDaemonSetStatus(ctx, ds, desiredNumberScheduled, currentNumberScheduled, numberMisscheduled, numberReady, updatedNumberScheduled, numberAvailable, numberUnavailable, updateObservedGen, hash, currentWave, waveStatuses, conditions)
 	if err != nil {
 		return fmt.Errorf("error storing status for DaemonSet %v: %v", ds.Name, err)
 	}
@@ -584,7 +616,19 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 	numberAvailable,
 	numberUnavailable int,
 	updateObservedGen bool,
-	hash string) error {
+	hash string,
+	currentWave string,
+	waveStatuses []appsv1beta1.DaemonSetWaveStatus,
+	conditions []apps.DaemonSetCondition) error {
+	available := int32(numberAvailable)
+	workloadmetrics.RecordStatus(workloadmetrics.KindDaemonSet, ds.Namespace, ds.Name, workloadmetrics.Status{
+		Desired:        int32(desiredNumberScheduled),
+		Updated:        int32(updatedNumberScheduled),
+		Ready:          int32(numberReady),
+		Available:      &available,
+		UpdateRevision: hash,
+		Paused:         ds.Spec.UpdateStrategy.RollingUpdate != nil && ptr.Deref(ds.Spec.UpdateStrategy.RollingUpdate.Paused, false),
+	})
 	if int(ds.Status.DesiredNumberScheduled) == desiredNumberScheduled &&
 		int(ds.Status.CurrentNumberScheduled) == currentNumberScheduled &&
 		int(ds.Status.NumberMisscheduled) == numberMisscheduled &&
@@ -593,7 +637,10 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 		int(ds.Status.NumberAvailable) == numberAvailable &&
 		int(ds.Status.NumberUnavailable) == numberUnavailable &&
 		ds.Status.ObservedGeneration >= ds.Generation &&
-		ds.Status.UpdateRevision == hash {
+		ds.Status.UpdateRevision == hash &&
+		ds.Status.CurrentWave == currentWave &&
+		reflect.DeepEqual(ds.Status.Waves, waveStatuses) &&
+		reflect.DeepEqual(ds.Status.Conditions, conditions) {
 		return nil
 	}
 
@@ -612,6 +659,9 @@ func (dsc *ReconcileDaemonSet) storeDaemonSetStatus(
 		toUpdate.Status.NumberAvailable = int32(numberAvailable)
 		toUpdate.Status.NumberUnavailable = int32(numberUnavailable)
 		toUpdate.Status.UpdateRevision = hash
+		toUpdate.Status.CurrentWave = currentWave
+		toUpdate.Status.Waves = waveStatuses
+		toUpdate.Status.Conditions = conditions
 
 		if _, updateErr = dsClient.UpdateStatus(ctx, toUpdate, metav1.UpdateOptions{}); updateErr == nil {
 			klog.InfoS("Updated DaemonSet status", "daemonSet", klog.KObj(ds), "status", kruiseutil.DumpJSON(toUpdate.Status))
@@ -749,6 +799,11 @@ func (dsc *ReconcileDaemonSet) syncNodes(ctx context.Context, ds *appsv1beta1.Da
 				var err error
 
 				podTemplate := template.DeepCopy()
+				if len(ds.Spec.NodeProfiles) > 0 {
+					if node, err := dsc.nodeLister.Get(nodesNeedingDaemonPods[ix]); err == nil {
+						ApplyNodeProfile(podTemplate, getNodeProfile(ds, node))
+					}
+				}
 				if scheduleDaemonSetPods {
 					// The pod's NodeAffinity will be updated to make sure the Pod is bound
 					// to the target node by default scheduler. It is safe to do so because there
@@ -825,7 +880,39 @@ func (dsc *ReconcileDaemonSet) syncWithPreparingDelete(ds *appsv1beta1.DaemonSet
 		} else if err != nil {
 			return nil, err
 		}
-		if !lifecycle.IsPodHooked(ds.Spec.Lifecycle.PreDelete, pod) {
+		if lifecycle.GetPodLifecycleState(pod) == appspub.LifecycleStatePreparingDelete {
+			hook := ds.Spec.Lifecycle.PreDelete
+			if marked, err := dsc.lifecycleControl.MarkPodHookTimedOut(pod, hook); err != nil {
+				return nil, err
+			} else if marked {
+				dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "LifecycleHookTimeout", "pod %s timed out in lifecycle state %s, onTimeout %s",
+					podName, appspub.LifecycleStatePreparingDelete, lifecycle.GetHookTimeoutAction(hook))
+			}
+			if remaining, ok := lifecycle.GetHookTimeoutRemaining(hook, pod); ok {
+				durationStore.Push(keyFunc(ds), remaining)
+			}
+			// the finalizers of hook would block the deletion proceeding after timed out
+			if lifecycle.GetHookTimeoutAction(hook) == appspub.LifecycleHookTimeoutProceed && lifecycle.IsHookTimedOut(hook, pod) {
+				if updated, gotPod, err := dsc.lifecycleControl.RemovePodHookFinalizers(pod, hook); err != nil {
+					return nil, err
+				} else if updated {
+					klog.V(3).InfoS("DaemonSet removed PreDelete finalizers of Pod timed out", "daemonSet", klog.KObj(ds), "podName", podName)
+					dsc.resourceVersionExpectations.Expect(gotPod)
+				}
+			}
+
+			updated, gotPod, retryAfter, err := dsc.lifecycleControl.ExecuteHTTPHook(pod, hook)
+			if err != nil {
+				return nil, err
+			}
+			durationStore.Push(keyFunc(ds), retryAfter)
+			if updated {
+				klog.V(3).InfoS("DaemonSet called PreDelete http hook of Pod", "daemonSet", klog.KObj(ds), "podName", podName)
+				dsc.resourceVersionExpectations.Expect(gotPod)
+				continue
+			}
+		}
+		if !lifecycle.IsPodHooked(ds.Spec.Lifecycle.PreDelete, pod, appspub.LifecycleStatePreparingDelete) {
 			podsCanDelete = append(podsCanDelete, podName)
 			continue
 		}
@@ -272,7 +272,9 @@ func (e *nodeEventHandler) Update(ctx context.Context, evt event.TypedUpdateEven
 		oldShouldRun, oldShouldContinueRunning := nodeShouldRunDaemonPod(oldNode, ds)
 		currentShouldRun, currentShouldContinueRunning := nodeShouldRunDaemonPod(curNode, ds)
 		if (oldShouldRun != currentShouldRun) || (oldShouldContinueRunning != currentShouldContinueRunning) ||
-			(NodeShouldUpdateBySelector(oldNode, ds) != NodeShouldUpdateBySelector(curNode, ds)) {
+			(NodeShouldUpdateBySelector(oldNode, ds) != NodeShouldUpdateBySelector(curNode, ds)) ||
+			(getNodeProfileName(ds, oldNode) != getNodeProfileName(ds, curNode)) ||
+			(isNodeUnschedulableForUpdate(ds, oldNode) != isNodeUnschedulableForUpdate(ds, curNode)) {
 			klog.V(6).InfoS("Update node triggers DaemonSet to reconcile", "nodeName", curNode.Name, "daemonSet", klog.KObj(ds))
 			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
 				Name:      ds.GetName(),
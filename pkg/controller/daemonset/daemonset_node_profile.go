@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/daemon/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	utilclient "github.com/openkruise/kruise/pkg/client"
+	"github.com/openkruise/kruise/pkg/features"
+	kruiseutil "github.com/openkruise/kruise/pkg/util"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
+	"github.com/openkruise/kruise/pkg/util/podadapter"
+)
+
+// getNodeProfile returns the first node profile the node matches, or nil if none matches.
+func getNodeProfile(ds *appsv1beta1.DaemonSet, node *corev1.Node) *appsv1beta1.DaemonSetNodeProfile {
+	for i := range ds.Spec.NodeProfiles {
+		profile := &ds.Spec.NodeProfiles[i]
+		selector, err := kruiseutil.ValidatedLabelSelectorAsSelector(profile.NodeSelector)
+		if err != nil {
+			// this should not happen if the DaemonSet passed validation
+			continue
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			return profile
+		}
+	}
+	return nil
+}
+
+func getNodeProfileName(ds *appsv1beta1.DaemonSet, node *corev1.Node) string {
+	if profile := getNodeProfile(ds, node); profile != nil {
+		return profile.Name
+	}
+	return ""
+}
+
+// ApplyNodeProfile merges the resources and env of the profile into the containers of pod template.
+func ApplyNodeProfile(template *corev1.PodTemplateSpec, profile *appsv1beta1.DaemonSetNodeProfile) {
+	if profile == nil {
+		return
+	}
+	for i := range profile.Containers {
+		containerProfile := &profile.Containers[i]
+		for j := range template.Spec.Containers {
+			c := &template.Spec.Containers[j]
+			if c.Name != containerProfile.Name {
+				continue
+			}
+			for name, quantity := range containerProfile.Resources.Limits {
+				if c.Resources.Limits == nil {
+					c.Resources.Limits = corev1.ResourceList{}
+				}
+				c.Resources.Limits[name] = quantity
+			}
+			for name, quantity := range containerProfile.Resources.Requests {
+				if c.Resources.Requests == nil {
+					c.Resources.Requests = corev1.ResourceList{}
+				}
+				c.Resources.Requests[name] = quantity
+			}
+			for _, env := range containerProfile.Env {
+				var found bool
+				for k := range c.Env {
+					if c.Env[k].Name == env.Name {
+						c.Env[k] = env
+						found = true
+						break
+					}
+				}
+				if !found {
+					c.Env = append(c.Env, env)
+				}
+			}
+		}
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] = profile.Name
+}
+
+// getNodeProfileResources returns the resources of containers to resize in-place, if the resources of pod
+// are different from the template with the node profile applied. Only cpu and memory can be resized in-place.
+func getNodeProfileResources(ds *appsv1beta1.DaemonSet, pod *corev1.Pod, profile *appsv1beta1.DaemonSetNodeProfile) (map[string]*corev1.ResourceRequirements, error) {
+	expected := ds.Spec.Template.DeepCopy()
+	ApplyNodeProfile(expected, profile)
+	verticalUpdate := inplaceupdate.GetNativeVerticalUpdateImpl()
+
+	resources := map[string]*corev1.ResourceRequirements{}
+	for i := range expected.Spec.Containers {
+		expectedContainer := &expected.Spec.Containers[i]
+		var container *corev1.Container
+		for j := range pod.Spec.Containers {
+			if pod.Spec.Containers[j].Name == expectedContainer.Name {
+				container = &pod.Spec.Containers[j]
+				break
+			}
+		}
+		if container == nil {
+			continue
+		}
+
+		var changed bool
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			for _, lists := range [][2]corev1.ResourceList{
+				{expectedContainer.Resources.Limits, container.Resources.Limits},
+				{expectedContainer.Resources.Requests, container.Resources.Requests},
+			} {
+				expectedQuantity, expectedExists := lists[0][name]
+				quantity, exists := lists[1][name]
+				if expectedExists != exists {
+					return nil, fmt.Errorf("can not add or remove %s of container %s in-place", name, container.Name)
+				}
+				if exists && !expectedQuantity.Equal(quantity) {
+					changed = true
+				}
+			}
+		}
+		if changed {
+			resources[container.Name] = &expectedContainer.Resources
+		}
+	}
+
+	if len(resources) > 0 {
+		oldTemplate := &corev1.PodTemplateSpec{Spec: pod.Spec}
+		newTemplate := &corev1.PodTemplateSpec{Spec: *pod.Spec.DeepCopy()}
+		verticalUpdate.UpdateResource(&corev1.Pod{Spec: newTemplate.Spec}, resources)
+		if verticalUpdate.IsPodQoSChanged(oldTemplate, newTemplate) {
+			return nil, fmt.Errorf("can not change the qos class of pod in-place")
+		}
+	}
+	return resources, nil
+}
+
+// syncNodeProfiles resizes the updated daemon pods in-place if the profile of their nodes changed or updated.
+// The env of profiles can not be updated in-place, which only applies to the newly created pods.
+func (dsc *ReconcileDaemonSet) syncNodeProfiles(ctx context.Context, ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string) error {
+	nodeToDaemonPods, err := dsc.getNodesToDaemonPods(ctx, ds)
+	if err != nil {
+		return fmt.Errorf("couldn't get node to daemon pod mapping for DaemonSet %q: %v", ds.Name, err)
+	}
+	generation, err := GetTemplateGeneration(ds)
+	if err != nil {
+		generation = nil
+	}
+
+	for _, node := range nodeList {
+		profile := getNodeProfile(ds, node)
+		for _, pod := range nodeToDaemonPods[node.Name] {
+			if pod.DeletionTimestamp != nil || !util.IsPodUpdated(pod, hash, generation) {
+				continue
+			}
+			// pods are resized back to the template if their profiles removed
+			resources, err := getNodeProfileResources(ds, pod, profile)
+			if err == nil && len(resources) == 0 && pod.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] == getNodeProfileName(ds, node) {
+				continue
+			}
+			if err != nil {
+				dsc.eventRecorder.Eventf(ds, corev1.EventTypeWarning, "FailedResizeNodeProfile",
+					"failed to resize pod %s in-place for node profile of %s: %v", pod.Name, node.Name, err)
+				continue
+			}
+			if len(resources) > 0 && !utilfeature.DefaultFeatureGate.Enabled(features.InPlaceWorkloadVerticalScaling) {
+				klog.V(4).InfoS("DaemonSet skipped resizing pod for node profile, for InPlaceWorkloadVerticalScaling disabled",
+					"daemonSet", klog.KObj(ds), "pod", klog.KObj(pod), "node", node.Name)
+				continue
+			}
+			if err := dsc.resizePodForNodeProfile(ctx, ds, pod, profile, resources); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (dsc *ReconcileDaemonSet) resizePodForNodeProfile(ctx context.Context, ds *appsv1beta1.DaemonSet, pod *corev1.Pod,
+	profile *appsv1beta1.DaemonSetNodeProfile, resources map[string]*corev1.ResourceRequirements) error {
+	profileName := ""
+	if profile != nil {
+		profileName = profile.Name
+	}
+	verticalUpdate := inplaceupdate.GetNativeVerticalUpdateImpl()
+	var updated *corev1.Pod
+	var err error
+	if utilclient.ShouldUpdateResourceByResize() {
+		if patch := verticalUpdate.GenerateResourcePatch(pod, resources); patch != nil {
+			adapter := &podadapter.AdapterTypedClient{Client: dsc.kubeClient}
+			if _, err = adapter.PatchPodResource(pod.DeepCopy(), client.RawPatch(types.StrategicMergePatchType, patch)); err != nil {
+				return fmt.Errorf("failed to resize pod %s: %v", pod.Name, err)
+			}
+		}
+		annotationValue := "null"
+		if profileName != "" {
+			annotationValue = fmt.Sprintf("%q", profileName)
+		}
+		body := fmt.Sprintf(`{"metadata":{"annotations":{"%s":%s}}}`, appsv1beta1.DaemonSetNodeProfileAnnotation, annotationValue)
+		updated, err = dsc.kubeClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, []byte(body), metav1.PatchOptions{})
+	} else {
+		clone := pod.DeepCopy()
+		verticalUpdate.UpdateResource(clone, resources)
+		if profileName == "" {
+			delete(clone.Annotations, appsv1beta1.DaemonSetNodeProfileAnnotation)
+		} else {
+			if clone.Annotations == nil {
+				clone.Annotations = map[string]string{}
+			}
+			clone.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] = profileName
+		}
+		updated, err = dsc.kubeClient.CoreV1().Pods(pod.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update pod %s for node profile: %v", pod.Name, err)
+	}
+	klog.V(3).InfoS("DaemonSet resized pod for node profile", "daemonSet", klog.KObj(ds), "pod", klog.KObj(pod), "profile", profileName)
+	dsc.eventRecorder.Eventf(ds, corev1.EventTypeNormal, "SuccessfulResizeNodeProfile", "resize pod %s for node profile %q", pod.Name, profileName)
+	dsc.resourceVersionExpectations.Expect(updated)
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"context"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func newNodeProfileDaemonSet() *appsv1beta1.DaemonSet {
+	ds := newDaemonSet("foo")
+	ds.Spec.Template.Spec.Containers[0].Name = "agent"
+	ds.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	ds.Spec.NodeProfiles = []appsv1beta1.DaemonSetNodeProfile{
+		{
+			Name:         "large",
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-class": "large"}},
+			Containers: []appsv1beta1.DaemonSetContainerProfile{{
+				Name: "agent",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				},
+				Env: []corev1.EnvVar{{Name: "WORKERS", Value: "32"}},
+			}},
+		},
+	}
+	return ds
+}
+
+func TestCreatePodsWithNodeProfile(t *testing.T) {
+	ds := newNodeProfileDaemonSet()
+	manager, podControl, _, err := newTestController(ds)
+	if err != nil {
+		t.Fatalf("error creating DaemonSets controller: %v", err)
+	}
+	_ = manager.nodeStore.Add(newNode("node-large", map[string]string{"node-class": "large"}))
+	_ = manager.nodeStore.Add(newNode("node-small", nil))
+	_ = manager.dsStore.Add(ds)
+	expectSyncDaemonSets(t, manager, ds, podControl, 2, 0, 0)
+
+	for _, template := range podControl.Templates {
+		container := template.Spec.Containers[0]
+		cpu := container.Resources.Limits[corev1.ResourceCPU]
+		memory := container.Resources.Limits[corev1.ResourceMemory]
+		switch template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values[0] {
+		case "node-large":
+			if cpu.String() != "8" || memory.String() != "1Gi" || len(container.Env) != 1 ||
+				template.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] != "large" {
+				t.Fatalf("expected large profile applied, got %+v, %v", container, template.Annotations)
+			}
+		default:
+			if cpu.String() != "1" || len(container.Env) != 0 || template.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] != "" {
+				t.Fatalf("expected no profile applied, got %+v, %v", container, template.Annotations)
+			}
+		}
+	}
+}
+
+func TestSyncNodeProfiles(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.InPlaceWorkloadVerticalScaling, true)()
+
+	tests := []struct {
+		name              string
+		nodeLabels        map[string]string
+		podProfile        string
+		podCPU            string
+		expectProfile     string
+		expectCPU         string
+		expectNotModified bool
+	}{
+		{
+			name:          "resize pod for node class changed to large",
+			nodeLabels:    map[string]string{"node-class": "large"},
+			podCPU:        "1",
+			expectProfile: "large",
+			expectCPU:     "8",
+		},
+		{
+			name:          "resize pod back to template for node class removed",
+			podProfile:    "large",
+			podCPU:        "8",
+			expectProfile: "",
+			expectCPU:     "1",
+		},
+		{
+			name:              "pod matches the node profile",
+			nodeLabels:        map[string]string{"node-class": "large"},
+			podProfile:        "large",
+			podCPU:            "8",
+			expectProfile:     "large",
+			expectCPU:         "8",
+			expectNotModified: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := newNodeProfileDaemonSet()
+			node := newNode("node-1", tc.nodeLabels)
+			pod := newPod("pod-1-", node.Name, simpleDaemonSetLabel, ds)
+			pod.Name = "pod-1"
+			pod.Spec = *pod.Spec.DeepCopy()
+			pod.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = resource.MustParse(tc.podCPU)
+			pod.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse(tc.podCPU)
+			if tc.podProfile != "" {
+				pod.Annotations = map[string]string{appsv1beta1.DaemonSetNodeProfileAnnotation: tc.podProfile}
+			}
+
+			manager, _, client, err := newTestController(ds, pod)
+			if err != nil {
+				t.Fatalf("error creating DaemonSets controller: %v", err)
+			}
+			_ = manager.nodeStore.Add(node)
+			_ = manager.podStore.Add(pod)
+			_ = manager.dsStore.Add(ds)
+
+			hash := pod.Labels[apps.DefaultDaemonSetUniqueLabelKey]
+			if err := manager.syncNodeProfiles(context.TODO(), ds, []*corev1.Node{node}, hash); err != nil {
+				t.Fatalf("failed to sync node profiles: %v", err)
+			}
+
+			got, err := client.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			cpu := got.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU]
+			if cpu.String() != tc.expectCPU || got.Annotations[appsv1beta1.DaemonSetNodeProfileAnnotation] != tc.expectProfile {
+				t.Fatalf("expected cpu %s and profile %q, got %s and %v", tc.expectCPU, tc.expectProfile, cpu.String(), got.Annotations)
+			}
+			var updated bool
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "update" && action.GetResource().Resource == "pods" {
+					updated = true
+				}
+			}
+			if updated == tc.expectNotModified {
+				t.Fatalf("expected pod modified %v, got %v", !tc.expectNotModified, updated)
+			}
+		})
+	}
+}
@@ -52,11 +52,22 @@ func (dsc *ReconcileDaemonSet) rollingUpdate(ctx context.Context, ds *appsv1beta
 		return fmt.Errorf("couldn't get unavailable numbers: %v", err)
 	}
 
-	// Advanced: filter the pods updated, updating and can update, according to partition and selector
-	nodeToDaemonPods, err = dsc.filterDaemonPodsToUpdate(ds, nodeList, hash, nodeToDaemonPods)
+	// Advanced: limit the nodes to update and maxUnavailable to the current wave, if waves are set
+	wave, err := dsc.getUpdateWave(ds, nodeList, hash, nodeToDaemonPods)
+	if err != nil {
+		return fmt.Errorf("failed to get update wave: %v", err)
+	}
+	if wave != nil && wave.maxUnavailable != nil && maxSurge == 0 {
+		maxUnavailable = *wave.maxUnavailable
+	}
+
+	// Advanced: filter the pods updated, updating and can update, according to partition, selector and wave
+	nodeToDaemonPods, err = dsc.filterDaemonPodsToUpdate(ds, nodeList, hash, nodeToDaemonPods, wave)
 	if err != nil {
 		return fmt.Errorf("failed to filterDaemonPodsToUpdate: %v", err)
 	}
+	// Advanced: exclude the unschedulable nodes, which are skipped or updated after the others
+	nodeToDaemonPods = filterUnschedulableNodesToUpdate(ds, nodeList, hash, nodeToDaemonPods)
 
 	now := dsc.failedPodsBackoff.Clock.Now()
 
@@ -281,7 +292,7 @@ func GetTemplateGeneration(ds *appsv1beta1.DaemonSet) (*int64, error) {
 	return &generation, nil
 }
 
-func (dsc *ReconcileDaemonSet) filterDaemonPodsToUpdate(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string, nodeToDaemonPods map[string][]*corev1.Pod) (map[string][]*corev1.Pod, error) {
+func (dsc *ReconcileDaemonSet) filterDaemonPodsToUpdate(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string, nodeToDaemonPods map[string][]*corev1.Pod, wave *updateWave) (map[string][]*corev1.Pod, error) {
 	existingNodes := sets.NewString()
 	for _, node := range nodeList {
 		existingNodes.Insert(node.Name)
@@ -292,7 +303,13 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsToUpdate(ds *appsv1beta1.DaemonSe
 		}
 	}
 
-	nodeNames, err := dsc.filterDaemonPodsNodeToUpdate(ds, hash, nodeToDaemonPods)
+	unschedulableNodes := sets.NewString()
+	for _, node := range nodeList {
+		if isNodeUnschedulableForUpdate(ds, node) {
+			unschedulableNodes.Insert(node.Name)
+		}
+	}
+	nodeNames, err := dsc.filterDaemonPodsNodeToUpdate(ds, hash, nodeToDaemonPods, wave, unschedulableNodes)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +321,11 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsToUpdate(ds *appsv1beta1.DaemonSe
 	return ret, nil
 }
 
-func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.DaemonSet, hash string, nodeToDaemonPods map[string][]*corev1.Pod) ([]string, error) {
+// filterDaemonPodsNodeToUpdate returns the nodes whose daemon pods have been updated, are updating or can be updated,
+// within the partition. The nodes in unschedulableNodes are skipped or put after the others to update according to
+// the unschedulableNodes strategy, and the skipped ones are still counted in the partition as they keep old pods.
+func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.DaemonSet, hash string, nodeToDaemonPods map[string][]*corev1.Pod,
+	wave *updateWave, unschedulableNodes sets.String) ([]string, error) {
 	var err error
 	var partition int32
 	var selector labels.Selector
@@ -327,10 +348,16 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.Daem
 	}
 	sort.Strings(allNodeNames)
 
+	var unschedulablePolicy appsv1beta1.UnschedulableNodesPolicyType
+	if strategy := getUnschedulableNodesStrategy(ds); strategy != nil {
+		unschedulablePolicy = strategy.Policy
+	}
+
 	var updated []string
 	var updating []string
 	var selected []string
 	var rest []string
+	var deprioritized []string
 	for i := len(allNodeNames) - 1; i >= 0; i-- {
 		nodeName := allNodeNames[i]
 
@@ -344,26 +371,39 @@ func (dsc *ReconcileDaemonSet) filterDaemonPodsNodeToUpdate(ds *appsv1beta1.Daem
 			continue
 		}
 
+		isSelected := true
 		if selector != nil {
 			node, err := dsc.nodeLister.Get(nodeName)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get node %v: %v", nodeName, err)
 			}
-			if selector.Matches(labels.Set(node.Labels)) {
+			isSelected = selector.Matches(labels.Set(node.Labels))
+		} else if wave != nil {
+			isSelected = wave.nodes.Has(nodeName)
+		}
+
+		switch {
+		case unschedulableNodes.Has(nodeName):
+			// the skipped nodes are not updated, and the deprioritized ones are updated after the others
+			if isSelected && unschedulablePolicy != appsv1beta1.SkipUnschedulableNodesPolicyType {
+				deprioritized = append(deprioritized, nodeName)
+			}
+		case selector != nil || wave != nil:
+			if isSelected {
 				selected = append(selected, nodeName)
-				continue
 			}
+		default:
+			rest = append(rest, nodeName)
 		}
-
-		rest = append(rest, nodeName)
 	}
 
 	sorted := append(updated, updating...)
-	if selector != nil {
+	if selector != nil || wave != nil {
 		sorted = append(sorted, selected...)
 	} else {
 		sorted = append(sorted, rest...)
 	}
+	sorted = append(sorted, deprioritized...)
 	if maxUpdate := len(allNodeNames) - int(partition); maxUpdate <= 0 {
 		return nil, nil
 	} else if maxUpdate < len(sorted) {
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -717,12 +718,13 @@ func TestGetTemplateGeneration(t *testing.T) {
 func TestFilterDaemonPodsNodeToUpdate(t *testing.T) {
 	now := metav1.Now()
 	type testcase struct {
-		name             string
-		rolling          *appsv1beta1.RollingUpdateDaemonSet
-		hash             string
-		nodeToDaemonPods map[string][]*corev1.Pod
-		nodes            []*corev1.Node
-		expectNodes      []string
+		name               string
+		rolling            *appsv1beta1.RollingUpdateDaemonSet
+		hash               string
+		nodeToDaemonPods   map[string][]*corev1.Pod
+		nodes              []*corev1.Node
+		unschedulableNodes []string
+		expectNodes        []string
 	}
 
 	tests := []testcase{
@@ -856,6 +858,81 @@ func TestFilterDaemonPodsNodeToUpdate(t *testing.T) {
 			},
 			expectNodes: []string{"n2", "n3", "n1"},
 		},
+		{
+			name: "Skip,partition=1,unschedulable=1",
+			rolling: &appsv1beta1.RollingUpdateDaemonSet{
+				Type:               appsv1beta1.StandardRollingUpdateType,
+				Partition:          &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: appsv1beta1.SkipUnschedulableNodesPolicyType},
+			},
+			hash: "v2",
+			nodeToDaemonPods: map[string][]*corev1.Pod{
+				"n1": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n2": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n3": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n4": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+			},
+			unschedulableNodes: []string{"n3"},
+			expectNodes:        []string{"n4", "n2", "n1"},
+		},
+		{
+			name: "Deprioritize,partition=1,unschedulable=1",
+			rolling: &appsv1beta1.RollingUpdateDaemonSet{
+				Type:               appsv1beta1.StandardRollingUpdateType,
+				Partition:          &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: appsv1beta1.DeprioritizeUnschedulableNodesPolicyType},
+			},
+			hash: "v2",
+			nodeToDaemonPods: map[string][]*corev1.Pod{
+				"n1": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n2": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n3": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n4": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+			},
+			unschedulableNodes: []string{"n3"},
+			expectNodes:        []string{"n4", "n2", "n1"},
+		},
+		{
+			name: "Deprioritize,partition=0,unschedulable=1",
+			rolling: &appsv1beta1.RollingUpdateDaemonSet{
+				Type:               appsv1beta1.StandardRollingUpdateType,
+				Partition:          &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: appsv1beta1.DeprioritizeUnschedulableNodesPolicyType},
+			},
+			hash: "v2",
+			nodeToDaemonPods: map[string][]*corev1.Pod{
+				"n1": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n2": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n3": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+				"n4": {
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "v1"}}},
+				},
+			},
+			unschedulableNodes: []string{"n3"},
+			expectNodes:        []string{"n4", "n2", "n1", "n3"},
+		},
 	}
 
 	testFn := func(test *testcase, t *testing.T) {
@@ -872,7 +949,7 @@ func TestFilterDaemonPodsNodeToUpdate(t *testing.T) {
 			Type:          appsv1beta1.RollingUpdateDaemonSetStrategyType,
 			RollingUpdate: test.rolling,
 		}}}
-		got, err := dsc.filterDaemonPodsNodeToUpdate(ds, test.hash, test.nodeToDaemonPods)
+		got, err := dsc.filterDaemonPodsNodeToUpdate(ds, test.hash, test.nodeToDaemonPods, nil, sets.NewString(test.unschedulableNodes...))
 		if err != nil {
 			t.Fatalf("failed to call filterDaemonPodsNodeToUpdate: %v", err)
 		}
@@ -28,7 +28,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/kubernetes/pkg/controller/daemon/util"
 	"k8s.io/utils/integer"
@@ -355,6 +357,76 @@ func NodeShouldUpdateBySelector(node *corev1.Node, ds *appsv1beta1.DaemonSet) bo
 	}
 }
 
+func getUnschedulableNodesStrategy(ds *appsv1beta1.DaemonSet) *appsv1beta1.DaemonSetUnschedulableNodesStrategy {
+	if ds.Spec.UpdateStrategy.Type != appsv1beta1.RollingUpdateDaemonSetStrategyType || ds.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return ds.Spec.UpdateStrategy.RollingUpdate.UnschedulableNodes
+}
+
+// isNodeUnschedulableForUpdate returns true if unschedulableNodes is set and the node is cordoned or has any of its taints.
+func isNodeUnschedulableForUpdate(ds *appsv1beta1.DaemonSet, node *corev1.Node) bool {
+	strategy := getUnschedulableNodesStrategy(ds)
+	if strategy == nil {
+		return false
+	}
+	if node.Spec.Unschedulable {
+		return true
+	}
+	for _, taint := range node.Spec.Taints {
+		for _, t := range strategy.Taints {
+			if taint.Key == t.Key && (t.Effect == "" || taint.Effect == t.Effect) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterUnschedulableNodesToUpdate removes the unschedulable nodes from nodeToDaemonPods, so that the pods on them
+// are neither updated nor counted against maxUnavailable and maxSurge. For Deprioritize policy, they are kept
+// if the pods on all the other nodes have been updated.
+func filterUnschedulableNodesToUpdate(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string, nodeToDaemonPods map[string][]*corev1.Pod) map[string][]*corev1.Pod {
+	strategy := getUnschedulableNodesStrategy(ds)
+	if strategy == nil {
+		return nodeToDaemonPods
+	}
+	unschedulableNodes := sets.NewString()
+	for _, node := range nodeList {
+		if _, ok := nodeToDaemonPods[node.Name]; ok && isNodeUnschedulableForUpdate(ds, node) {
+			unschedulableNodes.Insert(node.Name)
+		}
+	}
+	if unschedulableNodes.Len() == 0 {
+		return nodeToDaemonPods
+	}
+
+	if strategy.Policy != appsv1beta1.SkipUnschedulableNodesPolicyType {
+		othersUpdated := true
+		for nodeName, pods := range nodeToDaemonPods {
+			if unschedulableNodes.Has(nodeName) {
+				continue
+			}
+			if _, oldPod, ok := findUpdatedPodsOnNode(ds, pods, hash); !ok || oldPod != nil {
+				othersUpdated = false
+				break
+			}
+		}
+		if othersUpdated {
+			return nodeToDaemonPods
+		}
+	}
+
+	klog.V(5).InfoS("DaemonSet excluded unschedulable nodes from rolling update", "daemonSet", klog.KObj(ds), "nodes", unschedulableNodes.List())
+	ret := make(map[string][]*corev1.Pod, len(nodeToDaemonPods))
+	for nodeName, pods := range nodeToDaemonPods {
+		if !unschedulableNodes.Has(nodeName) {
+			ret[nodeName] = pods
+		}
+	}
+	return ret
+}
+
 func isPodPreDeleting(pod *corev1.Pod) bool {
 	return pod != nil && lifecycle.GetPodLifecycleState(pod) == appspub.LifecycleStatePreparingDelete
 }
@@ -371,3 +443,44 @@ func podAvailableWaitingTime(pod *corev1.Pod, minReadySeconds int32, now time.Ti
 	}
 	return minReadySecondsDuration - now.Sub(c.LastTransitionTime.Time)
 }
+
+// calculateLifecycleHookConditions returns the conditions of ds, with the pods timed out in lifecycle hook with
+// MarkFailed action listed in LifecycleHookFailed condition.
+func calculateLifecycleHookConditions(ds *appsv1beta1.DaemonSet, nodeToDaemonPods map[string][]*corev1.Pod) []apps.DaemonSetCondition {
+	var pods []*corev1.Pod
+	for _, daemonPods := range nodeToDaemonPods {
+		pods = append(pods, daemonPods...)
+	}
+	failedPods := lifecycle.GetPodsFailedInHook(ds.Spec.Lifecycle, pods)
+
+	var oldCondition *apps.DaemonSetCondition
+	var conditions []apps.DaemonSetCondition
+	for i := range ds.Status.Conditions {
+		if ds.Status.Conditions[i].Type == appsv1beta1.DaemonSetConditionLifecycleHookFailed {
+			oldCondition = &ds.Status.Conditions[i]
+			continue
+		}
+		conditions = append(conditions, ds.Status.Conditions[i])
+	}
+	if len(failedPods) == 0 {
+		if oldCondition == nil {
+			return ds.Status.Conditions
+		}
+		return conditions
+	}
+
+	condition := apps.DaemonSetCondition{
+		Type:               appsv1beta1.DaemonSetConditionLifecycleHookFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "LifecycleHookTimeout",
+		Message:            lifecycle.GetLifecycleHookFailedMessage(failedPods),
+	}
+	if oldCondition != nil {
+		if oldCondition.Status == condition.Status && oldCondition.Message == condition.Message {
+			return ds.Status.Conditions
+		}
+		condition.LastTransitionTime = oldCondition.LastTransitionTime
+	}
+	return append(conditions, condition)
+}
@@ -19,7 +19,9 @@ package daemonset
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +33,9 @@ import (
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
 	"k8s.io/kubernetes/pkg/securitycontext"
 	labelsutil "k8s.io/kubernetes/pkg/util/labels"
+	"k8s.io/utils/ptr"
 
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 )
 
@@ -719,3 +723,124 @@ func newStandardRollingUpdateStrategy(matchLabels map[string]string) appsv1beta1
 	}
 	return strategy
 }
+
+func TestFilterUnschedulableNodesToUpdate(t *testing.T) {
+	const hash = "new-hash"
+	newTestPod := func(nodeName, podHash string) *corev1.Pod {
+		pod := newPod(nodeName+"-", nodeName, nil, nil)
+		pod.Labels = map[string]string{apps.DefaultDaemonSetUniqueLabelKey: podHash}
+		return pod
+	}
+	cordoned := newNode("cordoned", nil)
+	cordoned.Spec.Unschedulable = true
+	draining := newNode("draining", nil)
+	draining.Spec.Taints = []corev1.Taint{{Key: "node.example.com/draining", Effect: corev1.TaintEffectNoSchedule}}
+	nodeList := []*corev1.Node{newNode("normal-1", nil), newNode("normal-2", nil), cordoned, draining}
+
+	tests := []struct {
+		name         string
+		strategy     *appsv1beta1.DaemonSetUnschedulableNodesStrategy
+		updatedNodes []string
+		expectNodes  []string
+	}{
+		{
+			name:        "unschedulableNodes not set",
+			expectNodes: []string{"cordoned", "draining", "normal-1", "normal-2"},
+		},
+		{
+			name:        "skip cordoned nodes",
+			strategy:    &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: appsv1beta1.SkipUnschedulableNodesPolicyType},
+			expectNodes: []string{"draining", "normal-1", "normal-2"},
+		},
+		{
+			name: "skip cordoned and tainted nodes",
+			strategy: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{
+				Policy: appsv1beta1.SkipUnschedulableNodesPolicyType,
+				Taints: []appsv1beta1.UnschedulableNodeTaint{{Key: "node.example.com/draining"}},
+			},
+			updatedNodes: []string{"normal-1", "normal-2"},
+			expectNodes:  []string{"normal-1", "normal-2"},
+		},
+		{
+			name: "deprioritize unschedulable nodes before the others updated",
+			strategy: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{
+				Policy: appsv1beta1.DeprioritizeUnschedulableNodesPolicyType,
+				Taints: []appsv1beta1.UnschedulableNodeTaint{{Key: "node.example.com/draining", Effect: corev1.TaintEffectNoExecute}},
+			},
+			updatedNodes: []string{"normal-1"},
+			expectNodes:  []string{"draining", "normal-1", "normal-2"},
+		},
+		{
+			name: "deprioritize unschedulable nodes after the others updated",
+			strategy: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{
+				Policy: appsv1beta1.DeprioritizeUnschedulableNodesPolicyType,
+				Taints: []appsv1beta1.UnschedulableNodeTaint{{Key: "node.example.com/draining"}},
+			},
+			updatedNodes: []string{"normal-1", "normal-2"},
+			expectNodes:  []string{"cordoned", "draining", "normal-1", "normal-2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := newDaemonSet("foo")
+			ds.Spec.UpdateStrategy = newStandardRollingUpdateStrategy(nil)
+			ds.Spec.UpdateStrategy.RollingUpdate.UnschedulableNodes = tc.strategy
+			nodeToDaemonPods := map[string][]*corev1.Pod{}
+			for _, node := range nodeList {
+				nodeToDaemonPods[node.Name] = []*corev1.Pod{newTestPod(node.Name, "old-hash")}
+			}
+			for _, nodeName := range tc.updatedNodes {
+				nodeToDaemonPods[nodeName] = []*corev1.Pod{newTestPod(nodeName, hash)}
+			}
+
+			got := filterUnschedulableNodesToUpdate(ds, nodeList, hash, nodeToDaemonPods)
+			var gotNodes []string
+			for _, node := range nodeList {
+				if _, ok := got[node.Name]; ok {
+					gotNodes = append(gotNodes, node.Name)
+				}
+			}
+			sort.Strings(gotNodes)
+			if !reflect.DeepEqual(gotNodes, tc.expectNodes) {
+				t.Fatalf("expected nodes %v, got %v", tc.expectNodes, gotNodes)
+			}
+		})
+	}
+}
+
+func TestCalculateLifecycleHookConditions(t *testing.T) {
+	ds := newDaemonSet("foo")
+	ds.Spec.Lifecycle = &appspub.Lifecycle{
+		PreDelete: &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](60), OnTimeout: appspub.LifecycleHookTimeoutMarkFailed},
+	}
+	otherCondition := apps.DaemonSetCondition{Type: "Other", Status: corev1.ConditionTrue}
+	ds.Status.Conditions = []apps.DaemonSetCondition{otherCondition}
+
+	newHookedPod := func(name string, timestamp time.Time) *corev1.Pod {
+		pod := newPod(name+"-", name, simpleDaemonSetLabel, ds)
+		pod.Labels[appspub.LifecycleStateKey] = string(appspub.LifecycleStatePreparingDelete)
+		pod.Annotations = map[string]string{appspub.LifecycleTimestampKey: timestamp.Format(time.RFC3339)}
+		return pod
+	}
+	nodeToDaemonPods := map[string][]*corev1.Pod{
+		"node-1": {newHookedPod("node-1", time.Now().Add(-time.Hour))},
+		"node-2": {newHookedPod("node-2", time.Now())},
+	}
+
+	conditions := calculateLifecycleHookConditions(ds, nodeToDaemonPods)
+	if len(conditions) != 2 || conditions[1].Type != appsv1beta1.DaemonSetConditionLifecycleHookFailed ||
+		conditions[1].Message != "pods timed out in lifecycle hook: "+nodeToDaemonPods["node-1"][0].Name+"(PreparingDelete)" {
+		t.Fatalf("unexpected conditions %v", conditions)
+	}
+
+	ds.Status.Conditions = conditions
+	if got := calculateLifecycleHookConditions(ds, nodeToDaemonPods); !reflect.DeepEqual(got, conditions) {
+		t.Fatalf("expected conditions unchanged, got %v", got)
+	}
+
+	delete(nodeToDaemonPods, "node-1")
+	if got := calculateLifecycleHookConditions(ds, nodeToDaemonPods); !reflect.DeepEqual(got, []apps.DaemonSetCondition{otherCondition}) {
+		t.Fatalf("expected LifecycleHookFailed condition removed, got %v", got)
+	}
+}
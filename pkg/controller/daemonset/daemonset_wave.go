@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/daemon/util"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruiseutil "github.com/openkruise/kruise/pkg/util"
+)
+
+// updateWave is the wave in rolling update.
+type updateWave struct {
+	// nodes are the nodes allowed to update, which belong to the current and previous waves.
+	nodes sets.String
+	// maxUnavailable overrides the maxUnavailable of rolling update if not nil.
+	maxUnavailable *int
+}
+
+func getUpdateWaves(ds *appsv1beta1.DaemonSet) []appsv1beta1.DaemonSetUpdateWave {
+	if ds.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return ds.Spec.UpdateStrategy.RollingUpdate.Waves
+}
+
+// getNodeWaveIndexes returns the index of wave each node belongs to. The index of nodes matching none of the waves is len(waves).
+func getNodeWaveIndexes(waves []appsv1beta1.DaemonSetUpdateWave, nodeList []*corev1.Node) (map[string]int, error) {
+	selectors := make([]labels.Selector, len(waves))
+	for i := range waves {
+		selector, err := kruiseutil.ValidatedLabelSelectorAsSelector(waves[i].NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		selectors[i] = selector
+	}
+	indexes := make(map[string]int, len(nodeList))
+	for _, node := range nodeList {
+		indexes[node.Name] = len(waves)
+		for i, selector := range selectors {
+			if selector.Matches(labels.Set(node.Labels)) {
+				indexes[node.Name] = i
+				break
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// calculateWaveStatuses counts the daemon pods of each wave, and keeps the completion time of waves once completed in the update revision.
+// The unschedulable nodes excluded from rolling update are not counted.
+func calculateWaveStatuses(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, nodeToDaemonPods map[string][]*corev1.Pod,
+	nodeWaves map[string]int, hash string, now time.Time) []appsv1beta1.DaemonSetWaveStatus {
+	waves := getUpdateWaves(ds)
+	if len(waves) == 0 {
+		return nil
+	}
+	statuses := make([]appsv1beta1.DaemonSetWaveStatus, len(waves))
+	for i := range waves {
+		statuses[i].Name = waves[i].Name
+	}
+	generation, err := GetTemplateGeneration(ds)
+	if err != nil {
+		generation = nil
+	}
+	for _, node := range nodeList {
+		index := nodeWaves[node.Name]
+		if index >= len(waves) {
+			continue
+		}
+		if shouldRun, _ := nodeShouldRunDaemonPod(node, ds); !shouldRun {
+			continue
+		}
+		// the unschedulable nodes are skipped or updated after all the waves
+		if isNodeUnschedulableForUpdate(ds, node) {
+			continue
+		}
+		statuses[index].DesiredNumberScheduled++
+		daemonPods := nodeToDaemonPods[node.Name]
+		if len(daemonPods) == 0 {
+			continue
+		}
+		sort.Sort(podByCreationTimestampAndPhase(daemonPods))
+		pod := daemonPods[0]
+		if !util.IsPodUpdated(pod, hash, generation) {
+			continue
+		}
+		statuses[index].UpdatedNumberScheduled++
+		if isDaemonPodAvailable(pod, ds.Spec.MinReadySeconds, metav1.Time{Time: now}) {
+			statuses[index].UpdatedNumberAvailable++
+		}
+	}
+
+	for i := range statuses {
+		// the completion time is kept even if some pods become unavailable later, unless a new revision comes,
+		// so that the completed waves are not soaked again
+		if ds.Status.UpdateRevision == hash {
+			for _, old := range ds.Status.Waves {
+				if old.Name == statuses[i].Name && old.CompletionTime != nil {
+					statuses[i].CompletionTime = old.CompletionTime
+				}
+			}
+		}
+		if statuses[i].CompletionTime == nil && statuses[i].UpdatedNumberAvailable >= statuses[i].DesiredNumberScheduled {
+			statuses[i].CompletionTime = &metav1.Time{Time: now}
+		}
+	}
+	return statuses
+}
+
+// getCurrentWaveIndex returns the index of the first wave in rolling update or soaking, and the time to wait for soaking.
+// It returns len(waves) if all waves have been finished.
+func getCurrentWaveIndex(waves []appsv1beta1.DaemonSetUpdateWave, statuses []appsv1beta1.DaemonSetWaveStatus, now time.Time) (int, time.Duration) {
+	for i := range waves {
+		if statuses[i].CompletionTime == nil {
+			return i, 0
+		}
+		if statuses[i].DesiredNumberScheduled == 0 || waves[i].SoakSeconds <= 0 {
+			continue
+		}
+		if soaked := statuses[i].CompletionTime.Add(time.Duration(waves[i].SoakSeconds) * time.Second); soaked.After(now) {
+			return i, soaked.Sub(now)
+		}
+	}
+	return len(waves), 0
+}
+
+// getCurrentWaveName returns the name of the current wave for status, or empty if all waves have been finished.
+func getCurrentWaveName(ds *appsv1beta1.DaemonSet, statuses []appsv1beta1.DaemonSetWaveStatus, now time.Time) string {
+	waves := getUpdateWaves(ds)
+	if index, _ := getCurrentWaveIndex(waves, statuses, now); index < len(waves) {
+		return waves[index].Name
+	}
+	return ""
+}
+
+// getUpdateWave returns the wave in rolling update, or nil if waves are not set.
+func (dsc *ReconcileDaemonSet) getUpdateWave(ds *appsv1beta1.DaemonSet, nodeList []*corev1.Node, hash string, nodeToDaemonPods map[string][]*corev1.Pod) (*updateWave, error) {
+	waves := getUpdateWaves(ds)
+	if len(waves) == 0 {
+		return nil, nil
+	}
+	nodeWaves, err := getNodeWaveIndexes(waves, nodeList)
+	if err != nil {
+		return nil, err
+	}
+	now := dsc.failedPodsBackoff.Clock.Now()
+	statuses := calculateWaveStatuses(ds, nodeList, nodeToDaemonPods, nodeWaves, hash, now)
+	index, wait := getCurrentWaveIndex(waves, statuses, now)
+	if wait > 0 {
+		klog.V(4).InfoS("DaemonSet was soaking wave", "daemonSet", klog.KObj(ds), "wave", waves[index].Name, "wait", wait)
+		durationStore.Push(keyFunc(ds), wait)
+	}
+
+	wave := &updateWave{nodes: sets.NewString()}
+	for name, i := range nodeWaves {
+		if i <= index {
+			wave.nodes.Insert(name)
+		}
+	}
+	if index < len(waves) && waves[index].MaxUnavailable != nil {
+		maxUnavailable, err := intstrutil.GetScaledValueFromIntOrPercent(waves[index].MaxUnavailable, int(statuses[index].DesiredNumberScheduled), true)
+		if err != nil {
+			return nil, err
+		}
+		if maxUnavailable < 1 {
+			maxUnavailable = 1
+		}
+		wave.maxUnavailable = &maxUnavailable
+	}
+	return wave, nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemonset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/flowcontrol"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestGetUpdateWave(t *testing.T) {
+	const hash = "new-hash"
+	now := time.Unix(1000, 0)
+
+	newWaveDaemonSet := func() *appsv1beta1.DaemonSet {
+		ds := newDaemonSet("foo")
+		ds.Spec.UpdateStrategy = appsv1beta1.DaemonSetUpdateStrategy{
+			Type: appsv1beta1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{
+						Name:           "canary",
+						NodeSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}},
+						MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+						SoakSeconds:    60,
+					},
+					{
+						Name:           "main",
+						NodeSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "main"}},
+						MaxUnavailable: ptr.To(intstr.FromString("50%")),
+					},
+				},
+			},
+		}
+		ds.Status.UpdateRevision = hash
+		return ds
+	}
+	nodeList := []*corev1.Node{
+		newNode("canary-1", map[string]string{"pool": "canary"}),
+		newNode("canary-2", map[string]string{"pool": "canary"}),
+		newNode("main-1", map[string]string{"pool": "main"}),
+		newNode("main-2", map[string]string{"pool": "main"}),
+		newNode("main-3", map[string]string{"pool": "main"}),
+		newNode("main-4", map[string]string{"pool": "main"}),
+		newNode("other-1", nil),
+	}
+	newNodeToDaemonPods := func(updatedNodes ...string) map[string][]*corev1.Pod {
+		nodeToDaemonPods := map[string][]*corev1.Pod{}
+		for _, node := range nodeList {
+			pod := newPod(node.Name+"-", node.Name, simpleDaemonSetLabel, nil)
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Time{Time: now.Add(-time.Hour)}}}
+			pod.Labels = map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "old-hash"}
+			for _, name := range updatedNodes {
+				if name == node.Name {
+					pod.Labels[apps.DefaultDaemonSetUniqueLabelKey] = hash
+				}
+			}
+			nodeToDaemonPods[node.Name] = []*corev1.Pod{pod}
+		}
+		return nodeToDaemonPods
+	}
+
+	tests := []struct {
+		name                 string
+		completionTimes      map[string]time.Time
+		updatedNodes         []string
+		expectCurrentWave    string
+		expectNodes          []string
+		expectMaxUnavailable *int
+		expectWaveStatuses   []appsv1beta1.DaemonSetWaveStatus
+	}{
+		{
+			name:                 "start from the first wave",
+			expectCurrentWave:    "canary",
+			expectNodes:          []string{"canary-1", "canary-2"},
+			expectMaxUnavailable: ptr.To(1),
+			expectWaveStatuses: []appsv1beta1.DaemonSetWaveStatus{
+				{Name: "canary", DesiredNumberScheduled: 2},
+				{Name: "main", DesiredNumberScheduled: 4},
+			},
+		},
+		{
+			name:                 "soak the first wave",
+			completionTimes:      map[string]time.Time{"canary": now.Add(-30 * time.Second)},
+			updatedNodes:         []string{"canary-1", "canary-2"},
+			expectCurrentWave:    "canary",
+			expectNodes:          []string{"canary-1", "canary-2"},
+			expectMaxUnavailable: ptr.To(1),
+			expectWaveStatuses: []appsv1beta1.DaemonSetWaveStatus{
+				{Name: "canary", DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, UpdatedNumberAvailable: 2, CompletionTime: &metav1.Time{Time: now.Add(-30 * time.Second)}},
+				{Name: "main", DesiredNumberScheduled: 4},
+			},
+		},
+		{
+			name:                 "move to the second wave after soaked",
+			completionTimes:      map[string]time.Time{"canary": now.Add(-90 * time.Second)},
+			updatedNodes:         []string{"canary-1", "canary-2", "main-1"},
+			expectCurrentWave:    "main",
+			expectNodes:          []string{"canary-1", "canary-2", "main-1", "main-2", "main-3", "main-4"},
+			expectMaxUnavailable: ptr.To(2),
+			expectWaveStatuses: []appsv1beta1.DaemonSetWaveStatus{
+				{Name: "canary", DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, UpdatedNumberAvailable: 2, CompletionTime: &metav1.Time{Time: now.Add(-90 * time.Second)}},
+				{Name: "main", DesiredNumberScheduled: 4, UpdatedNumberScheduled: 1, UpdatedNumberAvailable: 1},
+			},
+		},
+		{
+			name:                 "keep the first wave completed after a pod became unavailable",
+			completionTimes:      map[string]time.Time{"canary": now.Add(-90 * time.Second)},
+			updatedNodes:         []string{"canary-1", "main-1"},
+			expectCurrentWave:    "main",
+			expectNodes:          []string{"canary-1", "canary-2", "main-1", "main-2", "main-3", "main-4"},
+			expectMaxUnavailable: ptr.To(2),
+			expectWaveStatuses: []appsv1beta1.DaemonSetWaveStatus{
+				{Name: "canary", DesiredNumberScheduled: 2, UpdatedNumberScheduled: 1, UpdatedNumberAvailable: 1, CompletionTime: &metav1.Time{Time: now.Add(-90 * time.Second)}},
+				{Name: "main", DesiredNumberScheduled: 4, UpdatedNumberScheduled: 1, UpdatedNumberAvailable: 1},
+			},
+		},
+		{
+			name:              "update the rest nodes after all waves",
+			completionTimes:   map[string]time.Time{"canary": now.Add(-90 * time.Second)},
+			updatedNodes:      []string{"canary-1", "canary-2", "main-1", "main-2", "main-3", "main-4"},
+			expectCurrentWave: "",
+			expectNodes:       []string{"canary-1", "canary-2", "main-1", "main-2", "main-3", "main-4", "other-1"},
+			expectWaveStatuses: []appsv1beta1.DaemonSetWaveStatus{
+				{Name: "canary", DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, UpdatedNumberAvailable: 2, CompletionTime: &metav1.Time{Time: now.Add(-90 * time.Second)}},
+				{Name: "main", DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, UpdatedNumberAvailable: 4, CompletionTime: &metav1.Time{Time: now}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := newWaveDaemonSet()
+			for name, completionTime := range tc.completionTimes {
+				ds.Status.Waves = append(ds.Status.Waves, appsv1beta1.DaemonSetWaveStatus{Name: name, CompletionTime: &metav1.Time{Time: completionTime}})
+			}
+			nodeToDaemonPods := newNodeToDaemonPods(tc.updatedNodes...)
+			dsc := &ReconcileDaemonSet{failedPodsBackoff: flowcontrol.NewFakeBackOff(time.Second, time.Minute, testingclock.NewFakeClock(now))}
+
+			wave, err := dsc.getUpdateWave(ds, nodeList, hash, nodeToDaemonPods)
+			if err != nil {
+				t.Fatalf("failed to get update wave: %v", err)
+			}
+			if !reflect.DeepEqual(wave.nodes.List(), tc.expectNodes) {
+				t.Fatalf("expected nodes %v, got %v", tc.expectNodes, wave.nodes.List())
+			}
+			if !reflect.DeepEqual(wave.maxUnavailable, tc.expectMaxUnavailable) {
+				t.Fatalf("expected maxUnavailable %v, got %v", ptr.Deref(tc.expectMaxUnavailable, -1), ptr.Deref(wave.maxUnavailable, -1))
+			}
+
+			nodeWaves, _ := getNodeWaveIndexes(getUpdateWaves(ds), nodeList)
+			statuses := calculateWaveStatuses(ds, nodeList, nodeToDaemonPods, nodeWaves, hash, now)
+			if !reflect.DeepEqual(statuses, tc.expectWaveStatuses) {
+				t.Fatalf("expected wave statuses %+v, got %+v", tc.expectWaveStatuses, statuses)
+			}
+			if currentWave := getCurrentWaveName(ds, statuses, now); currentWave != tc.expectCurrentWave {
+				t.Fatalf("expected current wave %q, got %q", tc.expectCurrentWave, currentWave)
+			}
+		})
+	}
+}
+
+func TestCalculateWaveStatusesWithUnschedulableNodes(t *testing.T) {
+	const hash = "new-hash"
+	now := time.Unix(1000, 0)
+
+	ds := newDaemonSet("foo")
+	ds.Spec.UpdateStrategy = appsv1beta1.DaemonSetUpdateStrategy{
+		Type: appsv1beta1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+			Waves: []appsv1beta1.DaemonSetUpdateWave{
+				{Name: "canary", NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+			},
+			UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: appsv1beta1.SkipUnschedulableNodesPolicyType},
+		},
+	}
+	ds.Status.UpdateRevision = hash
+	cordoned := newNode("canary-2", map[string]string{"pool": "canary"})
+	cordoned.Spec.Unschedulable = true
+	nodeList := []*corev1.Node{newNode("canary-1", map[string]string{"pool": "canary"}), cordoned}
+	nodeToDaemonPods := map[string][]*corev1.Pod{}
+	for _, node := range nodeList {
+		pod := newPod(node.Name+"-", node.Name, simpleDaemonSetLabel, nil)
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Time{Time: now.Add(-time.Hour)}}}
+		pod.Labels = map[string]string{apps.DefaultDaemonSetUniqueLabelKey: "old-hash"}
+		nodeToDaemonPods[node.Name] = []*corev1.Pod{pod}
+	}
+	nodeToDaemonPods["canary-1"][0].Labels[apps.DefaultDaemonSetUniqueLabelKey] = hash
+
+	nodeWaves, _ := getNodeWaveIndexes(getUpdateWaves(ds), nodeList)
+	statuses := calculateWaveStatuses(ds, nodeList, nodeToDaemonPods, nodeWaves, hash, now)
+	expectWaveStatuses := []appsv1beta1.DaemonSetWaveStatus{
+		{Name: "canary", DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, UpdatedNumberAvailable: 1, CompletionTime: &metav1.Time{Time: now}},
+	}
+	if !reflect.DeepEqual(statuses, expectWaveStatuses) {
+		t.Fatalf("expected wave statuses %+v, got %+v", expectWaveStatuses, statuses)
+	}
+}
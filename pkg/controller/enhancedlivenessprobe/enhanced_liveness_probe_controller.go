@@ -0,0 +1,376 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/ratelimiter"
+)
+
+func init() {
+	flag.IntVar(&concurrentReconciles, "enhancedlivenessprobe-workers", concurrentReconciles, "Max concurrent workers for EnhancedLivenessProbe controller.")
+	flag.Float64Var(&globalRestartQPS, "enhancedlivenessprobe-global-restart-qps", globalRestartQPS,
+		"Max container restarts per second caused by enhanced liveness probe failures in the cluster.")
+	flag.IntVar(&globalRestartBurst, "enhancedlivenessprobe-global-restart-burst", globalRestartBurst,
+		"Max burst of container restarts caused by enhanced liveness probe failures in the cluster.")
+	flag.Float64Var(&workloadRestartQPS, "enhancedlivenessprobe-workload-restart-qps", workloadRestartQPS,
+		"Max container restarts per second caused by enhanced liveness probe failures in each workload.")
+	flag.IntVar(&workloadRestartBurst, "enhancedlivenessprobe-workload-restart-burst", workloadRestartBurst,
+		"Max burst of container restarts caused by enhanced liveness probe failures in each workload.")
+}
+
+var (
+	concurrentReconciles = 3
+	nodePodProbeKind     = appsv1alpha1.SchemeGroupVersion.WithKind("NodePodProbe")
+
+	globalRestartQPS     = 1.0
+	globalRestartBurst   = 20
+	workloadRestartQPS   = 0.1
+	workloadRestartBurst = 3
+)
+
+const (
+	// nodePodProbeNotFoundDelay is the delay to retry when the NodePodProbe of node has not been created yet.
+	nodePodProbeNotFoundDelay = 10 * time.Second
+	// crrTTLSecondsAfterFinished is the TTL of ContainerRecreateRequests created by this controller.
+	crrTTLSecondsAfterFinished = int32(600)
+)
+
+// Add creates a new EnhancedLivenessProbe Controller and adds it to the Manager with default RBAC.
+// It syncs the native liveness probes backed up by webhook into NodePodProbe, and turns the failed results
+// into ContainerRecreateRequests under the per-workload and global restart rate limits.
+func Add(mgr manager.Manager) error {
+	if !utildiscovery.DiscoverGVK(nodePodProbeKind) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.EnhancedLivenessProbeGate) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerGate) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.KruiseDaemon) {
+		return nil
+	}
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) *ReconcileEnhancedLivenessProbe {
+	return &ReconcileEnhancedLivenessProbe{
+		Client:   utilclient.NewClientFromManager(mgr, "enhancedlivenessprobe-controller"),
+		recorder: mgr.GetEventRecorderFor("enhancedlivenessprobe-controller"),
+		limiter:  newRestartLimiter(globalRestartQPS, globalRestartBurst, workloadRestartQPS, workloadRestartBurst),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("enhancedlivenessprobe-controller", mgr, controller.Options{
+		Reconciler: r, MaxConcurrentReconciles: concurrentReconciles, CacheSyncTimeout: util.GetControllerCacheSyncTimeout(),
+		RateLimiter: ratelimiter.DefaultControllerRateLimiter[reconcile.Request]()})
+	if err != nil {
+		return err
+	}
+
+	// watch for changes to pod
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, &enqueueRequestForPod{})); err != nil {
+		return err
+	}
+
+	// watch for changes to NodePodProbe
+	if err = c.Watch(source.Kind(mgr.GetCache(), &appsv1alpha1.NodePodProbe{}, &enqueueRequestForNodePodProbe{})); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileEnhancedLivenessProbe{}
+
+// ReconcileEnhancedLivenessProbe reconciles the Pods using enhanced liveness probe
+type ReconcileEnhancedLivenessProbe struct {
+	client.Client
+	recorder record.EventRecorder
+	limiter  *restartLimiter
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=nodepodprobes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests,verbs=get;list;watch;create
+
+// Reconcile syncs the enhanced liveness probes of the Pod into NodePodProbe, and restarts the containers
+// whose liveness probes have failed.
+func (r *ReconcileEnhancedLivenessProbe) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pod := &corev1.Pod{}
+	if err := r.Get(context.TODO(), req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !kubecontroller.IsPodActive(pod) || pod.Spec.NodeName == "" || !enhancedlivenessprobe.UsingEnhancedLivenessProbe(pod) {
+		return ctrl.Result{}, nil
+	}
+	probes, err := enhancedlivenessprobe.GetContainerLivenessProbes(pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get container liveness probes", "pod", klog.KObj(pod))
+		return ctrl.Result{}, nil
+	} else if len(probes) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	npp := &appsv1alpha1.NodePodProbe{}
+	if err = r.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, npp); err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(4).InfoS("NodePodProbe not found, waiting for it created", "pod", klog.KObj(pod), "nodeName", pod.Spec.NodeName)
+			return ctrl.Result{RequeueAfter: nodePodProbeNotFoundDelay}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err = r.syncNodePodProbe(pod, probes, npp); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	delay, err := r.restartFailedContainers(pod, npp)
+	return ctrl.Result{RequeueAfter: delay}, err
+}
+
+// syncNodePodProbe makes the enhanced liveness probes of pod in NodePodProbe consistent with the backed up native probes.
+func (r *ReconcileEnhancedLivenessProbe) syncNodePodProbe(pod *corev1.Pod, probes []enhancedlivenessprobe.ContainerLivenessProbe, npp *appsv1alpha1.NodePodProbe) error {
+	var desired []appsv1alpha1.ContainerProbe
+	for i := range probes {
+		probe, err := resolveProbePort(pod, probes[i].Name, probes[i].LivenessProbe)
+		if err != nil {
+			klog.ErrorS(err, "Failed to resolve liveness probe port", "pod", klog.KObj(pod), "container", probes[i].Name)
+			continue
+		}
+		desired = append(desired, appsv1alpha1.ContainerProbe{
+			Name:          enhancedlivenessprobe.GetProbeName(probes[i].Name),
+			ContainerName: probes[i].Name,
+			Probe:         appsv1alpha1.ContainerProbeSpec{Probe: probe},
+		})
+	}
+
+	nppClone := npp.DeepCopy()
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if !setPodProbes(nppClone, pod, desired) {
+			return nil
+		}
+		updateErr := r.Update(context.TODO(), nppClone)
+		if updateErr == nil {
+			return nil
+		}
+		if err := r.Get(context.TODO(), client.ObjectKey{Name: npp.Name}, nppClone); err != nil {
+			klog.ErrorS(err, "Failed to get updated NodePodProbe from client", "nodePodProbe", klog.KObj(npp))
+		}
+		return updateErr
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to update enhanced liveness probes in NodePodProbe", "pod", klog.KObj(pod), "nodePodProbe", klog.KObj(npp))
+		return err
+	}
+	return nil
+}
+
+// setPodProbes replaces the enhanced liveness probes of pod in NodePodProbe, and returns true if it is changed.
+func setPodProbes(npp *appsv1alpha1.NodePodProbe, pod *corev1.Pod, desired []appsv1alpha1.ContainerProbe) bool {
+	oldSpec := npp.Spec.DeepCopy()
+	var podProbe *appsv1alpha1.PodProbe
+	for i := range npp.Spec.PodProbes {
+		if npp.Spec.PodProbes[i].UID == string(pod.UID) {
+			podProbe = &npp.Spec.PodProbes[i]
+			break
+		}
+	}
+	if podProbe == nil {
+		npp.Spec.PodProbes = append(npp.Spec.PodProbes, appsv1alpha1.PodProbe{Name: pod.Name, Namespace: pod.Namespace, UID: string(pod.UID)})
+		podProbe = &npp.Spec.PodProbes[len(npp.Spec.PodProbes)-1]
+	}
+	if podProbe.IP == "" {
+		podProbe.IP = pod.Status.PodIP
+	}
+	var newProbes []appsv1alpha1.ContainerProbe
+	for _, probe := range podProbe.Probes {
+		if _, ok := enhancedlivenessprobe.ParseProbeName(probe.Name); !ok {
+			newProbes = append(newProbes, probe)
+		}
+	}
+	podProbe.Probes = append(newProbes, desired...)
+	return !reflect.DeepEqual(oldSpec, &npp.Spec)
+}
+
+// resolveProbePort converts the named port of tcpSocket and httpGet probe to the number of container port,
+// for kruise-daemon only probes the numeric port.
+func resolveProbePort(pod *corev1.Pod, containerName string, probe corev1.Probe) (corev1.Probe, error) {
+	var port *intstr.IntOrString
+	switch {
+	case probe.TCPSocket != nil:
+		probe.TCPSocket = probe.TCPSocket.DeepCopy()
+		port = &probe.TCPSocket.Port
+	case probe.HTTPGet != nil:
+		probe.HTTPGet = probe.HTTPGet.DeepCopy()
+		port = &probe.HTTPGet.Port
+	default:
+		return probe, nil
+	}
+	if port.Type == intstr.Int {
+		return probe, nil
+	}
+	container := util.GetContainer(containerName, pod)
+	if container == nil {
+		return probe, fmt.Errorf("container %s not found", containerName)
+	}
+	for _, p := range container.Ports {
+		if p.Name == port.StrVal {
+			*port = intstr.FromInt32(p.ContainerPort)
+			return probe, nil
+		}
+	}
+	return probe, fmt.Errorf("port %s not found in container %s", port.StrVal, containerName)
+}
+
+// getFailedContainers returns the running containers whose enhanced liveness probes have failed since they started.
+func getFailedContainers(pod *corev1.Pod, npp *appsv1alpha1.NodePodProbe) []string {
+	var probeStatus *appsv1alpha1.PodProbeStatus
+	for i := range npp.Status.PodProbeStatuses {
+		if npp.Status.PodProbeStatuses[i].UID == string(pod.UID) {
+			probeStatus = &npp.Status.PodProbeStatuses[i]
+			break
+		}
+	}
+	if probeStatus == nil {
+		return nil
+	}
+
+	var failed []string
+	for _, state := range probeStatus.ProbeStates {
+		containerName, ok := enhancedlivenessprobe.ParseProbeName(state.Name)
+		if !ok || state.State != appsv1alpha1.ProbeFailed {
+			continue
+		}
+		containerStatus := util.GetContainerStatus(containerName, pod)
+		if containerStatus == nil || containerStatus.State.Running == nil {
+			continue
+		}
+		// ignore the failure of previous container, which has not been probed since the container restarted
+		if state.LastTransitionTime.Before(&containerStatus.State.Running.StartedAt) {
+			continue
+		}
+		failed = append(failed, containerName)
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// restartFailedContainers creates ContainerRecreateRequest for the failed containers of pod if the restart limiters allow.
+// It returns the delay to check again when the restarts are throttled.
+func (r *ReconcileEnhancedLivenessProbe) restartFailedContainers(pod *corev1.Pod, npp *appsv1alpha1.NodePodProbe) (time.Duration, error) {
+	failed := getFailedContainers(pod, npp)
+	if len(failed) == 0 {
+		return 0, nil
+	}
+
+	crrName := getCRRName(pod, failed)
+	crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+	if err := r.List(context.TODO(), crrList, client.InNamespace(pod.Namespace), client.MatchingLabels{
+		appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID),
+	}); err != nil {
+		return 0, err
+	}
+	for i := range crrList.Items {
+		crr := &crrList.Items[i]
+		if crr.Name == crrName || crr.Labels[appsv1alpha1.ContainerRecreateRequestActiveKey] == "true" {
+			klog.V(4).InfoS("ContainerRecreateRequest exists for Pod, skip restarting", "pod", klog.KObj(pod), "containerRecreateRequest", crr.Name)
+			return 0, nil
+		}
+	}
+
+	if delay := r.limiter.reserve(getWorkloadKey(pod), len(failed), time.Now()); delay > 0 {
+		klog.InfoS("Restarts of liveness probe failed containers are throttled", "pod", klog.KObj(pod), "containers", failed, "delay", delay)
+		r.recorder.Eventf(pod, corev1.EventTypeWarning, "RestartThrottled",
+			"Restarting containers %s with failed liveness probe is throttled for %v", strings.Join(failed, ","), delay)
+		return delay, nil
+	}
+
+	var containers []appsv1alpha1.ContainerRecreateRequestContainer
+	for _, name := range failed {
+		containers = append(containers, appsv1alpha1.ContainerRecreateRequestContainer{Name: name})
+	}
+	crr := &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      crrName,
+			Labels:    map[string]string{appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID)},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(pod, corev1.SchemeGroupVersion.WithKind("Pod")),
+			},
+		},
+		Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+			PodName:    pod.Name,
+			Containers: containers,
+			Strategy: &appsv1alpha1.ContainerRecreateRequestStrategy{
+				FailurePolicy: appsv1alpha1.ContainerRecreateRequestFailurePolicyIgnore,
+			},
+			TTLSecondsAfterFinished: ptr.To(crrTTLSecondsAfterFinished),
+		},
+	}
+	if err := r.Create(context.TODO(), crr); err != nil && !errors.IsAlreadyExists(err) {
+		klog.ErrorS(err, "Failed to create ContainerRecreateRequest for liveness probe failed containers", "pod", klog.KObj(pod), "containers", failed)
+		return 0, err
+	}
+	klog.InfoS("Created ContainerRecreateRequest for liveness probe failed containers", "pod", klog.KObj(pod), "containers", failed, "containerRecreateRequest", crrName)
+	r.recorder.Eventf(pod, corev1.EventTypeNormal, "RestartingContainers",
+		"Restarting containers %s with failed liveness probe by ContainerRecreateRequest %s", strings.Join(failed, ","), crrName)
+	return 0, nil
+}
+
+// getCRRName returns a stable name for the restart of the failed containers in their current running instances.
+func getCRRName(pod *corev1.Pod, containers []string) string {
+	hasher := fnv.New32a()
+	for _, name := range containers {
+		_, _ = hasher.Write([]byte(name))
+		if status := util.GetContainerStatus(name, pod); status != nil {
+			_, _ = hasher.Write([]byte(status.ContainerID))
+		}
+	}
+	return fmt.Sprintf("liveness-%s-%s", pod.UID, rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+)
+
+var scheme *runtime.Scheme
+
+func init() {
+	scheme = runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+}
+
+func newTestPod(name string, ownerUID types.UID, startedAt time.Time) *corev1.Pod {
+	probes := []enhancedlivenessprobe.ContainerLivenessProbe{{
+		Name: "main",
+		LivenessProbe: corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")},
+		}},
+	}}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-uid"),
+			Annotations: map[string]string{
+				appsv1alpha1.AnnotationUsingEnhancedLiveness:       "true",
+				appsv1alpha1.AnnotationNativeContainerProbeContext: util.DumpJSON(probes),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", Name: "cs", UID: ownerUID, Controller: ptr.To(true),
+			}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name:  "main",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "1.1.1.1",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:        "main",
+				ContainerID: "containerd://" + name,
+				State:       corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(startedAt)}},
+			}},
+		},
+	}
+}
+
+func newFailedStatus(pod *corev1.Pod, transitionTime time.Time) appsv1alpha1.PodProbeStatus {
+	return appsv1alpha1.PodProbeStatus{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		UID:       string(pod.UID),
+		ProbeStates: []appsv1alpha1.ContainerProbeState{{
+			Name:               enhancedlivenessprobe.GetProbeName("main"),
+			State:              appsv1alpha1.ProbeFailed,
+			LastTransitionTime: metav1.NewTime(transitionTime),
+		}},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	pod0 := newTestPod("pod-0", "cs-uid", now.Add(-time.Hour))
+	pod1 := newTestPod("pod-1", "cs-uid", now.Add(-time.Hour))
+	// the failure happened before the container restarted
+	pod2 := newTestPod("pod-2", "cs-uid", now)
+	npp := &appsv1alpha1.NodePodProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec: appsv1alpha1.NodePodProbeSpec{PodProbes: []appsv1alpha1.PodProbe{{
+			Namespace: "default", Name: "pod-0", UID: "pod-0-uid",
+			Probes: []appsv1alpha1.ContainerProbe{{Name: "ppm#healthy", ContainerName: "main"}},
+		}}},
+		Status: appsv1alpha1.NodePodProbeStatus{PodProbeStatuses: []appsv1alpha1.PodProbeStatus{
+			newFailedStatus(pod0, now.Add(-time.Minute)),
+			newFailedStatus(pod1, now.Add(-time.Minute)),
+			newFailedStatus(pod2, now.Add(-time.Minute)),
+		}},
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod0, pod1, pod2, npp).
+		WithStatusSubresource(&appsv1alpha1.NodePodProbe{}).Build()
+	r := &ReconcileEnhancedLivenessProbe{
+		Client:   cli,
+		recorder: record.NewFakeRecorder(10),
+		// allow only one restart of the workload
+		limiter: newRestartLimiter(100, 100, 0.001, 1),
+	}
+
+	reconcilePod := func(name string) ctrl.Result {
+		res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}})
+		if err != nil {
+			t.Fatalf("failed to reconcile %s: %v", name, err)
+		}
+		return res
+	}
+	if res := reconcilePod("pod-0"); res.RequeueAfter != 0 {
+		t.Fatalf("expected pod-0 not throttled, got %v", res)
+	}
+	if res := reconcilePod("pod-1"); res.RequeueAfter == 0 {
+		t.Fatalf("expected pod-1 throttled")
+	}
+	if res := reconcilePod("pod-2"); res.RequeueAfter != 0 {
+		t.Fatalf("expected pod-2 not restarted, got %v", res)
+	}
+	// reconcile again should not create another one
+	reconcilePod("pod-0")
+
+	crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+	if err := cli.List(context.TODO(), crrList, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+	if len(crrList.Items) != 1 || crrList.Items[0].Spec.PodName != "pod-0" || crrList.Items[0].Spec.Containers[0].Name != "main" {
+		t.Fatalf("expected one crr for pod-0, got %v", util.DumpJSON(crrList.Items))
+	}
+
+	gotNPP := &appsv1alpha1.NodePodProbe{}
+	if err := cli.Get(context.TODO(), client.ObjectKey{Name: "node-1"}, gotNPP); err != nil {
+		t.Fatal(err)
+	}
+	if len(gotNPP.Spec.PodProbes) != 3 {
+		t.Fatalf("expected probes of 3 pods, got %v", util.DumpJSON(gotNPP.Spec))
+	}
+	probes := gotNPP.Spec.PodProbes[0].Probes
+	if len(probes) != 2 || probes[0].Name != "ppm#healthy" || probes[1].Name != enhancedlivenessprobe.GetProbeName("main") ||
+		probes[1].Probe.TCPSocket.Port.IntValue() != 8080 || gotNPP.Spec.PodProbes[0].IP != "1.1.1.1" {
+		t.Fatalf("unexpected probes of pod-0: %v", util.DumpJSON(gotNPP.Spec.PodProbes[0]))
+	}
+}
+
+func TestRestartLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newRestartLimiter(1, 3, 1, 2)
+
+	if delay := limiter.reserve("ns/a", 2, now); delay != 0 {
+		t.Fatalf("expected allowed, got delay %v", delay)
+	}
+	// workload a has used up its burst
+	if delay := limiter.reserve("ns/a", 1, now); delay <= 0 {
+		t.Fatalf("expected workload throttled")
+	}
+	// the throttled reservation should not consume the global limiter
+	if delay := limiter.reserve("ns/b", 1, now); delay != 0 {
+		t.Fatalf("expected allowed, got delay %v", delay)
+	}
+	// global limiter has used up its burst
+	if delay := limiter.reserve("ns/c", 1, now); delay <= 0 {
+		t.Fatalf("expected globally throttled")
+	}
+	if delay := limiter.reserve("ns/c", 1, now.Add(time.Second)); delay != 0 {
+		t.Fatalf("expected allowed after refilled, got delay %v", delay)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+)
+
+var _ handler.TypedEventHandler[*corev1.Pod, reconcile.Request] = &enqueueRequestForPod{}
+
+type enqueueRequestForPod struct{}
+
+func (p *enqueueRequestForPod) Create(ctx context.Context, evt event.TypedCreateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if enhancedlivenessprobe.UsingEnhancedLivenessProbe(evt.Object) && evt.Object.Spec.NodeName != "" {
+		p.queue(q, evt.Object)
+	}
+}
+
+func (p *enqueueRequestForPod) Delete(ctx context.Context, evt event.TypedDeleteEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPod) Generic(ctx context.Context, evt event.TypedGenericEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPod) Update(ctx context.Context, evt event.TypedUpdateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	newPod, oldPod := evt.ObjectNew, evt.ObjectOld
+	if !enhancedlivenessprobe.UsingEnhancedLivenessProbe(newPod) || newPod.Spec.NodeName == "" {
+		return
+	}
+	if oldPod.Spec.NodeName != newPod.Spec.NodeName || oldPod.Status.PodIP != newPod.Status.PodIP ||
+		!enhancedlivenessprobe.UsingEnhancedLivenessProbe(oldPod) ||
+		!reflect.DeepEqual(oldPod.Status.ContainerStatuses, newPod.Status.ContainerStatuses) {
+		p.queue(q, newPod)
+	}
+}
+
+func (p *enqueueRequestForPod) queue(q workqueue.TypedRateLimitingInterface[reconcile.Request], pod *corev1.Pod) {
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+}
+
+var _ handler.TypedEventHandler[*appsv1alpha1.NodePodProbe, reconcile.Request] = &enqueueRequestForNodePodProbe{}
+
+type enqueueRequestForNodePodProbe struct{}
+
+func (p *enqueueRequestForNodePodProbe) Create(ctx context.Context, evt event.TypedCreateEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForNodePodProbe) Delete(ctx context.Context, evt event.TypedDeleteEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForNodePodProbe) Generic(ctx context.Context, evt event.TypedGenericEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForNodePodProbe) Update(ctx context.Context, evt event.TypedUpdateEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldStates := map[string][]appsv1alpha1.ContainerProbeState{}
+	for _, status := range evt.ObjectOld.Status.PodProbeStatuses {
+		oldStates[status.UID] = getLivenessProbeStates(status)
+	}
+	for _, status := range evt.ObjectNew.Status.PodProbeStatuses {
+		newStates := getLivenessProbeStates(status)
+		if len(newStates) > 0 && !reflect.DeepEqual(oldStates[status.UID], newStates) {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: status.Namespace, Name: status.Name}})
+		}
+	}
+}
+
+func getLivenessProbeStates(status appsv1alpha1.PodProbeStatus) []appsv1alpha1.ContainerProbeState {
+	var states []appsv1alpha1.ContainerProbeState
+	for _, state := range status.ProbeStates {
+		if _, ok := enhancedlivenessprobe.ParseProbeName(state.Name); ok {
+			// ignore the lastProbeTime which changes periodically
+			state.LastProbeTime = metav1.Time{}
+			states = append(states, state)
+		}
+	}
+	return states
+}
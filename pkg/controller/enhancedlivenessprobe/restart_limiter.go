@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// workloadLimiterTTL is the duration to keep the limiter of a workload that has no restarts.
+	workloadLimiterTTL = time.Hour
+	// maxThrottledDelay is the delay to check again when the restarts can never be allowed by limiters.
+	maxThrottledDelay = time.Minute
+)
+
+// restartLimiter limits the container restarts caused by liveness probe failures, both for each workload and globally,
+// so that an outage of a shared dependency does not restart all containers at once.
+type restartLimiter struct {
+	mu sync.Mutex
+
+	global        *rate.Limiter
+	workloadLimit rate.Limit
+	workloadBurst int
+	workloads     *utilcache.LRUExpireCache
+}
+
+func newRestartLimiter(globalQPS float64, globalBurst int, workloadQPS float64, workloadBurst int) *restartLimiter {
+	return &restartLimiter{
+		global:        rate.NewLimiter(rate.Limit(globalQPS), globalBurst),
+		workloadLimit: rate.Limit(workloadQPS),
+		workloadBurst: workloadBurst,
+		workloads:     utilcache.NewLRUExpireCache(10000),
+	}
+}
+
+// reserve tries to take n restarts for the workload from both limiters.
+// It returns 0 if the restarts are allowed, otherwise the delay to try again and nothing is taken.
+func (l *restartLimiter) reserve(workloadKey string, n int, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var workload *rate.Limiter
+	if obj, ok := l.workloads.Get(workloadKey); ok {
+		workload = obj.(*rate.Limiter)
+	} else {
+		workload = rate.NewLimiter(l.workloadLimit, l.workloadBurst)
+	}
+	l.workloads.Add(workloadKey, workload, workloadLimiterTTL)
+
+	globalReservation := l.global.ReserveN(now, min(n, l.global.Burst()))
+	workloadReservation := workload.ReserveN(now, min(n, workload.Burst()))
+	if !globalReservation.OK() || !workloadReservation.OK() {
+		globalReservation.CancelAt(now)
+		workloadReservation.CancelAt(now)
+		return maxThrottledDelay
+	}
+
+	delay := globalReservation.DelayFrom(now)
+	if d := workloadReservation.DelayFrom(now); d > delay {
+		delay = d
+	}
+	if delay > 0 {
+		globalReservation.CancelAt(now)
+		workloadReservation.CancelAt(now)
+	}
+	return delay
+}
+
+// getWorkloadKey returns the key of the workload that owns the pod for restart limiting.
+func getWorkloadKey(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return fmt.Sprintf("%s/%s", pod.Namespace, owner.UID)
+	}
+	return fmt.Sprintf("%s/%s", pod.Namespace, pod.UID)
+}
@@ -43,6 +43,7 @@ import (
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
 	"github.com/openkruise/kruise/pkg/util/controllerfinder"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 )
@@ -243,6 +244,10 @@ func (r *ReconcileNodePodProbe) updatePodProbeStatus(pod *corev1.Pod, status app
 		if probeState.State == "" {
 			continue
 		}
+		// enhanced liveness probes are handled by the enhancedlivenessprobe controller
+		if _, ok := enhancedlivenessprobe.ParseProbeName(probeState.Name); ok {
+			continue
+		}
 		// fetch podProbeMarker
 		ppmName, probeName := strings.Split(probeState.Name, "#")[0], strings.Split(probeState.Name, "#")[1]
 		ppm := &appsv1alpha1.PodProbeMarker{}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"sort"
 	"time"
 
 	apps "k8s.io/api/apps/v1"
@@ -265,12 +266,25 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1al
 	}
 
 	klog.V(3).InfoS("PodUnavailableBudget controller pods expectedCount", "podUnavailableBudget", klog.KObj(pub), "podCount", len(pods), "expectedCount", expectedCount)
-	desiredAvailable, err := r.getDesiredAvailableForPub(pub, expectedCount)
+	currentWindow, nextWindow, err := pubcontrol.GetMaintenanceWindows(pub, currentTime)
+	if err != nil {
+		r.recorder.Eventf(pub, corev1.EventTypeWarning, "InvalidMaintenanceWindows", "Failed to parse maintenance windows: %v", err)
+		return nil, err
+	}
+	desiredAvailable, err := r.getDesiredAvailableForPub(pub, expectedCount, currentWindow)
 	if err != nil {
 		r.recorder.Eventf(pub, corev1.EventTypeWarning, "CalculateExpectedPodCountFailed", "Failed to calculate the number of expected pods: %v", err)
 		return nil, err
 	}
 
+	// the topology domains of scheduled pods, only when topologyKey is set
+	var podDomains map[string]string
+	if pub.Spec.TopologyKey != "" {
+		if podDomains, err = getPodTopologyDomains(pub, pods); err != nil {
+			return nil, err
+		}
+	}
+
 	// for debug
 	var conflictTimes int
 	var costOfGet, costOfUpdate time.Duration
@@ -319,9 +333,16 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1al
 		var disruptedPods, unavailablePods map[string]metav1.Time
 		disruptedPods, unavailablePods, recheckTime = r.buildDisruptedAndUnavailablePods(pods, pubClone, currentTime)
 		currentAvailable := countAvailablePods(pods, disruptedPods, unavailablePods)
+		var topologyDomains []policyv1alpha1.PubTopologyDomainStatus
+		if podDomains != nil {
+			if topologyDomains, err = r.buildTopologyDomains(pubClone, pods, podDomains, expectedCount, disruptedPods, unavailablePods, currentWindow); err != nil {
+				return err
+			}
+		}
 
 		start = time.Now()
-		updateErr := r.updatePubStatus(pubClone, currentAvailable, desiredAvailable, expectedCount, disruptedPods, unavailablePods)
+		updateErr := r.updatePubStatus(pubClone, currentAvailable, desiredAvailable, expectedCount, disruptedPods, unavailablePods,
+			topologyDomains, currentWindow, nextWindow)
 		costOfUpdate += time.Since(start)
 		if updateErr == nil {
 			return nil
@@ -336,6 +357,12 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1al
 	if err != nil {
 		klog.ErrorS(err, "Failed to update PodUnavailableBudget status", "podUnavailableBudget", klog.KObj(pub))
 	}
+	// recalculate the budget when the current maintenance window ends or the next one starts
+	for _, windowTime := range getMaintenanceWindowBoundaries(currentWindow, nextWindow) {
+		if recheckTime == nil || windowTime.Before(*recheckTime) {
+			recheckTime = windowTime
+		}
+	}
 	return recheckTime, err
 }
 
@@ -386,7 +413,113 @@ func countAvailablePods(pods []*corev1.Pod, disruptedPods, unavailablePods map[s
 	return
 }
 
-func (r *ReconcilePodUnavailableBudget) getDesiredAvailableForPub(pub *policyv1alpha1.PodUnavailableBudget, expectedCount int32) (desiredAvailable int32, err error) {
+func getPodTopologyDomains(pub *policyv1alpha1.PodUnavailableBudget, pods []*corev1.Pod) (map[string]string, error) {
+	podDomains := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		if !kubecontroller.IsPodActive(pod) {
+			continue
+		}
+		domain, scheduled, err := pubcontrol.PubControl.GetPodTopologyDomain(pub, pod)
+		if err != nil {
+			return nil, err
+		} else if scheduled {
+			podDomains[pod.Name] = domain
+		}
+	}
+	return podDomains, nil
+}
+
+// buildTopologyDomains calculates the budget in each topology domain, in which the desired available
+// is scaled by the expected replicas of the domain.
+func (r *ReconcilePodUnavailableBudget) buildTopologyDomains(pub *policyv1alpha1.PodUnavailableBudget, pods []*corev1.Pod, podDomains map[string]string,
+	expectedCount int32, disruptedPods, unavailablePods map[string]metav1.Time, currentWindow *policyv1alpha1.MaintenanceWindowStatus) ([]policyv1alpha1.PubTopologyDomainStatus, error) {
+	domainPods := make(map[string][]*corev1.Pod)
+	for _, pod := range pods {
+		if domain, ok := podDomains[pod.Name]; ok {
+			domainPods[domain] = append(domainPods[domain], pod)
+		}
+	}
+	domainReplicas := getTopologyDomainReplicas(pub.Status.TopologyDomains, domainPods, expectedCount)
+
+	topologyDomains := make([]policyv1alpha1.PubTopologyDomainStatus, 0, len(domainReplicas))
+	for domain, totalReplicas := range domainReplicas {
+		matchedPods := domainPods[domain]
+		desiredAvailable, err := r.getDesiredAvailableForPub(pub, totalReplicas, currentWindow)
+		if err != nil {
+			return nil, err
+		}
+		currentAvailable := countAvailablePods(matchedPods, disruptedPods, unavailablePods)
+		unavailableAllowed := currentAvailable - desiredAvailable
+		if unavailableAllowed < 0 {
+			unavailableAllowed = 0
+		}
+		topologyDomains = append(topologyDomains, policyv1alpha1.PubTopologyDomainStatus{
+			Domain:             domain,
+			UnavailableAllowed: unavailableAllowed,
+			CurrentAvailable:   currentAvailable,
+			DesiredAvailable:   desiredAvailable,
+			TotalReplicas:      totalReplicas,
+		})
+	}
+	sort.Slice(topologyDomains, func(i, j int) bool {
+		return topologyDomains[i].Domain < topologyDomains[j].Domain
+	})
+	return topologyDomains, nil
+}
+
+// getTopologyDomainReplicas returns the expected replicas of each topology domain, which is the number of pods
+// scheduled in the domain, plus the pods deleted from the domain and not replaced yet. The replacements pending are
+// the expected count missing from the scheduled pods, and credited to the domains having fewer pods than in the last status,
+// so that the budget of a domain does not shrink with its pods disrupted.
+func getTopologyDomainReplicas(lastDomains []policyv1alpha1.PubTopologyDomainStatus, domainPods map[string][]*corev1.Pod,
+	expectedCount int32) map[string]int32 {
+	domainReplicas := make(map[string]int32, len(domainPods))
+	pending := expectedCount
+	for domain, matchedPods := range domainPods {
+		domainReplicas[domain] = int32(len(matchedPods))
+		pending -= int32(len(matchedPods))
+	}
+	// the last domains are sorted by name
+	for _, last := range lastDomains {
+		if pending <= 0 {
+			break
+		}
+		if missing := last.TotalReplicas - domainReplicas[last.Domain]; missing > 0 {
+			credit := min(missing, pending)
+			domainReplicas[last.Domain] += credit
+			pending -= credit
+		}
+	}
+	return domainReplicas
+}
+
+func getMaintenanceWindowBoundaries(currentWindow, nextWindow *policyv1alpha1.MaintenanceWindowStatus) []*time.Time {
+	var boundaries []*time.Time
+	if currentWindow != nil {
+		boundaries = append(boundaries, &currentWindow.EndTime.Time)
+	}
+	if nextWindow != nil {
+		boundaries = append(boundaries, &nextWindow.StartTime.Time)
+	}
+	return boundaries
+}
+
+func (r *ReconcilePodUnavailableBudget) getDesiredAvailableForPub(pub *policyv1alpha1.PodUnavailableBudget, expectedCount int32,
+	currentWindow *policyv1alpha1.MaintenanceWindowStatus) (desiredAvailable int32, err error) {
+	// out of maintenance windows, the stricter budget takes effect
+	if len(pub.Spec.MaintenanceWindows) > 0 && currentWindow == nil {
+		var maxUnavailable int
+		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(pubcontrol.GetOutOfWindowMaxUnavailable(pub), int(expectedCount), true)
+		if err != nil {
+			return
+		}
+		desiredAvailable = expectedCount - int32(maxUnavailable)
+		if desiredAvailable < 0 {
+			desiredAvailable = 0
+		}
+		return
+	}
+
 	if pub.Spec.MaxUnavailable != nil {
 		var maxUnavailable int
 		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(pub.Spec.MaxUnavailable, int(expectedCount), true)
@@ -467,12 +600,21 @@ func (r *ReconcilePodUnavailableBudget) buildDisruptedAndUnavailablePods(pods []
 }
 
 func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1alpha1.PodUnavailableBudget, currentAvailable, desiredAvailable, expectedCount int32,
-	disruptedPods, unavailablePods map[string]metav1.Time) error {
+	disruptedPods, unavailablePods map[string]metav1.Time, topologyDomains []policyv1alpha1.PubTopologyDomainStatus,
+	currentWindow, nextWindow *policyv1alpha1.MaintenanceWindowStatus) error {
 
 	unavailableAllowed := currentAvailable - desiredAvailable
 	if unavailableAllowed <= 0 {
 		unavailableAllowed = 0
 	}
+	// with topologyKey, the budget is also limited by the sum of all topology domains
+	if topologyDomains != nil {
+		var domainsUnavailableAllowed int32
+		for _, domain := range topologyDomains {
+			domainsUnavailableAllowed += domain.UnavailableAllowed
+		}
+		unavailableAllowed = min(unavailableAllowed, domainsUnavailableAllowed)
+	}
 
 	if pub.Status.CurrentAvailable == currentAvailable &&
 		pub.Status.DesiredAvailable == desiredAvailable &&
@@ -480,18 +622,24 @@ func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1alpha1.PodU
 		pub.Status.UnavailableAllowed == unavailableAllowed &&
 		pub.Status.ObservedGeneration == pub.Generation &&
 		apiequality.Semantic.DeepEqual(pub.Status.DisruptedPods, disruptedPods) &&
-		apiequality.Semantic.DeepEqual(pub.Status.UnavailablePods, unavailablePods) {
+		apiequality.Semantic.DeepEqual(pub.Status.UnavailablePods, unavailablePods) &&
+		apiequality.Semantic.DeepEqual(pub.Status.TopologyDomains, topologyDomains) &&
+		apiequality.Semantic.DeepEqual(pub.Status.CurrentMaintenanceWindow, currentWindow) &&
+		apiequality.Semantic.DeepEqual(pub.Status.NextMaintenanceWindow, nextWindow) {
 		return nil
 	}
 
 	pub.Status = policyv1alpha1.PodUnavailableBudgetStatus{
-		CurrentAvailable:   currentAvailable,
-		DesiredAvailable:   desiredAvailable,
-		TotalReplicas:      expectedCount,
-		UnavailableAllowed: unavailableAllowed,
-		DisruptedPods:      disruptedPods,
-		UnavailablePods:    unavailablePods,
-		ObservedGeneration: pub.Generation,
+		CurrentAvailable:         currentAvailable,
+		DesiredAvailable:         desiredAvailable,
+		TotalReplicas:            expectedCount,
+		UnavailableAllowed:       unavailableAllowed,
+		DisruptedPods:            disruptedPods,
+		UnavailablePods:          unavailablePods,
+		ObservedGeneration:       pub.Generation,
+		TopologyDomains:          topologyDomains,
+		CurrentMaintenanceWindow: currentWindow,
+		NextMaintenanceWindow:    nextWindow,
 	}
 	err := r.Client.Status().Update(context.TODO(), pub)
 	if err != nil {
@@ -1309,6 +1309,7 @@ func TestDesiredAvailableForPub(t *testing.T) {
 	cases := []struct {
 		name             string
 		getPub           func() *policyv1alpha1.PodUnavailableBudget
+		currentWindow    *policyv1alpha1.MaintenanceWindowStatus
 		totalReplicas    int32
 		desiredAvailable int32
 	}{
@@ -1325,12 +1326,53 @@ func TestDesiredAvailableForPub(t *testing.T) {
 			totalReplicas:    15,
 			desiredAvailable: 13,
 		},
+		{
+			name: "DesiredAvailableForPub, in maintenance window, maxUnavailable 10%, total 15",
+			getPub: func() *policyv1alpha1.PodUnavailableBudget {
+				demo := pubDemo.DeepCopy()
+				demo.Spec.MaxUnavailable = ptr.To(intstr.FromString("10%"))
+				demo.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				}
+				return demo
+			},
+			currentWindow:    &policyv1alpha1.MaintenanceWindowStatus{},
+			totalReplicas:    15,
+			desiredAvailable: 13,
+		},
+		{
+			name: "DesiredAvailableForPub, out of maintenance window, total 15",
+			getPub: func() *policyv1alpha1.PodUnavailableBudget {
+				demo := pubDemo.DeepCopy()
+				demo.Spec.MaxUnavailable = ptr.To(intstr.FromString("10%"))
+				demo.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				}
+				return demo
+			},
+			totalReplicas:    15,
+			desiredAvailable: 15,
+		},
+		{
+			name: "DesiredAvailableForPub, out of maintenance window, outOfWindowMaxUnavailable 1, total 15",
+			getPub: func() *policyv1alpha1.PodUnavailableBudget {
+				demo := pubDemo.DeepCopy()
+				demo.Spec.MaxUnavailable = ptr.To(intstr.FromString("10%"))
+				demo.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				}
+				demo.Spec.OutOfWindowMaxUnavailable = ptr.To(intstr.FromInt32(1))
+				return demo
+			},
+			totalReplicas:    15,
+			desiredAvailable: 14,
+		},
 	}
 
 	rec := ReconcilePodUnavailableBudget{}
 	for _, cs := range cases {
 		t.Run(cs.name, func(t *testing.T) {
-			expect, _ := rec.getDesiredAvailableForPub(cs.getPub(), cs.totalReplicas)
+			expect, _ := rec.getDesiredAvailableForPub(cs.getPub(), cs.totalReplicas, cs.currentWindow)
 			if expect != cs.desiredAvailable {
 				t.Fatalf("expect %d, but get %d", cs.desiredAvailable, expect)
 			}
@@ -1338,6 +1380,93 @@ func TestDesiredAvailableForPub(t *testing.T) {
 	}
 }
 
+func TestPubReconcileWithTopologyKey(t *testing.T) {
+	pub := pubDemo.DeepCopy()
+	pub.Annotations[policyv1alpha1.PubProtectTotalReplicasAnnotation] = "8"
+	pub.Spec.TopologyKey = corev1.LabelTopologyZone
+	defer util.GlobalCache.Delete(pub)
+
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pub).
+		WithStatusSubresource(&policyv1alpha1.PodUnavailableBudget{})
+	for _, zone := range []string{"zone-a", "zone-b"} {
+		builder.WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-" + zone,
+			Labels: map[string]string{corev1.LabelTopologyZone: zone},
+		}})
+	}
+	for i := 0; i < 8; i++ {
+		pod := podDemo.DeepCopy()
+		pod.Name = fmt.Sprintf("%s-%d", pod.Name, i)
+		switch {
+		case i < 4:
+			pod.Spec.NodeName = "node-zone-a"
+		case i < 7:
+			pod.Spec.NodeName = "node-zone-b"
+		}
+		if i == 6 {
+			podReadyCondition := podutil.GetPodReadyCondition(pod.Status)
+			podReadyCondition.Status = corev1.ConditionFalse
+		}
+		builder.WithObjects(pod)
+	}
+	fakeClient := builder.Build()
+
+	finder := &controllerfinder.ControllerFinder{Client: fakeClient}
+	pubcontrol.InitPubControl(fakeClient, finder, record.NewFakeRecorder(10))
+	reconciler := ReconcilePodUnavailableBudget{
+		Client:           fakeClient,
+		recorder:         record.NewFakeRecorder(10),
+		controllerFinder: finder,
+	}
+	if _, err := reconciler.syncPodUnavailableBudget(pub); err != nil {
+		t.Fatalf("sync PodUnavailableBudget failed: %s", err.Error())
+	}
+	newPub, err := getLatestPub(fakeClient, pub)
+	if err != nil {
+		t.Fatalf("getLatestPub failed: %s", err.Error())
+	}
+
+	// maxUnavailable 30% in each zone, the unscheduled pod is not counted in any zone
+	expectDomains := []policyv1alpha1.PubTopologyDomainStatus{
+		{Domain: "zone-a", UnavailableAllowed: 2, CurrentAvailable: 4, DesiredAvailable: 2, TotalReplicas: 4},
+		{Domain: "zone-b", UnavailableAllowed: 0, CurrentAvailable: 2, DesiredAvailable: 2, TotalReplicas: 3},
+	}
+	if !reflect.DeepEqual(expectDomains, newPub.Status.TopologyDomains) {
+		t.Fatalf("expect topology domains(%s) but get(%s)", util.DumpJSON(expectDomains), util.DumpJSON(newPub.Status.TopologyDomains))
+	}
+	// the global budget is capped by the sum of the zones
+	if newPub.Status.UnavailableAllowed != 2 || newPub.Status.DesiredAvailable != 5 || newPub.Status.CurrentAvailable != 7 {
+		t.Fatalf("expect unavailableAllowed 2, desiredAvailable 5 and currentAvailable 7, but get status(%s)", util.DumpJSON(newPub.Status))
+	}
+
+	// the pods deleted from zone-a are not replaced yet, and zone-a keeps its budget
+	for _, name := range []string{"test-pod-0", "test-pod-1"} {
+		pod := &corev1.Pod{}
+		if err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pub.Namespace, Name: name}, pod); err != nil {
+			t.Fatalf("get pod failed: %s", err.Error())
+		}
+		if err = fakeClient.Delete(context.TODO(), pod); err != nil {
+			t.Fatalf("delete pod failed: %s", err.Error())
+		}
+	}
+	if _, err = reconciler.syncPodUnavailableBudget(newPub); err != nil {
+		t.Fatalf("sync PodUnavailableBudget failed: %s", err.Error())
+	}
+	if newPub, err = getLatestPub(fakeClient, pub); err != nil {
+		t.Fatalf("getLatestPub failed: %s", err.Error())
+	}
+	expectDomains = []policyv1alpha1.PubTopologyDomainStatus{
+		{Domain: "zone-a", UnavailableAllowed: 0, CurrentAvailable: 2, DesiredAvailable: 2, TotalReplicas: 4},
+		{Domain: "zone-b", UnavailableAllowed: 0, CurrentAvailable: 2, DesiredAvailable: 2, TotalReplicas: 3},
+	}
+	if !reflect.DeepEqual(expectDomains, newPub.Status.TopologyDomains) {
+		t.Fatalf("expect topology domains(%s) but get(%s)", util.DumpJSON(expectDomains), util.DumpJSON(newPub.Status.TopologyDomains))
+	}
+	if newPub.Status.UnavailableAllowed != 0 {
+		t.Fatalf("expect unavailableAllowed 0, but get status(%s)", util.DumpJSON(newPub.Status))
+	}
+}
+
 func getLatestPub(client client.Client, pub *policyv1alpha1.PodUnavailableBudget) (*policyv1alpha1.PodUnavailableBudget, error) {
 	newPub := &policyv1alpha1.PodUnavailableBudget{}
 	key := types.NamespacedName{
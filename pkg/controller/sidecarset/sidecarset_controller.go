@@ -37,6 +37,7 @@ import (
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 )
 
@@ -124,13 +125,18 @@ type ReconcileSidecarSet struct {
 
 // Reconcile reads that state of the cluster for a SidecarSet object and makes changes based on the state read
 // and what is in the SidecarSet.Spec
-func (r *ReconcileSidecarSet) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileSidecarSet) Reconcile(_ context.Context, request reconcile.Request) (res reconcile.Result, retErr error) {
+	defer func() {
+		workloadmetrics.RecordReconcileError("sidecarset", retErr)
+	}()
+
 	sidecarSet := &appsv1beta1.SidecarSet{}
 	err := r.Get(context.TODO(), request.NamespacedName, sidecarSet)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			workloadmetrics.DeleteStatus(workloadmetrics.KindSidecarSet, request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ import (
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
 	"github.com/openkruise/kruise/pkg/util/fieldindex"
 	historyutil "github.com/openkruise/kruise/pkg/util/history"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
 
@@ -120,7 +122,25 @@ func (p *Processor) UpdateSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (reconc
 		return reconcile.Result{}, nil
 	}
 
-	// 5. If sidecar container hot upgrade complete, then set the other one(empty sidecar container) image to HotUpgradeEmptyImage
+	// 5. If the rollout of the latest revision failed, stop updating pods until a new revision is published;
+	// otherwise roll back the updated pods which stay unready for too long when failurePolicy is Rollback.
+	if isSidecarSetRolloutFailed(sidecarSet) {
+		klog.V(3).InfoS("SidecarSet rollout of the latest revision failed, and stop updating pods", "sidecarSet", klog.KObj(sidecarSet), "revision", sidecarSet.Status.LatestRevision)
+		return reconcile.Result{}, nil
+	}
+	var requeueAfter time.Duration
+	if isRollbackOnFailureEnabled(sidecarSet) {
+		var failedPods []*corev1.Pod
+		failedPods, requeueAfter = getFailedUpdatedPods(control, pods)
+		if len(failedPods) > 0 {
+			if err := p.rollbackFailedPods(control, failedPods, status.DeepCopy()); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+	}
+
+	// 6. If sidecar container hot upgrade complete, then set the other one(empty sidecar container) image to HotUpgradeEmptyImage
 	if isSidecarSetHasHotUpgradeContainer(sidecarSet) {
 		var podsInHotUpgrading []*corev1.Pod
 		for _, pod := range pods {
@@ -146,21 +166,21 @@ func (p *Processor) UpdateSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (reconc
 			if err := p.flipHotUpgradingContainers(control, podsInHotUpgrading); err != nil {
 				return reconcile.Result{}, err
 			}
-			return reconcile.Result{}, nil
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
 		}
 	}
 
-	// 6. sidecarset already updates all matched pods, then return
+	// 7. sidecarset already updates all matched pods, then return
 	if isSidecarSetUpdateFinish(status) {
 		klog.V(3).InfoS("SidecarSet matched pods were latest, and don't need update", "sidecarSet", klog.KObj(sidecarSet), "matchedPodCount", len(pods))
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
-	// 7. upgrade pod sidecar
+	// 8. upgrade pod sidecar
 	if err := p.updatePods(control, pods); err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (p *Processor) updatePods(control sidecarcontrol.SidecarControl, pods []*corev1.Pod) error {
@@ -260,6 +280,13 @@ func (p *Processor) listMatchedSidecarSets(pod *corev1.Pod) string {
 }
 
 func (p *Processor) updateSidecarSetStatus(sidecarSet *appsv1beta1.SidecarSet, status *appsv1beta1.SidecarSetStatus) error {
+	workloadmetrics.RecordStatus(workloadmetrics.KindSidecarSet, sidecarSet.Namespace, sidecarSet.Name, workloadmetrics.Status{
+		Desired:        status.MatchedPods,
+		Updated:        status.UpdatedPods,
+		Ready:          status.ReadyPods,
+		UpdateRevision: status.LatestRevision,
+		Paused:         sidecarSet.Spec.UpdateStrategy.Paused,
+	})
 	if !inconsistentStatus(sidecarSet, status) {
 		return nil
 	}
@@ -539,7 +566,7 @@ func calculateStatus(control sidecarcontrol.SidecarControl, pods []*corev1.Pod,
 			}
 		}
 	}
-	return &appsv1beta1.SidecarSetStatus{
+	status := &appsv1beta1.SidecarSetStatus{
 		ObservedGeneration: sidecarset.Generation,
 		MatchedPods:        matchedPods,
 		UpdatedPods:        updatedPods,
@@ -547,7 +574,18 @@ func calculateStatus(control sidecarcontrol.SidecarControl, pods []*corev1.Pod,
 		UpdatedReadyPods:   updatedAndReady,
 		LatestRevision:     latestRevision.Name,
 		CollisionCount:     pointer.Int32Ptr(collisionCount),
-	}
+		Conditions:         sidecarset.Status.Conditions,
+	}
+	// the failed rollout is over once a new revision is published
+	if isSidecarSetRolloutFailed(sidecarset) && sidecarset.Status.LatestRevision != latestRevision.Name {
+		setSidecarSetCondition(status, appsv1beta1.SidecarSetCondition{
+			Type:    appsv1beta1.SidecarSetConditionTypeRolloutFailed,
+			Status:  corev1.ConditionFalse,
+			Reason:  "NewRevision",
+			Message: fmt.Sprintf("new revision %s is published", latestRevision.Name),
+		})
+	}
+	return status
 }
 
 func isSidecarSetNotUpdate(s *appsv1beta1.SidecarSet) bool {
@@ -637,7 +675,8 @@ func inconsistentStatus(sidecarSet *appsv1beta1.SidecarSet, status *appsv1beta1.
 		status.ReadyPods != sidecarSet.Status.ReadyPods ||
 		status.UpdatedReadyPods != sidecarSet.Status.UpdatedReadyPods ||
 		status.LatestRevision != sidecarSet.Status.LatestRevision ||
-		!pointer.Int32Equal(sidecarSet.Status.CollisionCount, status.CollisionCount)
+		!pointer.Int32Equal(sidecarSet.Status.CollisionCount, status.CollisionCount) ||
+		!reflect.DeepEqual(sidecarSet.Status.Conditions, status.Conditions)
 }
 
 func isSidecarSetUpdateFinish(status *appsv1beta1.SidecarSetStatus) bool {
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/history"
+
+	"github.com/openkruise/kruise/apis/apps/pub"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+)
+
+const defaultUnreadyThresholdSeconds = 300
+
+// isRollbackOnFailureEnabled returns whether the failed updates of sidecarSet should be rolled back.
+func isRollbackOnFailureEnabled(sidecarSet *appsv1beta1.SidecarSet) bool {
+	policy := sidecarSet.Spec.UpdateStrategy.FailurePolicy
+	return policy != nil && policy.Type == appsv1beta1.RollbackSidecarSetUpdateFailurePolicyType
+}
+
+func getUnreadyThreshold(sidecarSet *appsv1beta1.SidecarSet) time.Duration {
+	policy := sidecarSet.Spec.UpdateStrategy.FailurePolicy
+	if policy == nil || policy.UnreadyThresholdSeconds == nil {
+		return defaultUnreadyThresholdSeconds * time.Second
+	}
+	return time.Duration(*policy.UnreadyThresholdSeconds) * time.Second
+}
+
+// isSidecarSetRolloutFailed returns whether the rollout of the latest revision has failed and been rolled back.
+func isSidecarSetRolloutFailed(sidecarSet *appsv1beta1.SidecarSet) bool {
+	condition := getSidecarSetCondition(sidecarSet.Status, appsv1beta1.SidecarSetConditionTypeRolloutFailed)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// getFailedUpdatedPods returns the pods which have been updated in-place to the latest revision but stay unready
+// for more than the threshold, and the duration after which the next updated but unready pod reaches the threshold.
+func getFailedUpdatedPods(control sidecarcontrol.SidecarControl, pods []*corev1.Pod) (failedPods []*corev1.Pod, requeueAfter time.Duration) {
+	sidecarSet := control.GetSidecarset()
+	threshold := getUnreadyThreshold(sidecarSet)
+	for _, pod := range pods {
+		if !sidecarcontrol.IsPodSidecarUpdated(sidecarSet, pod) || !isPodSidecarInPlaceUpdated(sidecarSet, pod) {
+			continue
+		}
+		if control.IsPodStateConsistent(pod, nil) && control.IsPodReady(pod) {
+			continue
+		}
+		upgradeSpec := sidecarcontrol.GetPodSidecarSetUpgradeSpecInAnnotations(sidecarSet.Name, sidecarcontrol.SidecarSetHashAnnotation, pod)
+		if upgradeSpec.UpdateTimestamp.IsZero() {
+			continue
+		}
+		if unready := time.Since(upgradeSpec.UpdateTimestamp.Time); unready >= threshold {
+			failedPods = append(failedPods, pod)
+		} else if left := threshold - unready; requeueAfter == 0 || left < requeueAfter {
+			requeueAfter = left
+		}
+	}
+	return failedPods, requeueAfter
+}
+
+// isPodSidecarInPlaceUpdated returns whether the sidecar containers of pod have been updated in-place by sidecarSet,
+// which distinguishes the updated pods from the ones injected by webhook on creation.
+func isPodSidecarInPlaceUpdated(sidecarSet *appsv1beta1.SidecarSet, pod *corev1.Pod) bool {
+	stateStr := pod.Annotations[sidecarcontrol.SidecarsetInplaceUpdateStateKey]
+	if stateStr == "" {
+		return false
+	}
+	sidecarUpdateStates := make(map[string]*pub.InPlaceUpdateState)
+	if err := json.Unmarshal([]byte(stateStr), &sidecarUpdateStates); err != nil {
+		klog.ErrorS(err, "Failed to parse pod annotations value", "pod", klog.KObj(pod),
+			"annotation", sidecarcontrol.SidecarsetInplaceUpdateStateKey, "value", stateStr)
+		return false
+	}
+	_, ok := sidecarUpdateStates[sidecarSet.Name]
+	return ok
+}
+
+// rollbackFailedPods updates the sidecar containers of failedPods in-place to the previous revision of sidecarSet,
+// and marks the rollout of the latest revision as failed in status.
+func (p *Processor) rollbackFailedPods(control sidecarcontrol.SidecarControl, failedPods []*corev1.Pod, status *appsv1beta1.SidecarSetStatus) error {
+	sidecarSet := control.GetSidecarset()
+	podNames := make([]string, 0, len(failedPods))
+	for _, pod := range failedPods {
+		podNames = append(podNames, pod.Name)
+	}
+
+	previous, previousRevision, err := p.getPreviousSidecarSet(sidecarSet)
+	if err != nil {
+		return err
+	}
+	threshold := getUnreadyThreshold(sidecarSet)
+	var message string
+	if previous == nil {
+		message = fmt.Sprintf("pods [%s] stayed unready for more than %v after updated to revision %s, and no previous revision to roll back to",
+			strings.Join(podNames, ","), threshold, sidecarSet.Status.LatestRevision)
+	} else {
+		previousControl := sidecarcontrol.New(previous)
+		for _, pod := range failedPods {
+			if err = p.updatePodSidecarAndHash(previousControl, pod); err != nil {
+				klog.ErrorS(err, "Failed to roll back pod sidecar", "sidecarSet", klog.KObj(sidecarSet), "pod", klog.KObj(pod))
+				return err
+			}
+			p.recorder.Eventf(pod, corev1.EventTypeWarning, "SidecarRolledBack",
+				"sidecarSet %s rolled back sidecar containers to revision %s", sidecarSet.Name, previousRevision)
+		}
+		message = fmt.Sprintf("pods [%s] stayed unready for more than %v after updated to revision %s, and have been rolled back to revision %s",
+			strings.Join(podNames, ","), threshold, sidecarSet.Status.LatestRevision, previousRevision)
+	}
+
+	setSidecarSetCondition(status, appsv1beta1.SidecarSetCondition{
+		Type:    appsv1beta1.SidecarSetConditionTypeRolloutFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  "UnreadyTimeout",
+		Message: message,
+	})
+	p.recorder.Eventf(sidecarSet, corev1.EventTypeWarning, "RolloutFailed", message)
+	klog.InfoS("SidecarSet rollout failed", "sidecarSet", klog.KObj(sidecarSet), "message", message)
+	return p.updateSidecarSetStatus(sidecarSet, status)
+}
+
+// getPreviousSidecarSet restores the sidecarSet from the newest ControllerRevision other than the latest one,
+// and returns the name of the ControllerRevision.
+func (p *Processor) getPreviousSidecarSet(sidecarSet *appsv1beta1.SidecarSet) (*appsv1beta1.SidecarSet, string, error) {
+	hc := sidecarcontrol.NewHistoryControl(p.Client)
+	revisions, err := p.historyController.ListControllerRevisions(sidecarcontrol.MockSidecarSetForRevision(sidecarSet), hc.GetRevisionSelector(sidecarSet))
+	if err != nil {
+		klog.ErrorS(err, "Failed to list history controllerRevisions", "sidecarSet", klog.KObj(sidecarSet))
+		return nil, "", err
+	}
+	history.SortControllerRevisions(revisions)
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Name == sidecarSet.Status.LatestRevision {
+			continue
+		}
+		previous, err := hc.GetHistorySidecarSet(sidecarSet, &appsv1beta1.SidecarSetInjectRevision{RevisionName: &revisions[i].Name})
+		if err != nil || previous == nil {
+			return nil, "", err
+		}
+		return previous, revisions[i].Name, nil
+	}
+	return nil, "", nil
+}
+
+func getSidecarSetCondition(status appsv1beta1.SidecarSetStatus, condType appsv1beta1.SidecarSetConditionType) *appsv1beta1.SidecarSetCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func setSidecarSetCondition(status *appsv1beta1.SidecarSetStatus, condition appsv1beta1.SidecarSetCondition) {
+	current := getSidecarSetCondition(*status, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return
+	}
+	if current != nil && current.Status == condition.Status {
+		condition.LastTransitionTime = current.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	conditions := []appsv1beta1.SidecarSetCondition{condition}
+	for _, c := range status.Conditions {
+		if c.Type != condition.Type {
+			conditions = append(conditions, c)
+		}
+	}
+	status.Conditions = conditions
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarset
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openkruise/kruise/apis/apps/pub"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+func newUpdatedPod(sidecarSet *appsv1beta1.SidecarSet, updatedAgo time.Duration, ready bool, inPlaceUpdated bool) *corev1.Pod {
+	pod := podDemo.DeepCopy()
+	pod.Spec.Containers[1].Image = sidecarSet.Spec.Containers[0].Image
+	pod.Status.ContainerStatuses[1].Image = sidecarSet.Spec.Containers[0].Image
+	pod.Status.ContainerStatuses[1].ImageID = testImageV2ImageID
+	sidecarSetHash := map[string]sidecarcontrol.SidecarSetUpgradeSpec{
+		sidecarSet.Name: {
+			UpdateTimestamp: metav1.NewTime(time.Now().Add(-updatedAgo)),
+			SidecarSetHash:  sidecarcontrol.GetSidecarSetRevision(sidecarSet),
+			SidecarSetName:  sidecarSet.Name,
+			SidecarList:     []string{"test-sidecar"},
+		},
+	}
+	by, _ := json.Marshal(sidecarSetHash)
+	pod.Annotations[sidecarcontrol.SidecarSetHashAnnotation] = string(by)
+	if inPlaceUpdated {
+		states := map[string]*pub.InPlaceUpdateState{sidecarSet.Name: {Revision: sidecarcontrol.GetSidecarSetRevision(sidecarSet)}}
+		by, _ = json.Marshal(states)
+		pod.Annotations[sidecarcontrol.SidecarsetInplaceUpdateStateKey] = string(by)
+	}
+	if !ready {
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		pod.Status.ContainerStatuses[1].Ready = false
+	}
+	return pod
+}
+
+func TestGetFailedUpdatedPods(t *testing.T) {
+	sidecarSet := sidecarSetDemo.DeepCopy()
+	sidecarSet.Spec.UpdateStrategy.FailurePolicy = &appsv1beta1.SidecarSetUpdateFailurePolicy{
+		Type:                    appsv1beta1.RollbackSidecarSetUpdateFailurePolicyType,
+		UnreadyThresholdSeconds: ptr.To(int32(60)),
+	}
+
+	cases := []struct {
+		name           string
+		pod            *corev1.Pod
+		expectFailed   bool
+		expectRequeued bool
+	}{
+		{
+			name: "updated and ready",
+			pod:  newUpdatedPod(sidecarSet, time.Hour, true, true),
+		},
+		{
+			name:         "updated and unready beyond threshold",
+			pod:          newUpdatedPod(sidecarSet, 2*time.Minute, false, true),
+			expectFailed: true,
+		},
+		{
+			name:           "updated and unready within threshold",
+			pod:            newUpdatedPod(sidecarSet, 10*time.Second, false, true),
+			expectRequeued: true,
+		},
+		{
+			name: "injected on creation and unready",
+			pod:  newUpdatedPod(sidecarSet, time.Hour, false, false),
+		},
+		{
+			name: "not updated and unready",
+			pod: func() *corev1.Pod {
+				pod := podDemo.DeepCopy()
+				pod.Status.Conditions[0].Status = corev1.ConditionFalse
+				return pod
+			}(),
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			failedPods, requeueAfter := getFailedUpdatedPods(sidecarcontrol.New(sidecarSet), []*corev1.Pod{cs.pod})
+			if (len(failedPods) > 0) != cs.expectFailed {
+				t.Fatalf("expect failed %v, but got %v", cs.expectFailed, len(failedPods) > 0)
+			}
+			if (requeueAfter > 0) != cs.expectRequeued {
+				t.Fatalf("expect requeued %v, but got requeueAfter %v", cs.expectRequeued, requeueAfter)
+			}
+		})
+	}
+}
+
+func TestRollbackFailedPods(t *testing.T) {
+	// use a dedicated name to avoid the expectations left by other cases
+	oldSidecarSet := sidecarSetDemo.DeepCopy()
+	oldSidecarSet.Name = "test-sidecarset-rollback"
+	oldSidecarSet.Annotations[sidecarcontrol.SidecarSetHashAnnotation] = "aaa"
+	oldSidecarSet.Spec.Containers[0].Image = "test-image:v1"
+	sidecarSet := sidecarSetDemo.DeepCopy()
+	sidecarSet.Name = "test-sidecarset-rollback"
+	sidecarSet.Spec.UpdateStrategy.FailurePolicy = &appsv1beta1.SidecarSetUpdateFailurePolicy{
+		Type:                    appsv1beta1.RollbackSidecarSetUpdateFailurePolicyType,
+		UnreadyThresholdSeconds: ptr.To(int32(60)),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sidecarSet).
+		WithStatusSubresource(&appsv1beta1.SidecarSet{}).Build()
+	hc := sidecarcontrol.NewHistoryControl(fakeClient)
+	var revisionNames []string
+	for i, s := range []*appsv1beta1.SidecarSet{oldSidecarSet, sidecarSet} {
+		revision, err := hc.NewRevision(s, webhookutil.GetNamespace(), int64(i+1), ptr.To(int32(0)))
+		if err != nil {
+			t.Fatalf("failed to new revision: %v", err)
+		}
+		if revision, err = hc.CreateControllerRevision(s, revision, ptr.To(int32(0))); err != nil {
+			t.Fatalf("failed to create revision: %v", err)
+		}
+		revisionNames = append(revisionNames, revision.Name)
+	}
+
+	failedPod := newUpdatedPod(sidecarSet, 2*time.Minute, false, true)
+	failedPod.Annotations[sidecarcontrol.SidecarSetListAnnotation] = sidecarSet.Name
+	if err := fakeClient.Create(context.TODO(), failedPod); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	processor := NewSidecarSetProcessor(fakeClient, record.NewFakeRecorder(10))
+	if _, err := processor.UpdateSidecarSet(sidecarSet); err != nil {
+		t.Fatalf("failed to update sidecarSet: %v", err)
+	}
+	podOutput, err := getLatestPod(fakeClient, failedPod)
+	if err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if image := podOutput.Spec.Containers[1].Image; image != "test-image:v1" {
+		t.Fatalf("expect sidecar rolled back to test-image:v1, but got %s", image)
+	}
+	if hash := sidecarcontrol.GetPodSidecarSetRevision(sidecarSet.Name, podOutput); hash != "aaa" {
+		t.Fatalf("expect pod sidecarSet hash aaa, but got %s", hash)
+	}
+	sidecarSetOutput, err := getLatestSidecarSet(fakeClient, sidecarSet)
+	if err != nil {
+		t.Fatalf("failed to get sidecarSet: %v", err)
+	}
+	if !isSidecarSetRolloutFailed(sidecarSetOutput) {
+		t.Fatalf("expect sidecarSet rollout failed, but got conditions %v", sidecarSetOutput.Status.Conditions)
+	}
+	condition := getSidecarSetCondition(sidecarSetOutput.Status, appsv1beta1.SidecarSetConditionTypeRolloutFailed)
+	if !strings.HasSuffix(condition.Message, "rolled back to revision "+revisionNames[0]) {
+		t.Fatalf("expect rolled back to revision %s, but got message %q", revisionNames[0], condition.Message)
+	}
+
+	// the failed revision must not be rolled out again
+	if _, err = processor.UpdateSidecarSet(sidecarSetOutput); err != nil {
+		t.Fatalf("failed to update sidecarSet: %v", err)
+	}
+	podOutput, _ = getLatestPod(fakeClient, failedPod)
+	if image := podOutput.Spec.Containers[1].Image; image != "test-image:v1" {
+		t.Fatalf("expect sidecar kept in test-image:v1, but got %s", image)
+	}
+
+	// a new revision ends the failed rollout
+	sidecarSetOutput.Annotations[sidecarcontrol.SidecarSetHashAnnotation] = "ccc"
+	sidecarSetOutput.Spec.Containers[0].Image = "test-image:v3"
+	if _, err = processor.UpdateSidecarSet(sidecarSetOutput); err != nil {
+		t.Fatalf("failed to update sidecarSet: %v", err)
+	}
+	sidecarSetOutput, _ = getLatestSidecarSet(fakeClient, sidecarSet)
+	if isSidecarSetRolloutFailed(sidecarSetOutput) {
+		t.Fatalf("expect failed rollout condition reset by new revision, but got conditions %v", sidecarSetOutput.Status.Conditions)
+	}
+}
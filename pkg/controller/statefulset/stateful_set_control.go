@@ -41,10 +41,13 @@ import (
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/expectations"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	imagejobutilfunc "github.com/openkruise/kruise/pkg/util/imagejob/utilfunction"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 	"github.com/openkruise/kruise/pkg/util/lifecycle"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
+	"github.com/openkruise/kruise/pkg/util/requeueduration"
 	"github.com/openkruise/kruise/pkg/util/specifieddelete"
 )
 
@@ -388,6 +391,7 @@ func (ssc *defaultStatefulSetControl) updateStatefulSet(
 
 	ssc.updatePVCStatus(&status, set, pods)
 	updateStatus(&status, minReadySeconds, currentRevision, updateRevision, pods)
+	setLifecycleHookFailedCondition(set, &status, pods)
 
 	startOrdinal, endOrdinal, reserveOrdinals := getStatefulSetReplicasRange(set)
 	// slice that will contain all Pods such that startOrdinal <= getOrdinal(pod) < endOrdinal and not in reserveOrdinals
@@ -543,6 +547,22 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 		klog.V(4).InfoS("Not satisfied update for statefulSet", "statefulSet", klog.KObj(set), "updateDirtyPods", updateDirtyPods)
 		return status, nil
 	}
+	// If resourceVersion expectations have not satisfied yet, skip updating pods
+	for _, pod := range pods {
+		if pod == nil {
+			continue
+		}
+		resourceVersionExpectations.Observe(pod)
+		if isSatisfied, unsatisfiedDuration := resourceVersionExpectations.IsSatisfied(pod); !isSatisfied {
+			if unsatisfiedDuration < expectations.ExpectationTimeout {
+				klog.V(4).InfoS("Not satisfied resourceVersion for statefulSet, wait for pod updating", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+				durationStore.Push(getStatefulSetKey(set), expectations.ExpectationTimeout-unsatisfiedDuration)
+				return status, nil
+			}
+			klog.InfoS("Expectation unsatisfied overtime for statefulSet, wait for pod updating timeout", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "timeout", unsatisfiedDuration)
+			resourceVersionExpectations.Delete(pod)
+		}
+	}
 
 	// refresh states for all pods
 	var modified bool
@@ -633,6 +653,7 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 	}
 
 	updateIndexes := sortPodsToUpdate(set.Spec.UpdateStrategy.RollingUpdate, updateRevision.Name, *set.Spec.Replicas, replicas)
+	updateIndexes = sortPodsByRole(set, replicas, updateIndexes)
 	klog.V(3).InfoS("Prepare to update pods indexes for StatefulSet", "statefulSet", klog.KObj(set), "podIndexes", updateIndexes)
 	// update pods in sequence
 	for _, target := range updateIndexes {
@@ -643,6 +664,13 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 				return status, err
 			}
 		}
+		var migrateClaims []string
+		if isDataMigrationEnabled(set) && isCreated(replicas[target]) {
+			if migrateClaims, err = ssc.podControl.getClaimsToMigrate(set, replicas[target]); err != nil {
+				return status, err
+			}
+			pvcMatched = len(migrateClaims) == 0
+		}
 
 		// the target is already up-to-date, go to next
 		if getPodRevision(replicas[target]) == updateRevision.Name && pvcMatched {
@@ -657,6 +685,30 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 			return status, nil
 		}
 
+		// the leader should be updated after its role moved to another pod
+		if isLeaderPod(getRoleAwareUpdateStrategy(set), replicas[target]) && !specifiedDeletedPods.Has(replicas[target].Name) {
+			if ready, err := ssc.prepareLeaderUpdate(set, replicas[target], unavailablePods); err != nil || !ready {
+				return status, err
+			}
+		}
+
+		// take volume snapshots of the pod before it is updated, and pause the rollout if any snapshot failed
+		if getSnapshotBeforeUpdateStrategy(set) != nil && getPodRevision(replicas[target]) != updateRevision.Name &&
+			!specifiedDeletedPods.Has(replicas[target].Name) && !isTerminating(replicas[target]) {
+			ready, err := ssc.snapshotBeforeUpdate(set, replicas[target], updateRevision)
+			if err != nil {
+				msg := fmt.Sprintf("failed to take volume snapshots of pod %s before update: %v", replicas[target].Name, err)
+				ssc.recorder.Event(set, v1.EventTypeWarning, "FailedSnapshotBeforeUpdate", msg)
+				SetStatefulsetCondition(status, NewStatefulsetCondition(appsv1beta1.FailedUpdatePod, v1.ConditionTrue, "FailedSnapshotBeforeUpdate", msg))
+				durationStore.Push(getStatefulSetKey(set), snapshotFailedRequeueDuration)
+				return status, nil
+			} else if !ready {
+				// mark target as unavailable because it is waiting for snapshots
+				unavailablePods.Insert(replicas[target].Name)
+				continue
+			}
+		}
+
 		// Kruise currently will not patch pvc size until a pod references the resized volume.
 		// online-file-system-expansion: if no pods referencing the volume are running, file system expansion will not happen.
 		// refer to https://kubernetes.io/blog/2018/07/12/resizing-persistent-volumes-using-kubernetes/#online-file-system-expansion
@@ -683,10 +735,19 @@ func (ssc *defaultStatefulSetControl) rollingUpdateStatefulsetPods(
 
 		// delete the Pod if it is not already terminating and does not match the update revision.
 		if !specifiedDeletedPods.Has(replicas[target].Name) && !isTerminating(replicas[target]) {
-			// todo validate in-place for pub
-			inplacing, inplaceUpdateErr := ssc.inPlaceUpdatePod(set, replicas[target], updateRevision, revisions)
-			if inplaceUpdateErr != nil {
-				return status, inplaceUpdateErr
+			var inplacing bool
+			if len(migrateClaims) > 0 {
+				// the Pod has to be recreated with the migrated claims
+				if err := ssc.prepareDataMigration(set, replicas[target], migrateClaims); err != nil {
+					return status, err
+				}
+			} else {
+				// todo validate in-place for pub
+				var inplaceUpdateErr error
+				inplacing, inplaceUpdateErr = ssc.inPlaceUpdatePod(set, replicas[target], updateRevision, revisions)
+				if inplaceUpdateErr != nil {
+					return status, inplaceUpdateErr
+				}
 			}
 			// if pod is inplacing or actual deleting, decrease revision
 			revisionNeedDecrease := inplacing
@@ -752,7 +813,34 @@ func (ssc *defaultStatefulSetControl) handleSpecifiedDeletedPods(
 }
 
 func (ssc *defaultStatefulSetControl) deletePod(set *appsv1beta1.StatefulSet, pod *v1.Pod) (modified, actualDeleting bool, err error) {
-	if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.PreDelete, pod) {
+	// the PreDelete hook is handled here for any update strategy, since pods are refreshed only in rolling update
+	if set.Spec.Lifecycle != nil && lifecycle.GetPodLifecycleState(pod) == appspub.LifecycleStatePreparingDelete {
+		hook := set.Spec.Lifecycle.PreDelete
+		if remaining, ok := lifecycle.GetHookTimeoutRemaining(hook, pod); ok {
+			durationStore.Push(getStatefulSetKey(set), remaining)
+		}
+		// the finalizers of hook would block the deletion proceeding after timed out
+		if lifecycle.GetHookTimeoutAction(hook) == appspub.LifecycleHookTimeoutProceed && lifecycle.IsHookTimedOut(hook, pod) {
+			if updated, gotPod, err := ssc.lifecycleControl.RemovePodHookFinalizers(pod, hook); err != nil {
+				return false, false, err
+			} else if updated {
+				klog.V(3).InfoS("StatefulSet removed PreDelete finalizers of Pod timed out", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+				pod = gotPod
+			}
+		}
+
+		updated, gotPod, retryAfter, err := ssc.lifecycleControl.ExecuteHTTPHook(pod, hook)
+		if err != nil {
+			return false, false, err
+		}
+		durationStore.Push(getStatefulSetKey(set), retryAfter)
+		if updated {
+			klog.V(3).InfoS("StatefulSet called PreDelete http hook of Pod", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+			resourceVersionExpectations.Expect(gotPod)
+			return true, false, nil
+		}
+	}
+	if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.PreDelete, pod, appspub.LifecycleStatePreparingDelete) {
 		markPodNotReady := set.Spec.Lifecycle.PreDelete.MarkPodNotReady
 		if updated, _, err := ssc.lifecycleControl.UpdatePodLifecycle(pod, appspub.LifecycleStatePreparingDelete, markPodNotReady); err != nil {
 			return false, false, err
@@ -783,12 +871,39 @@ func (ssc *defaultStatefulSetControl) refreshPodState(set *appsv1beta1.StatefulS
 		return false, 0, res.RefreshErr
 	}
 
+	hook := lifecycle.GetPodLifecycleHook(set.Spec.Lifecycle, pod)
+	if marked, err := ssc.lifecycleControl.MarkPodHookTimedOut(pod, hook); err != nil {
+		klog.ErrorS(err, "AdvancedStatefulSet failed to mark pod lifecycle hook timed out",
+			"statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+		return false, 0, err
+	} else if marked {
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "LifecycleHookTimeout", "pod %s timed out in lifecycle state %s, onTimeout %s",
+			pod.Name, lifecycle.GetPodLifecycleState(pod), lifecycle.GetHookTimeoutAction(hook))
+	}
+
+	hookUpdated, gotPod, hookRetryAfter, err := ssc.lifecycleControl.ExecuteHTTPHook(pod, hook)
+	if err != nil {
+		klog.ErrorS(err, "AdvancedStatefulSet failed to update pod lifecycle http hook status",
+			"statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+		return false, 0, err
+	} else if hookUpdated {
+		resourceVersionExpectations.Expect(gotPod)
+		klog.V(3).InfoS("AdvancedStatefulSet called pod lifecycle http hook", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
+		return true, hookRetryAfter, nil
+	}
+	delay := requeueduration.Duration{}
+	delay.Update(res.DelayDuration)
+	delay.Update(hookRetryAfter)
+	if remaining, ok := lifecycle.GetHookTimeoutRemaining(hook, pod); ok {
+		delay.Update(remaining)
+	}
+
 	var state appspub.LifecycleStateType
 	switch lifecycle.GetPodLifecycleState(pod) {
 	case appspub.LifecycleStatePreparingNormal:
 		if set.Spec.Lifecycle == nil ||
 			set.Spec.Lifecycle.PreNormal == nil ||
-			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.PreNormal, pod) {
+			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.PreNormal, pod, appspub.LifecycleStatePreparingNormal) {
 			state = appspub.LifecycleStateNormal
 		}
 	case appspub.LifecycleStatePreparingUpdate:
@@ -796,7 +911,7 @@ func (ssc *defaultStatefulSetControl) refreshPodState(set *appsv1beta1.StatefulS
 		// then rollback, do not need update pod inplace since it is the update revision,
 		// so just update pod lifecycle state. ref: https://github.com/openkruise/kruise/issues/1156
 		if getPodRevision(pod) == updateRevision {
-			if set.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if set.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 				state = appspub.LifecycleStateUpdated
 			} else {
 				state = appspub.LifecycleStateNormal
@@ -804,7 +919,7 @@ func (ssc *defaultStatefulSetControl) refreshPodState(set *appsv1beta1.StatefulS
 		}
 	case appspub.LifecycleStateUpdating:
 		if opts.CheckPodUpdateCompleted(pod) == nil {
-			if set.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if set.Spec.Lifecycle != nil && !lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 				state = appspub.LifecycleStateUpdated
 			} else {
 				state = appspub.LifecycleStateNormal
@@ -813,7 +928,7 @@ func (ssc *defaultStatefulSetControl) refreshPodState(set *appsv1beta1.StatefulS
 	case appspub.LifecycleStateUpdated:
 		if set.Spec.Lifecycle == nil ||
 			set.Spec.Lifecycle.InPlaceUpdate == nil ||
-			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod) {
+			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStateUpdated) {
 			state = appspub.LifecycleStateNormal
 		}
 	}
@@ -827,11 +942,11 @@ func (ssc *defaultStatefulSetControl) refreshPodState(set *appsv1beta1.StatefulS
 			return false, 0, err
 		} else if updated {
 			klog.V(3).InfoS("AdvancedStatefulSet updated pod lifecycle", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "lifecycleState", state)
-			return true, res.DelayDuration, nil
+			return true, delay.Get(), nil
 		}
 	}
 
-	return false, res.DelayDuration, nil
+	return false, delay.Get(), nil
 }
 
 func (ssc *defaultStatefulSetControl) inPlaceUpdatePod(
@@ -865,7 +980,7 @@ func (ssc *defaultStatefulSetControl) inPlaceUpdatePod(
 		case "", appspub.LifecycleStatePreparingNormal, appspub.LifecycleStateNormal:
 			var err error
 			var updated bool
-			if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStatePreparingUpdate) {
 				markPodNotReady := set.Spec.Lifecycle.InPlaceUpdate.MarkPodNotReady
 				if updated, _, err = ssc.lifecycleControl.UpdatePodLifecycle(pod, appspub.LifecycleStatePreparingUpdate, markPodNotReady); err == nil && updated {
 					klog.V(3).InfoS("StatefulSet updated pod lifecycle to PreparingUpdate", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod))
@@ -884,7 +999,7 @@ func (ssc *defaultStatefulSetControl) inPlaceUpdatePod(
 			}
 			return true, err
 		case appspub.LifecycleStatePreparingUpdate:
-			if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.InPlaceUpdate, pod) {
+			if set.Spec.Lifecycle != nil && lifecycle.IsPodHooked(set.Spec.Lifecycle.InPlaceUpdate, pod, appspub.LifecycleStatePreparingUpdate) {
 				return true, nil
 			}
 		case appspub.LifecycleStateUpdating:
@@ -928,6 +1043,15 @@ func (ssc *defaultStatefulSetControl) updateStatefulSetStatus(
 
 	// complete any in progress rolling update if necessary
 	completeRollingUpdate(set, status)
+	workloadmetrics.RecordStatus(workloadmetrics.KindStatefulSet, set.Namespace, set.Name, workloadmetrics.Status{
+		Desired:         *set.Spec.Replicas,
+		Updated:         status.UpdatedReplicas,
+		Ready:           status.ReadyReplicas,
+		Available:       &status.AvailableReplicas,
+		CurrentRevision: status.CurrentRevision,
+		UpdateRevision:  status.UpdateRevision,
+		Paused:          set.Spec.UpdateStrategy.RollingUpdate != nil && set.Spec.UpdateStrategy.RollingUpdate.Paused,
+	})
 
 	// if the status is not inconsistent do not perform an update
 	if !inconsistentStatus(set, status) {
@@ -1111,6 +1235,15 @@ func (ssc *defaultStatefulSetControl) processReplica(
 	}
 	// If we find a Pod that has not been created we create the Pod
 	if !isCreated(replicas[i]) {
+		// the Pod is recreated after its claims migrated
+		if migrated, err := ssc.processDataMigration(set, replicas[i]); err != nil {
+			msg := fmt.Sprintf("failed to migrate data of Pod %s: %v", replicas[i].Name, err)
+			condition := NewStatefulsetCondition(appsv1beta1.FailedCreatePod, v1.ConditionTrue, "FailedDataMigration", msg)
+			SetStatefulsetCondition(status, condition)
+			return true, false, err
+		} else if !migrated {
+			return true, false, nil
+		}
 		if utilfeature.DefaultFeatureGate.Enabled(features.StatefulSetAutoDeletePVC) {
 			if isStale, err := ssc.podControl.PodClaimIsStale(set, replicas[i]); err != nil {
 				return true, false, err
@@ -1124,7 +1257,7 @@ func (ssc *defaultStatefulSetControl) processReplica(
 		state := appspub.LifecycleStatePreparingNormal
 		if set.Spec.Lifecycle == nil ||
 			set.Spec.Lifecycle.PreNormal == nil ||
-			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.PreNormal, replicas[i]) {
+			lifecycle.IsPodAllHooked(set.Spec.Lifecycle.PreNormal, replicas[i], appspub.LifecycleStatePreparingNormal) {
 			state = appspub.LifecycleStateNormal
 		}
 		lifecycle.SetPodLifecycle(state)(replicas[i])
@@ -21,12 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -43,11 +46,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	storagelisters "k8s.io/client-go/listers/storage/v1"
+	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
@@ -68,6 +73,7 @@ import (
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/httphook"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 	"github.com/openkruise/kruise/pkg/util/lifecycle"
 	"github.com/openkruise/kruise/pkg/util/revisionadapter"
@@ -2670,6 +2676,96 @@ func TestStatefulSetControlLifecycleHook(t *testing.T) {
 	}
 }
 
+func TestDeletePodWithPreDeleteHookTimedOut(t *testing.T) {
+	set := newStatefulSet(3)
+	set.Spec.Lifecycle = &appspub.Lifecycle{
+		PreDelete: &appspub.LifecycleHook{
+			FinalizersHandler: []string{"example.com/hook"},
+			TimeoutSeconds:    ptr.To(int32(60)),
+			OnTimeout:         appspub.LifecycleHookTimeoutProceed,
+		},
+	}
+	pod := newStatefulSetPod(set, 2)
+	pod.Labels[appspub.LifecycleStateKey] = string(appspub.LifecycleStatePreparingDelete)
+	pod.Annotations = map[string]string{appspub.LifecycleTimestampKey: time.Now().Add(-2 * time.Minute).Format(time.RFC3339)}
+	pod.Finalizers = []string{"example.com/hook", "example.com/other"}
+
+	client := fake.NewSimpleClientset(pod)
+	ssc := &defaultStatefulSetControl{
+		podControl:       NewStatefulPodControl(client, nil, nil, nil, record.NewFakeRecorder(10)),
+		recorder:         record.NewFakeRecorder(10),
+		lifecycleControl: lifecycle.NewForTypedClient(client),
+	}
+	modified, actualDeleting, err := ssc.deletePod(set, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !modified || !actualDeleting {
+		t.Fatalf("expected pod deleted, got modified %v, actualDeleting %v", modified, actualDeleting)
+	}
+
+	var removed, deleted bool
+	for _, action := range client.Actions() {
+		switch {
+		case action.Matches("update", "pods"):
+			updated := action.(core.UpdateAction).GetObject().(*v1.Pod)
+			removed = reflect.DeepEqual(updated.Finalizers, []string{"example.com/other"})
+		case action.Matches("delete", "pods"):
+			deleted = removed
+		}
+	}
+	if !deleted {
+		t.Fatalf("expected finalizers of hook removed before deleting pod, got actions %v", client.Actions())
+	}
+}
+
+func TestDeletePodWithPreDeleteHTTPHook(t *testing.T) {
+	allowedHosts := httphook.AllowedHosts
+	httphook.AllowedHosts = "127.0.0.1"
+	defer func() { httphook.AllowedHosts = allowedHosts }()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	set := newStatefulSet(3)
+	set.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+	set.Spec.Lifecycle = &appspub.Lifecycle{
+		PreDelete: &appspub.LifecycleHook{HTTPHandler: &appspub.LifecycleHTTPHandler{URL: server.URL}},
+	}
+	pod := newStatefulSetPod(set, 2)
+	pod.Labels[appspub.LifecycleStateKey] = string(appspub.LifecycleStatePreparingDelete)
+	pod.Annotations = map[string]string{appspub.LifecycleTimestampKey: time.Now().Format(time.RFC3339)}
+
+	client := fake.NewSimpleClientset(pod)
+	ssc := &defaultStatefulSetControl{
+		podControl:       NewStatefulPodControl(client, nil, nil, nil, record.NewFakeRecorder(10)),
+		recorder:         record.NewFakeRecorder(10),
+		lifecycleControl: lifecycle.NewForTypedClient(client),
+	}
+	modified, actualDeleting, err := ssc.deletePod(set, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !modified || actualDeleting {
+		t.Fatalf("expected http hook called without deleting pod, got modified %v, actualDeleting %v", modified, actualDeleting)
+	}
+	gotPod, err := client.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := lifecycle.GetHTTPHookStatus(gotPod); status == nil || status.LastCallTime == "" {
+		t.Fatalf("expected http hook status recorded, got %v", gotPod.Annotations)
+	}
+	if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return atomic.LoadInt32(&calls) > 0, nil
+	}); err != nil {
+		t.Fatalf("expected http hook endpoint called: %v", err)
+	}
+}
+
 type manageCase struct {
 	name           string
 	set            *appsv1beta1.StatefulSet
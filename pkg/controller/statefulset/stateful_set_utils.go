@@ -646,7 +646,8 @@ func inconsistentStatus(set *appsv1beta1.StatefulSet, status *appsv1beta1.Statef
 		status.UpdatedReplicas != set.Status.UpdatedReplicas ||
 		status.CurrentRevision != set.Status.CurrentRevision ||
 		status.UpdateRevision != set.Status.UpdateRevision ||
-		status.LabelSelector != set.Status.LabelSelector {
+		status.LabelSelector != set.Status.LabelSelector ||
+		hasLifecycleHookFailedConditionChanged(set.Status, *status) {
 		return true
 	}
 
@@ -751,6 +752,28 @@ func SetStatefulsetCondition(status *appsv1beta1.StatefulSetStatus, condition ap
 	status.Conditions = append(newConditions, condition)
 }
 
+// setLifecycleHookFailedCondition lists the pods timed out in lifecycle hook with MarkFailed action in LifecycleHookFailed condition.
+func setLifecycleHookFailedCondition(set *appsv1beta1.StatefulSet, status *appsv1beta1.StatefulSetStatus, pods []*v1.Pod) {
+	failedPods := lifecycle.GetPodsFailedInHook(set.Spec.Lifecycle, pods)
+	if len(failedPods) == 0 {
+		return
+	}
+	condition := NewStatefulsetCondition(appsv1beta1.LifecycleHookFailed, v1.ConditionTrue, "LifecycleHookTimeout", lifecycle.GetLifecycleHookFailedMessage(failedPods))
+	if oldCondition := GetStatefulsetConditition(set.Status, condition.Type); oldCondition != nil && oldCondition.Status == condition.Status {
+		condition.LastTransitionTime = oldCondition.LastTransitionTime
+	}
+	SetStatefulsetCondition(status, condition)
+}
+
+func hasLifecycleHookFailedConditionChanged(oldStatus, newStatus appsv1beta1.StatefulSetStatus) bool {
+	oldCond := GetStatefulsetConditition(oldStatus, appsv1beta1.LifecycleHookFailed)
+	newCond := GetStatefulsetConditition(newStatus, appsv1beta1.LifecycleHookFailed)
+	if oldCond == nil || newCond == nil {
+		return oldCond != newCond
+	}
+	return oldCond.Message != newCond.Message
+}
+
 func filterOutCondition(conditions []apps.StatefulSetCondition, condType apps.StatefulSetConditionType) []apps.StatefulSetCondition {
 	var newCondititions []apps.StatefulSetCondition
 	for _, c := range conditions {
@@ -62,6 +62,7 @@ import (
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
 	"github.com/openkruise/kruise/pkg/util/lifecycle"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 	"github.com/openkruise/kruise/pkg/util/requeueduration"
 	"github.com/openkruise/kruise/pkg/util/revisionadapter"
@@ -77,6 +78,8 @@ var (
 	concurrentReconciles = 3
 
 	updateExpectations = expectations.NewUpdateExpectations(revisionadapter.NewDefaultImpl())
+	// resourceVersionExpectations waits for the pods patched out of update, such as the status of lifecycle http hook
+	resourceVersionExpectations = expectations.NewResourceVersionExpectation()
 	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
 	durationStore = requeueduration.DurationStore{}
 
@@ -239,6 +242,9 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets/status,verbs=get;update;patch
@@ -262,6 +268,7 @@ func (ssc *ReconcileStatefulSet) Reconcile(ctx context.Context, request reconcil
 			}
 		} else {
 			klog.ErrorS(retErr, "Finished syncing StatefulSet error", "statefulSet", request, "elapsedTime", time.Since(startTime))
+			workloadmetrics.RecordReconcileError("statefulset", retErr)
 		}
 	}()
 
@@ -269,6 +276,7 @@ func (ssc *ReconcileStatefulSet) Reconcile(ctx context.Context, request reconcil
 	if errors.IsNotFound(err) {
 		klog.InfoS("StatefulSet deleted", "statefulSet", key)
 		updateExpectations.DeleteExpectations(key)
+		workloadmetrics.DeleteStatus(workloadmetrics.KindStatefulSet, namespace, name)
 		return reconcile.Result{}, nil
 	}
 	if err != nil {
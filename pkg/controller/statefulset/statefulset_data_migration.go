@@ -0,0 +1,354 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/pvc"
+)
+
+const (
+	// dataMigrationRequeueDuration is the interval to check the migration jobs, for their events do not trigger StatefulSet reconcile.
+	dataMigrationRequeueDuration = 10 * time.Second
+
+	// dataMigrationClaimsAnnotation records the names of templates whose claims are migrated by the job.
+	dataMigrationClaimsAnnotation = "apps.kruise.io/data-migration-claims"
+	// dataMigrationVolumesAnnotation records the new PersistentVolumes indexed by the names of templates.
+	dataMigrationVolumesAnnotation = "apps.kruise.io/data-migration-volumes"
+	// dataMigrationReclaimPolicyAnnotation records the original reclaim policy of the new PersistentVolume.
+	dataMigrationReclaimPolicyAnnotation = "apps.kruise.io/data-migration-reclaim-policy"
+)
+
+func isDataMigrationEnabled(set *appsv1beta1.StatefulSet) bool {
+	return set.Spec.VolumeClaimUpdateStrategy.Type == appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType &&
+		set.Spec.VolumeClaimUpdateStrategy.DataMigration != nil && len(set.Spec.VolumeClaimTemplates) > 0
+}
+
+func getDataMigrationJobName(set *appsv1beta1.StatefulSet, ordinal int) string {
+	return fmt.Sprintf("%s-migration", getPodName(set, ordinal))
+}
+
+// getDataMigrationClaimName returns the name of the new claim provisioned for the data migration of claimName.
+func getDataMigrationClaimName(claimName string) string {
+	return fmt.Sprintf("%s-migration", claimName)
+}
+
+func getVolumeClaimTemplateByName(set *appsv1beta1.StatefulSet, name string) *v1.PersistentVolumeClaim {
+	for i := range set.Spec.VolumeClaimTemplates {
+		if set.Spec.VolumeClaimTemplates[i].Name == name {
+			return &set.Spec.VolumeClaimTemplates[i]
+		}
+	}
+	return nil
+}
+
+// getClaimsToMigrate returns the names of templates whose existing claims of the pod are incompatible with them.
+func (spc *StatefulPodControl) getClaimsToMigrate(set *appsv1beta1.StatefulSet, pod *v1.Pod) ([]string, error) {
+	var names []string
+	fn := func(claim, template *v1.PersistentVolumeClaim) (bool, error) {
+		if compatible, _ := pvc.IsPVCCompatibleAndReady(claim, template); !compatible {
+			names = append(names, template.Name)
+		}
+		return true, nil
+	}
+	if _, err := spc.handlePVCWithCustomFn(set, pod, true, fn); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// prepareDataMigration provisions the new claims from templates and creates the suspended migration job before the pod
+// is deleted. The job prevents the pod from being recreated until the claims are migrated.
+func (ssc *defaultStatefulSetControl) prepareDataMigration(set *appsv1beta1.StatefulSet, pod *v1.Pod, templateNames []string) error {
+	if sigsruntimeClient == nil {
+		return fmt.Errorf("no client to migrate data")
+	}
+	claims := getPersistentVolumeClaims(set, pod)
+	for _, name := range templateNames {
+		claim := claims[name]
+		claim.Name = getDataMigrationClaimName(claim.Name)
+		claim.Labels = map[string]string{statefulSetNameLabelKey: set.Name}
+		if err := sigsruntimeClient.Create(context.TODO(), &claim); err != nil && !errors.IsAlreadyExists(err) {
+			ssc.recorder.Eventf(set, v1.EventTypeWarning, "FailedDataMigration", "failed to create pvc %s for pod %s: %v", claim.Name, pod.Name, err)
+			return err
+		}
+	}
+
+	job := &batchv1.Job{}
+	jobName := getDataMigrationJobName(set, getOrdinal(pod))
+	err := sigsruntimeClient.Get(context.TODO(), client.ObjectKey{Namespace: set.Namespace, Name: jobName}, job)
+	if err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+	job = newDataMigrationJob(set, pod, jobName, templateNames)
+	if err := sigsruntimeClient.Create(context.TODO(), job); err != nil && !errors.IsAlreadyExists(err) {
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "FailedDataMigration", "failed to create migration job %s for pod %s: %v", jobName, pod.Name, err)
+		return err
+	}
+	klog.V(2).InfoS("StatefulSet created data migration job", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "job", jobName, "claims", templateNames)
+	ssc.recorder.Eventf(set, v1.EventTypeNormal, "SuccessfulCreateDataMigration", "create migration job %s for pod %s", jobName, pod.Name)
+	return nil
+}
+
+func newDataMigrationJob(set *appsv1beta1.StatefulSet, pod *v1.Pod, jobName string, templateNames []string) *batchv1.Job {
+	migration := set.Spec.VolumeClaimUpdateStrategy.DataMigration
+	template := migration.Template.DeepCopy()
+	if template.Spec.RestartPolicy == "" || template.Spec.RestartPolicy == v1.RestartPolicyAlways {
+		template.Spec.RestartPolicy = v1.RestartPolicyNever
+	}
+	ordinal := getOrdinal(pod)
+	for _, name := range templateNames {
+		claimName := getPersistentVolumeClaimName(set, getVolumeClaimTemplateByName(set, name), ordinal)
+		template.Spec.Volumes = append(template.Spec.Volumes,
+			v1.Volume{
+				Name: appsv1beta1.DataMigrationSourceVolumePrefix + name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			},
+			v1.Volume{
+				Name: appsv1beta1.DataMigrationTargetVolumePrefix + name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: getDataMigrationClaimName(claimName)},
+				},
+			})
+	}
+	claimsValue, _ := json.Marshal(templateNames)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       set.Namespace,
+			Name:            jobName,
+			Labels:          map[string]string{statefulSetNameLabelKey: set.Name},
+			Annotations:     map[string]string{dataMigrationClaimsAnnotation: string(claimsValue)},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(set, controllerKind)},
+		},
+		Spec: batchv1.JobSpec{
+			// resumed after the pod deleted
+			Suspend:      ptr.To(true),
+			BackoffLimit: migration.BackoffLimit,
+			Template:     *template,
+		},
+	}
+}
+
+func isJobFinished(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == conditionType && c.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// processDataMigration returns true if the pod, which has not been created, has no data migration in progress.
+// Otherwise, it resumes the migration job after the pod deleted, and swaps the claims after the job succeeded.
+func (ssc *defaultStatefulSetControl) processDataMigration(set *appsv1beta1.StatefulSet, pod *v1.Pod) (bool, error) {
+	if !isDataMigrationEnabled(set) || sigsruntimeClient == nil {
+		return true, nil
+	}
+	job := &batchv1.Job{}
+	err := sigsruntimeClient.Get(context.TODO(), client.ObjectKey{Namespace: set.Namespace, Name: getDataMigrationJobName(set, getOrdinal(pod))}, job)
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if job.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	if ptr.Deref(job.Spec.Suspend, false) {
+		job.Spec.Suspend = ptr.To(false)
+		klog.V(2).InfoS("StatefulSet resumed data migration job", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "job", klog.KObj(job))
+		return false, sigsruntimeClient.Update(context.TODO(), job)
+	}
+	if isJobFinished(job, batchv1.JobFailed) {
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "FailedDataMigration", "migration job %s for pod %s failed", job.Name, pod.Name)
+		return false, fmt.Errorf("migration job %s for pod %s failed", job.Name, pod.Name)
+	}
+	if !isJobFinished(job, batchv1.JobComplete) {
+		klog.V(4).InfoS("StatefulSet was waiting for data migration job", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "job", klog.KObj(job))
+		durationStore.Push(getStatefulSetKey(set), dataMigrationRequeueDuration)
+		return false, nil
+	}
+
+	if swapped, err := ssc.swapMigratedClaims(set, pod, job); err != nil || !swapped {
+		return false, err
+	}
+	if err := sigsruntimeClient.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	ssc.recorder.Eventf(set, v1.EventTypeNormal, "SuccessfulDataMigration", "migrate data of pod %s", pod.Name)
+	return true, nil
+}
+
+// swapMigratedClaims binds the new volumes to the claim names of the pod step by step, and returns true if all done:
+//  1. bind the new volume to the claim name in advance with Retain policy, so that it survives the new claim deleted;
+//  2. delete the new claim;
+//  3. delete the old claim, with its volume retained if OldVolumeClaimPolicy is Retain;
+//  4. recreate the claim from template with the new volume;
+//  5. restore the reclaim policy of the new volume after bound.
+func (ssc *defaultStatefulSetControl) swapMigratedClaims(set *appsv1beta1.StatefulSet, pod *v1.Pod, job *batchv1.Job) (bool, error) {
+	var templateNames []string
+	if err := json.Unmarshal([]byte(job.Annotations[dataMigrationClaimsAnnotation]), &templateNames); err != nil {
+		return false, fmt.Errorf("invalid annotation %s of job %s: %v", dataMigrationClaimsAnnotation, job.Name, err)
+	}
+	volumes := map[string]string{}
+	if value, ok := job.Annotations[dataMigrationVolumesAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &volumes); err != nil {
+			return false, fmt.Errorf("invalid annotation %s of job %s: %v", dataMigrationVolumesAnnotation, job.Name, err)
+		}
+	}
+
+	ctx := context.TODO()
+	ordinal := getOrdinal(pod)
+	claims := getPersistentVolumeClaims(set, pod)
+	allDone := true
+	for _, name := range templateNames {
+		template := getVolumeClaimTemplateByName(set, name)
+		if template == nil {
+			continue
+		}
+		claimName := getPersistentVolumeClaimName(set, template, ordinal)
+		newClaimName := getDataMigrationClaimName(claimName)
+
+		volumeName, ok := volumes[name]
+		if !ok {
+			newClaim := &v1.PersistentVolumeClaim{}
+			if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: newClaimName}, newClaim); err != nil {
+				return false, err
+			}
+			if newClaim.Spec.VolumeName == "" {
+				return false, fmt.Errorf("pvc %s has not been bound", newClaimName)
+			}
+			// record the volume first, for the new claim will be deleted
+			volumes[name] = newClaim.Spec.VolumeName
+			value, _ := json.Marshal(volumes)
+			job.Annotations[dataMigrationVolumesAnnotation] = string(value)
+			return false, sigsruntimeClient.Update(ctx, job)
+		}
+
+		volume := &v1.PersistentVolume{}
+		if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Name: volumeName}, volume); err != nil {
+			return false, err
+		}
+		if volume.Spec.ClaimRef == nil || volume.Spec.ClaimRef.Name != claimName {
+			if volume.Annotations == nil {
+				volume.Annotations = map[string]string{}
+			}
+			if _, ok := volume.Annotations[dataMigrationReclaimPolicyAnnotation]; !ok {
+				volume.Annotations[dataMigrationReclaimPolicyAnnotation] = string(volume.Spec.PersistentVolumeReclaimPolicy)
+			}
+			volume.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimRetain
+			volume.Spec.ClaimRef = &v1.ObjectReference{Kind: "PersistentVolumeClaim", APIVersion: "v1", Namespace: set.Namespace, Name: claimName}
+			klog.V(2).InfoS("StatefulSet binding migrated volume", "statefulSet", klog.KObj(set), "pv", volumeName, "pvc", claimName)
+			if err := sigsruntimeClient.Update(ctx, volume); err != nil {
+				return false, err
+			}
+			allDone = false
+			continue
+		}
+
+		newClaim := &v1.PersistentVolumeClaim{}
+		if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: newClaimName}, newClaim); err == nil {
+			if newClaim.DeletionTimestamp == nil {
+				if err := sigsruntimeClient.Delete(ctx, newClaim); err != nil && !errors.IsNotFound(err) {
+					return false, err
+				}
+			}
+			allDone = false
+			continue
+		} else if !errors.IsNotFound(err) {
+			return false, err
+		}
+
+		claim := &v1.PersistentVolumeClaim{}
+		err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: claimName}, claim)
+		if errors.IsNotFound(err) {
+			claim = ptr.To(claims[name])
+			claim.Spec.VolumeName = volumeName
+			if err := sigsruntimeClient.Create(ctx, claim); err != nil && !errors.IsAlreadyExists(err) {
+				return false, err
+			}
+			allDone = false
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if claim.Spec.VolumeName != volumeName {
+			if err := ssc.deleteOldClaim(set, claim); err != nil {
+				return false, err
+			}
+			allDone = false
+			continue
+		}
+
+		if claim.Status.Phase != v1.ClaimBound {
+			allDone = false
+			continue
+		}
+		if policy, ok := volume.Annotations[dataMigrationReclaimPolicyAnnotation]; ok {
+			volume.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimPolicy(policy)
+			delete(volume.Annotations, dataMigrationReclaimPolicyAnnotation)
+			if err := sigsruntimeClient.Update(ctx, volume); err != nil {
+				return false, err
+			}
+		}
+	}
+	if !allDone {
+		durationStore.Push(getStatefulSetKey(set), dataMigrationRequeueDuration)
+	}
+	return allDone, nil
+}
+
+func (ssc *defaultStatefulSetControl) deleteOldClaim(set *appsv1beta1.StatefulSet, claim *v1.PersistentVolumeClaim) error {
+	if claim.DeletionTimestamp != nil {
+		return nil
+	}
+	policy := set.Spec.VolumeClaimUpdateStrategy.DataMigration.OldVolumeClaimPolicy
+	if policy != appsv1beta1.DeleteOldVolumeClaimPolicyType && claim.Spec.VolumeName != "" {
+		volume := &v1.PersistentVolume{}
+		if err := sigsruntimeClient.Get(context.TODO(), client.ObjectKey{Name: claim.Spec.VolumeName}, volume); err != nil {
+			return err
+		}
+		if volume.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimRetain {
+			volume.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimRetain
+			if err := sigsruntimeClient.Update(context.TODO(), volume); err != nil {
+				return err
+			}
+		}
+	}
+	klog.V(2).InfoS("StatefulSet deleting old pvc after data migrated", "statefulSet", klog.KObj(set), "pvc", klog.KObj(claim), "policy", policy)
+	if err := sigsruntimeClient.Delete(context.TODO(), claim); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	ssc.recorder.Eventf(set, v1.EventTypeNormal, "SuccessfulDeleteOldPVC", "delete pvc %s after data migrated", claim.Name)
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigsfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+	kruiseinformers "github.com/openkruise/kruise/pkg/client/informers/externalversions"
+)
+
+func newDataMigrationTestSet() *appsv1beta1.StatefulSet {
+	set := newStatefulSet(3)
+	for i := range set.Spec.VolumeClaimTemplates {
+		set.Spec.VolumeClaimTemplates[i].Spec.StorageClassName = ptr.To("new")
+		set.Spec.VolumeClaimTemplates[i].Spec.Resources.Requests = v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}
+	}
+	set.Spec.VolumeClaimUpdateStrategy = appsv1beta1.VolumeClaimUpdateStrategy{
+		Type: appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType,
+		DataMigration: &appsv1beta1.VolumeClaimDataMigration{
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "copy", Image: "busybox", Command: []string{"cp", "-a", "/source/.", "/target/"}}},
+			}},
+			BackoffLimit:         ptr.To(int32(3)),
+			OldVolumeClaimPolicy: appsv1beta1.RetainOldVolumeClaimPolicyType,
+		},
+	}
+	return set
+}
+
+func TestGetClaimsToMigrate(t *testing.T) {
+	set := newDataMigrationTestSet()
+	pod := newStatefulSetPod(set, 1)
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+	kruiseInformerFactory := kruiseinformers.NewSharedInformerFactory(kruisefake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+	om := newFakeObjectManager(informerFactory, kruiseInformerFactory)
+	spc := NewStatefulPodControlFromManager(om, &noopRecorder{})
+
+	for _, claim := range getPersistentVolumeClaims(set, pod) {
+		claim := claim
+		_ = om.claimsIndexer.Add(&claim)
+	}
+	if names, err := spc.getClaimsToMigrate(set, pod); err != nil || len(names) != 0 {
+		t.Fatalf("expected no claims to migrate, got %v, %v", names, err)
+	}
+
+	set.Spec.VolumeClaimTemplates[0].Spec.StorageClassName = ptr.To("another")
+	if names, err := spc.getClaimsToMigrate(set, pod); err != nil || !reflect.DeepEqual(names, []string{"datadir"}) {
+		t.Fatalf("expected datadir to migrate, got %v, %v", names, err)
+	}
+}
+
+func TestDataMigration(t *testing.T) {
+	set := newDataMigrationTestSet()
+	pod := newStatefulSetPod(set, 1)
+	claimName := getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[0], 1)
+	newClaimName := getDataMigrationClaimName(claimName)
+
+	oldClaim := ptr.To(getPersistentVolumeClaims(set, pod)["datadir"])
+	oldClaim.Spec.StorageClassName = ptr.To("old")
+	oldClaim.Spec.VolumeName = "pv-old"
+	oldClaim.Status.Phase = v1.ClaimBound
+	oldVolume := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-old"},
+		Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+	}
+
+	defer func(c client.Client) { sigsruntimeClient = c }(sigsruntimeClient)
+	sigsruntimeClient = sigsfake.NewClientBuilder().WithObjects(oldClaim, oldVolume).Build()
+	ssc := &defaultStatefulSetControl{recorder: record.NewFakeRecorder(100)}
+	ctx := context.TODO()
+
+	if err := ssc.prepareDataMigration(set, pod, []string{"datadir"}); err != nil {
+		t.Fatalf("failed to prepare data migration: %v", err)
+	}
+	job := &batchv1.Job{}
+	jobKey := client.ObjectKey{Namespace: set.Namespace, Name: getDataMigrationJobName(set, 1)}
+	if err := sigsruntimeClient.Get(ctx, jobKey, job); err != nil {
+		t.Fatalf("failed to get migration job: %v", err)
+	}
+	if !ptr.Deref(job.Spec.Suspend, false) || len(job.Spec.Template.Spec.Volumes) != 2 ||
+		job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName != claimName ||
+		job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName != newClaimName {
+		t.Fatalf("unexpected migration job %+v", job.Spec)
+	}
+
+	// resume the job after pod deleted
+	if migrated, err := ssc.processDataMigration(set, pod); err != nil || migrated {
+		t.Fatalf("expected job resumed, got %v, %v", migrated, err)
+	}
+	_ = sigsruntimeClient.Get(ctx, jobKey, job)
+	if ptr.Deref(job.Spec.Suspend, false) {
+		t.Fatalf("expected job resumed")
+	}
+	if migrated, err := ssc.processDataMigration(set, pod); err != nil || migrated {
+		t.Fatalf("expected waiting for job, got %v, %v", migrated, err)
+	}
+
+	// the job succeeded with the new claim bound
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	_ = sigsruntimeClient.Status().Update(ctx, job)
+	newClaim := &v1.PersistentVolumeClaim{}
+	_ = sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: newClaimName}, newClaim)
+	newClaim.Spec.VolumeName = "pv-new"
+	_ = sigsruntimeClient.Update(ctx, newClaim)
+	_ = sigsruntimeClient.Create(ctx, &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-new"},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+			ClaimRef:                      &v1.ObjectReference{Namespace: set.Namespace, Name: newClaimName},
+		},
+	})
+
+	var migrated bool
+	for i := 0; i < 10 && !migrated; i++ {
+		var err error
+		if migrated, err = ssc.processDataMigration(set, pod); err != nil {
+			t.Fatalf("failed to process data migration: %v", err)
+		}
+		// simulate the binding of pv controller
+		claim := &v1.PersistentVolumeClaim{}
+		if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: claimName}, claim); err == nil &&
+			claim.Spec.VolumeName == "pv-new" && claim.Status.Phase != v1.ClaimBound {
+			claim.Status.Phase = v1.ClaimBound
+			_ = sigsruntimeClient.Status().Update(ctx, claim)
+		}
+	}
+	if !migrated {
+		t.Fatalf("expected data migrated")
+	}
+
+	claim := &v1.PersistentVolumeClaim{}
+	if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: claimName}, claim); err != nil {
+		t.Fatalf("failed to get claim: %v", err)
+	}
+	if claim.Spec.VolumeName != "pv-new" || *claim.Spec.StorageClassName != "new" {
+		t.Fatalf("expected claim recreated with new volume, got %+v", claim.Spec)
+	}
+	newVolume := &v1.PersistentVolume{}
+	_ = sigsruntimeClient.Get(ctx, client.ObjectKey{Name: "pv-new"}, newVolume)
+	if newVolume.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete || newVolume.Spec.ClaimRef.Name != claimName {
+		t.Fatalf("unexpected new volume %+v", newVolume.Spec)
+	}
+	_ = sigsruntimeClient.Get(ctx, client.ObjectKey{Name: "pv-old"}, oldVolume)
+	if oldVolume.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimRetain {
+		t.Fatalf("expected old volume retained, got %v", oldVolume.Spec.PersistentVolumeReclaimPolicy)
+	}
+	if err := sigsruntimeClient.Get(ctx, client.ObjectKey{Namespace: set.Namespace, Name: newClaimName}, newClaim); !errors.IsNotFound(err) {
+		t.Fatalf("expected new claim deleted, got %v", err)
+	}
+	if err := sigsruntimeClient.Get(ctx, jobKey, job); !errors.IsNotFound(err) {
+		t.Fatalf("expected job deleted, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+const (
+	defaultRoleSwitchoverTimeoutSeconds = 60
+	defaultMaxRoleSwitchoverAttempts    = 3
+	// roleSwitchoverRequestTimeout is the timeout of a single switchover request.
+	roleSwitchoverRequestTimeout = 10 * time.Second
+	// roleSwitchoverWorkers is the max number of switchover requests running at the same time.
+	roleSwitchoverWorkers = 10
+)
+
+var (
+	roleSwitchoverExecutor = httphook.NewExecutor(roleSwitchoverWorkers)
+	roleSwitchoverNow      = time.Now
+)
+
+func getRoleAwareUpdateStrategy(set *appsv1beta1.StatefulSet) *appsv1beta1.RoleAwareUpdateStrategy {
+	if set.Spec.UpdateStrategy.RollingUpdate == nil {
+		return nil
+	}
+	return set.Spec.UpdateStrategy.RollingUpdate.RoleAwareUpdate
+}
+
+func getRoleSwitchoverTimeout(strategy *appsv1beta1.RoleAwareUpdateStrategy) time.Duration {
+	if strategy.SwitchoverTimeoutSeconds == nil || *strategy.SwitchoverTimeoutSeconds <= 0 {
+		return defaultRoleSwitchoverTimeoutSeconds * time.Second
+	}
+	return time.Duration(*strategy.SwitchoverTimeoutSeconds) * time.Second
+}
+
+// isLeaderPod returns true if the role label of pod is the leader role of strategy.
+func isLeaderPod(strategy *appsv1beta1.RoleAwareUpdateStrategy, pod *v1.Pod) bool {
+	if strategy == nil || pod == nil {
+		return false
+	}
+	leaderValue := strategy.LeaderRoleValue
+	if leaderValue == "" {
+		leaderValue = appsv1beta1.DefaultLeaderRoleValue
+	}
+	value, ok := pod.Labels[strategy.RoleLabelKey]
+	return ok && value == leaderValue
+}
+
+// sortPodsByRole moves the indexes of leader pods to the end and keeps the order of the others.
+func sortPodsByRole(set *appsv1beta1.StatefulSet, replicas []*v1.Pod, indexes []int) []int {
+	strategy := getRoleAwareUpdateStrategy(set)
+	if strategy == nil {
+		return indexes
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return !isLeaderPod(strategy, replicas[indexes[i]]) && isLeaderPod(strategy, replicas[indexes[j]])
+	})
+	return indexes
+}
+
+func getMaxRoleSwitchoverAttempts(strategy *appsv1beta1.RoleAwareUpdateStrategy) int {
+	if strategy.MaxSwitchoverAttempts == nil || *strategy.MaxSwitchoverAttempts <= 0 {
+		return defaultMaxRoleSwitchoverAttempts
+	}
+	return int(*strategy.MaxSwitchoverAttempts)
+}
+
+// getRoleSwitchoverAttempts returns the number of switchover called for the pod in its current revision.
+func getRoleSwitchoverAttempts(pod *v1.Pod) int {
+	value, ok := pod.Annotations[appsv1beta1.RoleSwitchoverAttemptsAnnotation]
+	if !ok {
+		return 0
+	}
+	idx := strings.LastIndex(value, "/")
+	if idx < 0 || value[:idx] != getPodRevision(pod) {
+		return 0
+	}
+	attempts, err := strconv.Atoi(value[idx+1:])
+	if err != nil {
+		return 0
+	}
+	return attempts
+}
+
+// prepareLeaderUpdate returns true if the leader pod can be updated now.
+// It waits for the other pods to be available, and then calls the switchover action in background and waits for
+// the role label to move away from the pod. The switchover is called again if the role does not move in
+// SwitchoverTimeoutSeconds, and the leader is updated without switchover after MaxSwitchoverAttempts.
+func (ssc *defaultStatefulSetControl) prepareLeaderUpdate(set *appsv1beta1.StatefulSet, pod *v1.Pod, unavailablePods sets.String) (bool, error) {
+	strategy := getRoleAwareUpdateStrategy(set)
+	// no need to switchover if it is not configured, the leader is not working, or no other pod can take over
+	if strategy.Switchover == nil || !isHealthy(pod) || (set.Spec.Replicas != nil && *set.Spec.Replicas <= 1) {
+		return true, nil
+	}
+	if others := unavailablePods.Difference(sets.NewString(pod.Name)); others.Len() > 0 {
+		klog.V(4).InfoS("StatefulSet was waiting for unavailable Pods before switchover of leader",
+			"statefulSet", klog.KObj(set), "unavailablePods", others.List(), "leader", klog.KObj(pod))
+		return false, nil
+	}
+
+	timeout := getRoleSwitchoverTimeout(strategy)
+	if ts, ok := pod.Annotations[appsv1beta1.RoleSwitchoverTimestampAnnotation]; ok {
+		if lastTime, err := time.Parse(time.RFC3339, ts); err == nil {
+			if elapsed := roleSwitchoverNow().Sub(lastTime); elapsed >= 0 && elapsed < timeout {
+				klog.V(4).InfoS("StatefulSet was waiting for role of leader to move", "statefulSet", klog.KObj(set), "leader", klog.KObj(pod))
+				durationStore.Push(getStatefulSetKey(set), timeout-elapsed)
+				return false, nil
+			}
+		}
+	}
+
+	attempts := getRoleSwitchoverAttempts(pod)
+	if maxAttempts := getMaxRoleSwitchoverAttempts(strategy); attempts >= maxAttempts {
+		klog.InfoS("StatefulSet updated leader without switchover, for its role did not move", "statefulSet", klog.KObj(set),
+			"leader", klog.KObj(pod), "attempts", attempts)
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "RoleSwitchoverExhausted",
+			"role of leader pod %s did not move after %d switchover attempts, update it without switchover", pod.Name, attempts)
+		return true, nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
+	if roleSwitchoverExecutor.IsRunning(key) {
+		durationStore.Push(getStatefulSetKey(set), roleSwitchoverRequestTimeout)
+		return false, nil
+	}
+	target := pod.DeepCopy()
+	if !roleSwitchoverExecutor.Submit(key, func() { ssc.callRoleSwitchoverInBackground(set, strategy.Switchover, target) }) {
+		klog.V(4).InfoS("StatefulSet was waiting for a worker to call switchover", "statefulSet", klog.KObj(set), "leader", klog.KObj(pod))
+		durationStore.Push(getStatefulSetKey(set), roleSwitchoverRequestTimeout)
+		return false, nil
+	}
+
+	clone := pod.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = map[string]string{}
+	}
+	clone.Annotations[appsv1beta1.RoleSwitchoverTimestampAnnotation] = roleSwitchoverNow().Format(time.RFC3339)
+	clone.Annotations[appsv1beta1.RoleSwitchoverAttemptsAnnotation] = fmt.Sprintf("%s/%d", getPodRevision(pod), attempts+1)
+	if err := ssc.podControl.objectMgr.UpdatePod(clone); err != nil {
+		return false, err
+	}
+	durationStore.Push(getStatefulSetKey(set), timeout)
+	return false, nil
+}
+
+func (ssc *defaultStatefulSetControl) callRoleSwitchoverInBackground(set *appsv1beta1.StatefulSet, action *appsv1beta1.RoleSwitchoverAction, pod *v1.Pod) {
+	if err := callRoleSwitchover(action, pod); err != nil {
+		klog.ErrorS(err, "StatefulSet failed to call switchover for leader", "statefulSet", klog.KObj(set), "leader", klog.KObj(pod))
+		ssc.recorder.Eventf(set, v1.EventTypeWarning, "FailedRoleSwitchover", "failed to call switchover for leader pod %s: %v", pod.Name, err)
+		return
+	}
+	ssc.recorder.Eventf(set, v1.EventTypeNormal, "SuccessfulRoleSwitchover", "called switchover for leader pod %s", pod.Name)
+}
+
+func callRoleSwitchover(action *appsv1beta1.RoleSwitchoverAction, pod *v1.Pod) error {
+	if action.HTTPPost == nil {
+		return fmt.Errorf("no switchover handler")
+	}
+	u, err := formatRoleSwitchoverURL(action.HTTPPost, pod)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	for _, h := range action.HTTPPost.HTTPHeaders {
+		header.Add(h.Name, h.Value)
+	}
+	return httphook.Post(&httphook.Request{
+		URL:     u.String(),
+		Header:  header,
+		Timeout: roleSwitchoverRequestTimeout,
+		PodIP:   pod.Status.PodIP,
+	}, nil)
+}
+
+// formatRoleSwitchoverURL returns the URL of switchover for pod. The host is always the pod IP,
+// so that the switchover can not be sent to any other endpoint.
+func formatRoleSwitchoverURL(action *v1.HTTPGetAction, pod *v1.Pod) (*url.URL, error) {
+	scheme := string(action.Scheme)
+	if scheme == "" {
+		scheme = "http"
+	}
+	host := pod.Status.PodIP
+	if host == "" {
+		return nil, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+	port, err := resolveContainerPort(action.Port, pod)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(action.Path)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = scheme
+	u.Host = net.JoinHostPort(host, strconv.Itoa(port))
+	return u, nil
+}
+
+func resolveContainerPort(port intstr.IntOrString, pod *v1.Pod) (int, error) {
+	if port.Type == intstr.Int {
+		if port.IntValue() <= 0 || port.IntValue() >= 65536 {
+			return 0, fmt.Errorf("invalid port number: %v", port.IntValue())
+		}
+		return port.IntValue(), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %s not found in pod %s", port.StrVal, pod.Name)
+}
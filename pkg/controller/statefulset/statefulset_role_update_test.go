@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+	kruiseinformers "github.com/openkruise/kruise/pkg/client/informers/externalversions"
+)
+
+func newRoleAwareTestPod(name, role string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"role": role}},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			PodIP:      "127.0.0.1",
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func TestSortPodsByRole(t *testing.T) {
+	set := newStatefulSet(4)
+	set.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateStatefulSetStrategy{
+		RoleAwareUpdate: &appsv1beta1.RoleAwareUpdateStrategy{RoleLabelKey: "role"},
+	}
+	replicas := []*v1.Pod{
+		newRoleAwareTestPod("pod-0", "follower"),
+		newRoleAwareTestPod("pod-1", "follower"),
+		newRoleAwareTestPod("pod-2", "leader"),
+		newRoleAwareTestPod("pod-3", "follower"),
+	}
+
+	got := sortPodsByRole(set, replicas, []int{3, 2, 1, 0})
+	if expected := []int{3, 1, 0, 2}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	set.Spec.UpdateStrategy.RollingUpdate.RoleAwareUpdate = nil
+	got = sortPodsByRole(set, replicas, []int{3, 2, 1, 0})
+	if expected := []int{3, 2, 1, 0}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestPrepareLeaderUpdate(t *testing.T) {
+	var called int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/switchover" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&called, 1)
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	now := time.Now()
+	defer func() { roleSwitchoverNow = time.Now }()
+	roleSwitchoverNow = func() time.Time { return now }
+
+	cases := []struct {
+		name            string
+		replicas        int
+		annotations     map[string]string
+		unavailablePods []string
+		noSwitchover    bool
+		expectReady     bool
+		expectCalled    int32
+		expectAttempts  string
+	}{
+		{
+			name:         "no switchover",
+			noSwitchover: true,
+			expectReady:  true,
+		},
+		{
+			name:        "no other pod to take over",
+			replicas:    1,
+			expectReady: true,
+		},
+		{
+			name:            "wait for other unavailable pods",
+			unavailablePods: []string{"pod-0", "pod-1"},
+		},
+		{
+			name:           "call switchover",
+			expectCalled:   1,
+			expectAttempts: "rev-1/1",
+		},
+		{
+			name:        "wait for role to move",
+			annotations: map[string]string{appsv1beta1.RoleSwitchoverTimestampAnnotation: now.Add(-10 * time.Second).Format(time.RFC3339)},
+		},
+		{
+			name: "call switchover again after timeout",
+			annotations: map[string]string{
+				appsv1beta1.RoleSwitchoverTimestampAnnotation: now.Add(-time.Minute).Format(time.RFC3339),
+				appsv1beta1.RoleSwitchoverAttemptsAnnotation:  "rev-1/1",
+			},
+			expectCalled:   1,
+			expectAttempts: "rev-1/2",
+		},
+		{
+			name: "update leader after max attempts",
+			annotations: map[string]string{
+				appsv1beta1.RoleSwitchoverTimestampAnnotation: now.Add(-time.Minute).Format(time.RFC3339),
+				appsv1beta1.RoleSwitchoverAttemptsAnnotation:  "rev-1/3",
+			},
+			expectReady: true,
+		},
+		{
+			name: "attempts in previous revision ignored",
+			annotations: map[string]string{
+				appsv1beta1.RoleSwitchoverTimestampAnnotation: now.Add(-time.Hour).Format(time.RFC3339),
+				appsv1beta1.RoleSwitchoverAttemptsAnnotation:  "rev-0/3",
+			},
+			expectCalled:   1,
+			expectAttempts: "rev-1/1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&called, 0)
+			informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+			kruiseInformerFactory := kruiseinformers.NewSharedInformerFactory(kruisefake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+			om := newFakeObjectManager(informerFactory, kruiseInformerFactory)
+			recorder := record.NewFakeRecorder(10)
+			ssc := &defaultStatefulSetControl{podControl: NewStatefulPodControlFromManager(om, &noopRecorder{}), recorder: recorder}
+
+			replicas := 3
+			if tc.replicas > 0 {
+				replicas = tc.replicas
+			}
+			set := newStatefulSet(replicas)
+			set.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateStatefulSetStrategy{
+				RoleAwareUpdate: &appsv1beta1.RoleAwareUpdateStrategy{
+					RoleLabelKey: "role",
+					Switchover: &appsv1beta1.RoleSwitchoverAction{
+						HTTPPost: &v1.HTTPGetAction{Path: "/switchover", Port: intstr.FromInt32(int32(port))},
+					},
+				},
+			}
+			if tc.noSwitchover {
+				set.Spec.UpdateStrategy.RollingUpdate.RoleAwareUpdate.Switchover = nil
+			}
+			pod := newRoleAwareTestPod("pod-1", "leader")
+			pod.Labels[apps.ControllerRevisionHashLabelKey] = "rev-1"
+			pod.Annotations = tc.annotations
+			_ = om.podsIndexer.Add(pod)
+
+			ready, err := ssc.prepareLeaderUpdate(set, pod, sets.NewString(tc.unavailablePods...))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			roleSwitchoverExecutor.Wait()
+			if got := atomic.LoadInt32(&called); ready != tc.expectReady || got != tc.expectCalled {
+				t.Fatalf("expected ready %v and called %d, got %v and %d", tc.expectReady, tc.expectCalled, ready, got)
+			}
+			if tc.expectCalled > 0 {
+				updated, _ := om.GetPod(pod.Namespace, pod.Name)
+				if updated.Annotations[appsv1beta1.RoleSwitchoverTimestampAnnotation] != now.Format(time.RFC3339) {
+					t.Fatalf("expected switchover timestamp updated, got %v", updated.Annotations)
+				}
+				if updated.Annotations[appsv1beta1.RoleSwitchoverAttemptsAnnotation] != tc.expectAttempts {
+					t.Fatalf("expected switchover attempts %s, got %v", tc.expectAttempts, updated.Annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatRoleSwitchoverURL(t *testing.T) {
+	pod := newRoleAwareTestPod("pod-0", "leader")
+	pod.Status.PodIP = "10.0.0.1"
+	pod.Spec.Containers = []v1.Container{{Name: "main", Ports: []v1.ContainerPort{{Name: "admin", ContainerPort: 8080}}}}
+
+	u, err := formatRoleSwitchoverURL(&v1.HTTPGetAction{Host: "169.254.169.254", Path: "/switchover", Port: intstr.FromString("admin")}, pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "http://10.0.0.1:8080/switchover"; u.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, u.String())
+	}
+
+	pod.Status.PodIP = ""
+	if _, err := formatRoleSwitchoverURL(&v1.HTTPGetAction{Path: "/switchover", Port: intstr.FromInt32(8080)}, pod); err == nil {
+		t.Fatalf("expected error for pod without IP")
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	// snapshotRequeueDuration is the interval to check the VolumeSnapshots not ready, for their events do not trigger StatefulSet reconcile.
+	snapshotRequeueDuration = 5 * time.Second
+	// snapshotFailedRequeueDuration is the interval to check the failed VolumeSnapshots, which may be fixed or deleted by users.
+	snapshotFailedRequeueDuration = time.Minute
+
+	// statefulSetNameLabelKey is the name of StatefulSet the resources are created by.
+	statefulSetNameLabelKey = "apps.kruise.io/statefulset-name"
+	// snapshotRevisionLabelKey is the update revision the VolumeSnapshot is created for.
+	snapshotRevisionLabelKey = "apps.kruise.io/update-revision"
+)
+
+// VolumeSnapshotGVK is the GroupVersionKind of CSI VolumeSnapshot.
+var VolumeSnapshotGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+
+// volumeSnapshotsRecord is the value of VolumeSnapshotsAnnotation on pods.
+type volumeSnapshotsRecord struct {
+	Revision  string   `json:"revision"`
+	Snapshots []string `json:"snapshots"`
+}
+
+func getSnapshotBeforeUpdateStrategy(set *appsv1beta1.StatefulSet) *appsv1beta1.SnapshotBeforeUpdateStrategy {
+	if set.Spec.UpdateStrategy.RollingUpdate == nil || len(set.Spec.VolumeClaimTemplates) == 0 {
+		return nil
+	}
+	return set.Spec.UpdateStrategy.RollingUpdate.SnapshotBeforeUpdate
+}
+
+// getVolumeSnapshotName returns the name of VolumeSnapshot for the claim before updated to the revision.
+// The revision number is increased each time the revision is rolled out again, so that a snapshot taken
+// in an earlier rollout of the same revision is never reused.
+func getVolumeSnapshotName(claimName string, updateRevision *apps.ControllerRevision) string {
+	return fmt.Sprintf("%s-%s-%d", claimName, updateRevision.Name[strings.LastIndex(updateRevision.Name, "-")+1:], updateRevision.Revision)
+}
+
+func getVolumeSnapshotsRecord(pod *v1.Pod) *volumeSnapshotsRecord {
+	value, ok := pod.Annotations[appsv1beta1.VolumeSnapshotsAnnotation]
+	if !ok {
+		return nil
+	}
+	record := &volumeSnapshotsRecord{}
+	if err := json.Unmarshal([]byte(value), record); err != nil {
+		return nil
+	}
+	return record
+}
+
+// snapshotBeforeUpdate returns true if the VolumeSnapshots of all claims of the pod have been ready to use
+// and recorded on the pod for the update revision. It creates the missing VolumeSnapshots and returns an error
+// if any of them failed, which blocks the rollout until it is fixed or deleted.
+func (ssc *defaultStatefulSetControl) snapshotBeforeUpdate(set *appsv1beta1.StatefulSet, pod *v1.Pod, updateRevision *apps.ControllerRevision) (bool, error) {
+	ordinal := getOrdinal(pod)
+	claimNames := make([]string, 0, len(set.Spec.VolumeClaimTemplates))
+	names := make([]string, 0, len(set.Spec.VolumeClaimTemplates))
+	for i := range set.Spec.VolumeClaimTemplates {
+		claimName := getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[i], ordinal)
+		claimNames = append(claimNames, claimName)
+		names = append(names, getVolumeSnapshotName(claimName, updateRevision))
+	}
+	if record := getVolumeSnapshotsRecord(pod); record != nil && record.Revision == updateRevision.Name && reflect.DeepEqual(record.Snapshots, names) {
+		return true, nil
+	}
+	if sigsruntimeClient == nil {
+		return false, fmt.Errorf("no client to create volume snapshots")
+	}
+	strategy := getSnapshotBeforeUpdateStrategy(set)
+
+	allReady := true
+	for i, name := range names {
+		claimName := claimNames[i]
+
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+		err := sigsruntimeClient.Get(context.TODO(), client.ObjectKey{Namespace: set.Namespace, Name: name}, snapshot)
+		if errors.IsNotFound(err) {
+			if err = ssc.createVolumeSnapshot(set, strategy, claimName, name, updateRevision.Name); err != nil && !errors.IsAlreadyExists(err) {
+				ssc.recorder.Eventf(set, v1.EventTypeWarning, "FailedCreateVolumeSnapshot", "failed to create volume snapshot %s for pod %s: %v", name, pod.Name, err)
+				return false, err
+			}
+			ssc.recorder.Eventf(set, v1.EventTypeNormal, "SuccessfulCreateVolumeSnapshot", "create volume snapshot %s for pod %s", name, pod.Name)
+			allReady = false
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		if msg, found, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); found && msg != "" {
+			return false, fmt.Errorf("volume snapshot %s failed: %s", name, msg)
+		}
+		if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); !ready {
+			allReady = false
+		}
+	}
+	if !allReady {
+		klog.V(4).InfoS("StatefulSet was waiting for volume snapshots to be ready", "statefulSet", klog.KObj(set), "pod", klog.KObj(pod), "snapshots", names)
+		durationStore.Push(getStatefulSetKey(set), snapshotRequeueDuration)
+		return false, nil
+	}
+
+	value, _ := json.Marshal(volumeSnapshotsRecord{Revision: updateRevision.Name, Snapshots: names})
+	clone := pod.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = map[string]string{}
+	}
+	clone.Annotations[appsv1beta1.VolumeSnapshotsAnnotation] = string(value)
+	// the pod will be updated in the next reconcile triggered by this update
+	return false, ssc.podControl.objectMgr.UpdatePod(clone)
+}
+
+func (ssc *defaultStatefulSetControl) createVolumeSnapshot(set *appsv1beta1.StatefulSet, strategy *appsv1beta1.SnapshotBeforeUpdateStrategy,
+	claimName, name, updateRevision string) error {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace(set.Namespace)
+	snapshot.SetName(name)
+	// no owner reference, for the snapshots are backups which should not be deleted with the StatefulSet
+	snapshot.SetLabels(map[string]string{
+		statefulSetNameLabelKey:  set.Name,
+		snapshotRevisionLabelKey: updateRevision,
+	})
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{"persistentVolumeClaimName": claimName},
+	}
+	if strategy.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *strategy.VolumeSnapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+	klog.V(2).InfoS("StatefulSet creating volume snapshot", "statefulSet", klog.KObj(set), "pvc", claimName, "volumeSnapshot", name)
+	return sigsruntimeClient.Create(context.TODO(), snapshot)
+}
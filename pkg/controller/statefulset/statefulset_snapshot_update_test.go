@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigsfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	kruisefake "github.com/openkruise/kruise/pkg/client/clientset/versioned/fake"
+	kruiseinformers "github.com/openkruise/kruise/pkg/client/informers/externalversions"
+)
+
+func newTestVolumeSnapshot(name string, status map[string]interface{}) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace("default")
+	snapshot.SetName(name)
+	if status != nil {
+		snapshot.Object["status"] = status
+	}
+	return snapshot
+}
+
+func TestSnapshotBeforeUpdate(t *testing.T) {
+	updateRevision := &apps.ControllerRevision{ObjectMeta: metav1.ObjectMeta{Name: "foo-7c8d9f"}, Revision: 3}
+
+	cases := []struct {
+		name            string
+		snapshotStatus  map[string]interface{}
+		noSnapshot      bool
+		recorded        bool
+		recordedEarlier bool
+		expectReady     bool
+		expectErr       bool
+		expectAnnotated bool
+	}{
+		{
+			name:       "create snapshots",
+			noSnapshot: true,
+		},
+		{
+			name:           "wait for snapshots ready",
+			snapshotStatus: map[string]interface{}{"readyToUse": false},
+		},
+		{
+			name:           "snapshots failed",
+			snapshotStatus: map[string]interface{}{"error": map[string]interface{}{"message": "csi error"}},
+			expectErr:      true,
+		},
+		{
+			name:            "record ready snapshots",
+			snapshotStatus:  map[string]interface{}{"readyToUse": true},
+			expectAnnotated: true,
+		},
+		{
+			name:        "snapshots recorded",
+			recorded:    true,
+			expectReady: true,
+		},
+		{
+			name:            "snapshots recorded in an earlier rollout of the revision",
+			recordedEarlier: true,
+			noSnapshot:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := newStatefulSet(3)
+			set.Spec.UpdateStrategy.RollingUpdate = &appsv1beta1.RollingUpdateStatefulSetStrategy{
+				SnapshotBeforeUpdate: &appsv1beta1.SnapshotBeforeUpdateStrategy{VolumeSnapshotClassName: ptr.To("csi-snapclass")},
+			}
+			pod := newStatefulSetPod(set, 1)
+			if tc.recorded || tc.recordedEarlier {
+				revision := updateRevision.DeepCopy()
+				if tc.recordedEarlier {
+					revision.Revision = 1
+				}
+				var names []string
+				for i := range set.Spec.VolumeClaimTemplates {
+					names = append(names, getVolumeSnapshotName(getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[i], 1), revision))
+				}
+				value, _ := json.Marshal(volumeSnapshotsRecord{Revision: revision.Name, Snapshots: names})
+				pod.Annotations = map[string]string{appsv1beta1.VolumeSnapshotsAnnotation: string(value)}
+			}
+
+			var objs []client.Object
+			if !tc.noSnapshot {
+				for i := range set.Spec.VolumeClaimTemplates {
+					claimName := getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[i], 1)
+					objs = append(objs, newTestVolumeSnapshot(getVolumeSnapshotName(claimName, updateRevision), tc.snapshotStatus))
+				}
+			}
+			defer func(c client.Client) { sigsruntimeClient = c }(sigsruntimeClient)
+			sigsruntimeClient = sigsfake.NewClientBuilder().WithObjects(objs...).Build()
+
+			informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+			kruiseInformerFactory := kruiseinformers.NewSharedInformerFactory(kruisefake.NewSimpleClientset(), controller.NoResyncPeriodFunc())
+			om := newFakeObjectManager(informerFactory, kruiseInformerFactory)
+			ssc := &defaultStatefulSetControl{podControl: NewStatefulPodControlFromManager(om, &noopRecorder{}), recorder: record.NewFakeRecorder(10)}
+			_ = om.podsIndexer.Add(pod)
+
+			ready, err := ssc.snapshotBeforeUpdate(set, pod, updateRevision)
+			if (err != nil) != tc.expectErr || ready != tc.expectReady {
+				t.Fatalf("expected ready %v and error %v, got %v and %v", tc.expectReady, tc.expectErr, ready, err)
+			}
+
+			for i := range set.Spec.VolumeClaimTemplates {
+				claimName := getPersistentVolumeClaimName(set, &set.Spec.VolumeClaimTemplates[i], 1)
+				snapshot := newTestVolumeSnapshot(getVolumeSnapshotName(claimName, updateRevision), nil)
+				err := sigsruntimeClient.Get(context.TODO(), client.ObjectKeyFromObject(snapshot), snapshot)
+				if !tc.noSnapshot {
+					continue
+				} else if err != nil {
+					t.Fatalf("failed to get volume snapshot: %v", err)
+				}
+				if source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName"); source != claimName {
+					t.Fatalf("expected snapshot source %s, got %s", claimName, source)
+				}
+			}
+
+			updated, _ := om.GetPod(pod.Namespace, pod.Name)
+			annotated := updated.Annotations[appsv1beta1.VolumeSnapshotsAnnotation] != pod.Annotations[appsv1beta1.VolumeSnapshotsAnnotation]
+			if record := getVolumeSnapshotsRecord(updated); tc.expectAnnotated != annotated {
+				t.Fatalf("expected annotated %v, got %v", tc.expectAnnotated, updated.Annotations)
+			} else if tc.expectAnnotated && (record.Revision != updateRevision.Name || len(record.Snapshots) != len(set.Spec.VolumeClaimTemplates)) {
+				t.Fatalf("unexpected record %+v", record)
+			}
+		})
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	workloadmetrics "github.com/openkruise/kruise/pkg/util/metrics/workload"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
 	"github.com/openkruise/kruise/pkg/util/requeueduration"
 )
@@ -173,14 +174,18 @@ type ReconcileUnitedDeployment struct {
 
 // Reconcile reads that state of the cluster for a UnitedDeployment object and makes changes based on the state read
 // and what is in the UnitedDeployment.Spec
-func (r *ReconcileUnitedDeployment) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileUnitedDeployment) Reconcile(_ context.Context, request reconcile.Request) (res reconcile.Result, retErr error) {
 	klog.V(4).InfoS("Reconcile UnitedDeployment", "unitedDeployment", request)
+	defer func() {
+		workloadmetrics.RecordReconcileError("uniteddeployment", retErr)
+	}()
 	// Fetch the UnitedDeployment instance
 	instance := &appsv1alpha1.UnitedDeployment{}
 	now := time.Now()
 	err := r.Get(context.TODO(), request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			workloadmetrics.DeleteStatus(workloadmetrics.KindUnitedDeployment, request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -578,6 +583,17 @@ func (r *ReconcileUnitedDeployment) classifySubsetBySubsetName(subsets []*Subset
 }
 
 func (r *ReconcileUnitedDeployment) updateStatus(instance *appsv1alpha1.UnitedDeployment, newStatus, oldStatus *appsv1alpha1.UnitedDeploymentStatus) error {
+	var updateRevision string
+	if newStatus.UpdateStatus != nil {
+		updateRevision = newStatus.UpdateStatus.UpdatedRevision
+	}
+	workloadmetrics.RecordStatus(workloadmetrics.KindUnitedDeployment, instance.Namespace, instance.Name, workloadmetrics.Status{
+		Desired:         getDesiredReplicas(instance, newStatus),
+		Updated:         newStatus.UpdatedReplicas,
+		Ready:           newStatus.ReadyReplicas,
+		CurrentRevision: newStatus.CurrentRevision,
+		UpdateRevision:  updateRevision,
+	})
 	newObj, err := r.updateUnitedDeployment(instance, oldStatus, newStatus)
 	if err == nil && newObj != nil {
 		ResourceVersionExpectation.Expect(newObj)
@@ -586,6 +602,19 @@ func (r *ReconcileUnitedDeployment) updateStatus(instance *appsv1alpha1.UnitedDe
 	return err
 }
 
+// getDesiredReplicas returns the replicas of UnitedDeployment, which is the sum of the replicas
+// allocated to subsets if spec.replicas is not set.
+func getDesiredReplicas(instance *appsv1alpha1.UnitedDeployment, newStatus *appsv1alpha1.UnitedDeploymentStatus) int32 {
+	if instance.Spec.Replicas != nil {
+		return *instance.Spec.Replicas
+	}
+	var replicas int32
+	for _, subsetReplicas := range newStatus.SubsetReplicas {
+		replicas += subsetReplicas
+	}
+	return replicas
+}
+
 var extraStatusSelector = fmt.Sprintf(",%s=false", appsv1alpha1.ReservedPodLabelKey)
 
 func (r *ReconcileUnitedDeployment) calculateStatus(newStatus *appsv1alpha1.UnitedDeploymentStatus, existingSubsets map[string]*Subset,
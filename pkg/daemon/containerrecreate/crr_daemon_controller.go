@@ -47,6 +47,7 @@ import (
 	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
 	"github.com/openkruise/kruise/pkg/daemon/kuberuntime"
 	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
 	"github.com/openkruise/kruise/pkg/util"
 	"github.com/openkruise/kruise/pkg/util/expectations"
 )
@@ -354,7 +355,9 @@ func (c *Controller) manage(crr *appsv1alpha1.ContainerRecreateRequest) error {
 		}
 
 		msg := fmt.Sprintf("Stopping container %s by ContainerRecreateRequest %s", state.Name, crr.Name)
+		killStart := time.Now()
 		err := runtimeManager.KillContainer(pod, kubeContainerStatus.ID, state.Name, msg, nil)
+		observeContainerKillDuration(killStart, err)
 		if err != nil {
 			klog.ErrorS(err, "Failed to kill container in Pod for CRR", "containerName", state.Name, "podNamespace", pod.Namespace, "podName", pod.Name, "crrNamespace", crr.Namespace, "crrName", crr.Name)
 			state.Phase = appsv1alpha1.ContainerRecreateRequestFailed
@@ -404,6 +407,7 @@ func (c *Controller) updateCRRPhase(crr *appsv1alpha1.ContainerRecreateRequest,
 	defer func() {
 		if crr.ResourceVersion != oldRev {
 			resourceVersionExpectation.Expect(crr)
+			CRRPhaseTransitions.WithLabelValues(daemonutil.MetricsNodeName(), string(phase)).Inc()
 		}
 	}()
 	return c.runtimeClient.Status().Update(context.TODO(), crr)
@@ -419,6 +423,7 @@ func (c *Controller) completeCRRStatus(crr *appsv1alpha1.ContainerRecreateReques
 	defer func() {
 		if crr.ResourceVersion != oldRev {
 			resourceVersionExpectation.Expect(crr)
+			CRRPhaseTransitions.WithLabelValues(daemonutil.MetricsNodeName(), string(appsv1alpha1.ContainerRecreateRequestCompleted)).Inc()
 		}
 	}()
 	return c.runtimeClient.Status().Update(context.TODO(), crr)
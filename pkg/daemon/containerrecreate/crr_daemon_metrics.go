@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrecreate
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+)
+
+var (
+	CRRPhaseTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kruise_daemon_container_recreate_phase_transitions_total",
+			Help: "Total number of ContainerRecreateRequest phase transitions made by kruise-daemon",
+		}, []string{"node", "phase"},
+	)
+	ContainerKillDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kruise_daemon_container_kill_duration_seconds",
+			Help:    "Duration of killing containers for ContainerRecreateRequest, by result Succeeded or Failed",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+		}, []string{"node", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(CRRPhaseTransitions, ContainerKillDuration)
+}
+
+func observeContainerKillDuration(start time.Time, err error) {
+	result := "Succeeded"
+	if err != nil {
+		result = "Failed"
+	}
+	ContainerKillDuration.WithLabelValues(daemonutil.MetricsNodeName(), result).Observe(time.Since(start).Seconds())
+}
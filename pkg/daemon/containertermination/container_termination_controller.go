@@ -0,0 +1,328 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containertermination
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kubeletcontainer "k8s.io/kubernetes/pkg/kubelet/container"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/client"
+	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
+	"github.com/openkruise/kruise/pkg/daemon/kuberuntime"
+	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
+)
+
+var (
+	// TODO: make it a configurable flag
+	workers = 5
+
+	// pollInterval is the interval to check whether the containers with higher priority have exited.
+	pollInterval = time.Second
+
+	nowFunc = time.Now
+)
+
+type Controller struct {
+	queue          workqueue.RateLimitingInterface
+	podLister      corelisters.PodLister
+	runtimeFactory daemonruntime.Factory
+	eventRecorder  record.EventRecorder
+}
+
+// NewController returns the Controller for container termination priority
+func NewController(opts daemonoptions.Options) (*Controller, error) {
+	if opts.PodInformer == nil {
+		return nil, fmt.Errorf("containertermination Controller can not run without pod informer")
+	}
+
+	queue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemExponentialFailureRateLimiter(500*time.Millisecond, 10*time.Second),
+		"container_termination_priority",
+	)
+
+	opts.PodInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod, ok := obj.(*v1.Pod)
+			if ok && eventFilter(pod) {
+				enqueue(queue, pod)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			newPod := newObj.(*v1.Pod)
+			if eventFilter(newPod) {
+				enqueue(queue, newPod)
+			}
+		},
+	})
+
+	genericClient := client.GetGenericClientWithName("kruise-daemon-containertermination")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: genericClient.KubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(opts.Scheme, v1.EventSource{Component: "kruise-daemon-containertermination", Host: opts.NodeName})
+
+	return &Controller{
+		queue:          queue,
+		podLister:      corelisters.NewPodLister(opts.PodInformer.GetIndexer()),
+		runtimeFactory: opts.RuntimeFactory,
+		eventRecorder:  recorder,
+	}, nil
+}
+
+func eventFilter(pod *v1.Pod) bool {
+	if pod.DeletionTimestamp == nil || len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	_, ok := pod.Annotations[appspub.ContainerTerminationPriorityKey]
+	return ok
+}
+
+func enqueue(q workqueue.Interface, pod *v1.Pod) {
+	q.Add(pod.Namespace + "/" + pod.Name)
+}
+
+func (c *Controller) Run(stop <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting containertermination Controller")
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stop)
+	}
+
+	klog.Info("Started containertermination Controller successfully")
+	<-stop
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeueAfter, err := c.sync(key.(string))
+	if err != nil {
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return true
+}
+
+func (c *Controller) sync(key string) (requeueAfter time.Duration, retErr error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.InfoS("Invalid key", "key", key)
+		return 0, nil
+	}
+
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		klog.ErrorS(err, "Failed to get Pod from lister", "namespace", namespace, "name", name)
+		return 0, err
+	} else if !eventFilter(pod) {
+		return 0, nil
+	}
+
+	priorities, err := appspub.GetContainerTerminationPriorities(pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get container termination priorities", "namespace", namespace, "name", name)
+		return 0, nil
+	}
+	stepTimeoutSeconds, err := appspub.GetContainerTerminationStepTimeoutSeconds(pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get container termination step timeout", "namespace", namespace, "name", name)
+		return 0, nil
+	}
+
+	kubeRuntime, err := c.getRuntimeForPod(pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get runtime for Pod", "namespace", namespace, "name", name)
+		return 0, nil
+	} else if kubeRuntime == nil {
+		return 0, nil
+	}
+
+	klog.V(3).InfoS("Start syncing", "namespace", namespace, "name", name)
+	defer func() {
+		if retErr != nil {
+			klog.ErrorS(retErr, "Failed to sync", "namespace", namespace, "name", name)
+		} else {
+			klog.V(3).InfoS("Finished syncing", "namespace", namespace, "name", name, "requeueAfter", requeueAfter)
+		}
+	}()
+
+	kubePodStatus, err := kubeRuntime.GetPodStatus(context.TODO(), pod.UID, name, namespace)
+	if err != nil {
+		return 0, fmt.Errorf("failed to GetPodStatus: %v", err)
+	}
+	runningContainers := make(map[string]kubeletcontainer.ContainerID)
+	for _, status := range kubePodStatus.ContainerStatuses {
+		if status.State == kubeletcontainer.ContainerStateRunning {
+			runningContainers[status.Name] = status.ID
+		}
+	}
+	running := sets.KeySet(runningContainers)
+
+	now := nowFunc()
+	toStop, requeueAfter := planTermination(pod, running, priorities, stepTimeoutSeconds, now)
+	if len(toStop) == 0 {
+		return requeueAfter, nil
+	}
+
+	var gracePeriod int64
+	if remaining := pod.DeletionTimestamp.Time.Sub(now); remaining > 0 {
+		gracePeriod = int64(remaining.Seconds())
+	}
+	c.eventRecorder.Eventf(pod, v1.EventTypeNormal, "StoppingContainers",
+		"Stopping containers %s after containers with higher termination priority", strings.Join(toStop, ","))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, containerName := range toStop {
+		wg.Add(1)
+		go func(containerID kubeletcontainer.ContainerID) {
+			defer wg.Done()
+			if err := kubeRuntime.StopContainer(containerID, gracePeriod); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(runningContainers[containerName])
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return 0, utilerrors.NewAggregate(errs)
+	}
+	// check the next priority as soon as the current one has been stopped
+	return pollInterval, nil
+}
+
+// planTermination returns the running containers that should be stopped now, and the duration to check again.
+// Containers are grouped by priority. The group with the highest priority is stopped by kubelet, and each of
+// the other groups is stopped once all groups with higher priorities have exited or its step timeout is exceeded.
+// The i-th group times out at stepTimeoutSeconds*i after the pod started terminating.
+func planTermination(pod *v1.Pod, running sets.Set[string], priorities map[string]int, stepTimeoutSeconds int64, now time.Time) (toStop []string, requeueAfter time.Duration) {
+	groups := make(map[int][]string)
+	for i := range pod.Spec.Containers {
+		name := pod.Spec.Containers[i].Name
+		groups[priorities[name]] = append(groups[priorities[name]], name)
+	}
+	orderedPriorities := make([]int, 0, len(groups))
+	for priority := range groups {
+		orderedPriorities = append(orderedPriorities, priority)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(orderedPriorities)))
+
+	var startTime time.Time
+	if pod.DeletionTimestamp != nil {
+		startTime = pod.DeletionTimestamp.Time
+		if pod.DeletionGracePeriodSeconds != nil {
+			startTime = startTime.Add(-time.Duration(*pod.DeletionGracePeriodSeconds) * time.Second)
+		}
+	}
+
+	var higherRunning bool
+	for i, priority := range orderedPriorities {
+		var groupRunning []string
+		for _, name := range groups[priority] {
+			if running.Has(name) {
+				groupRunning = append(groupRunning, name)
+			}
+		}
+		if i > 0 && higherRunning {
+			deadline := startTime.Add(time.Duration(stepTimeoutSeconds*int64(i)) * time.Second)
+			if now.Before(deadline) {
+				if len(groupRunning) == 0 {
+					continue
+				}
+				requeueAfter = deadline.Sub(now)
+				if requeueAfter > pollInterval {
+					requeueAfter = pollInterval
+				}
+				return toStop, requeueAfter
+			}
+		}
+		if i > 0 {
+			toStop = append(toStop, groupRunning...)
+		}
+		if len(groupRunning) > 0 {
+			higherRunning = true
+		}
+	}
+	return toStop, 0
+}
+
+func (c *Controller) getRuntimeForPod(pod *v1.Pod) (kuberuntime.Runtime, error) {
+	var existingID string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if len(cs.ContainerID) > 0 {
+			existingID = cs.ContainerID
+			break
+		}
+	}
+	if existingID == "" {
+		return nil, nil
+	}
+
+	containerID := kubeletcontainer.ContainerID{}
+	if err := containerID.ParseString(existingID); err != nil {
+		return nil, fmt.Errorf("failed to parse containerID %s: %v", existingID, err)
+	} else if containerID.Type == "" {
+		return nil, fmt.Errorf("no runtime name in containerID %s", existingID)
+	}
+
+	runtimeName := containerID.Type
+	runtimeService := c.runtimeFactory.GetRuntimeServiceByName(runtimeName)
+	if runtimeService == nil {
+		return nil, fmt.Errorf("not found runtime service for %s in daemon", runtimeName)
+	}
+
+	return kuberuntime.NewGenericRuntime(runtimeName, runtimeService, c.eventRecorder, &http.Client{}), nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containertermination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	criapi "k8s.io/cri-api/pkg/apis"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	critesting "k8s.io/cri-api/pkg/apis/testing"
+	kubelettypes "k8s.io/kubelet/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+)
+
+type fakeRuntimeFactory struct {
+	runtimeService criapi.RuntimeService
+}
+
+func (f *fakeRuntimeFactory) GetImageService() runtimeimage.ImageService { return nil }
+func (f *fakeRuntimeFactory) GetRuntimeService() criapi.RuntimeService   { return f.runtimeService }
+func (f *fakeRuntimeFactory) GetRuntimeServiceByName(string) criapi.RuntimeService {
+	return f.runtimeService
+}
+
+func newTerminatingPod(now time.Time, elapsed time.Duration, containers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:                  "default",
+			Name:                       "pod-0",
+			UID:                        "pod-uid",
+			DeletionTimestamp:          &metav1.Time{Time: now.Add(-elapsed).Add(120 * time.Second)},
+			DeletionGracePeriodSeconds: ptr.To[int64](120),
+			Annotations: map[string]string{
+				appspub.ContainerTerminationPriorityKey:    `{"app":10,"log":10,"proxy":-10}`,
+				appspub.ContainerTerminationStepTimeoutKey: "20",
+			},
+		},
+	}
+	for _, name := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: name, ContainerID: "containerd://" + name})
+	}
+	return pod
+}
+
+func TestPlanTermination(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	priorities := map[string]int{"app": 10, "log": 10, "proxy": -10}
+	containers := []string{"app", "log", "sidecar", "proxy"}
+
+	cases := []struct {
+		name            string
+		elapsed         time.Duration
+		running         []string
+		expectedStop    []string
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "higher priority still running",
+			elapsed:         5 * time.Second,
+			running:         []string{"app", "log", "sidecar", "proxy"},
+			expectedRequeue: pollInterval,
+		},
+		{
+			name:            "higher priority exited",
+			elapsed:         5 * time.Second,
+			running:         []string{"sidecar", "proxy"},
+			expectedStop:    []string{"sidecar"},
+			expectedRequeue: pollInterval,
+		},
+		{
+			name:            "step timed out",
+			elapsed:         25 * time.Second,
+			running:         []string{"app", "sidecar", "proxy"},
+			expectedStop:    []string{"sidecar"},
+			expectedRequeue: pollInterval,
+		},
+		{
+			name:            "step timed out and next step waiting",
+			elapsed:         39500 * time.Millisecond,
+			running:         []string{"app", "sidecar", "proxy"},
+			expectedStop:    []string{"sidecar"},
+			expectedRequeue: 500 * time.Millisecond,
+		},
+		{
+			name:         "middle priority has no running containers",
+			elapsed:      5 * time.Second,
+			running:      []string{"proxy"},
+			expectedStop: []string{"proxy"},
+		},
+		{
+			name:    "all exited",
+			elapsed: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := newTerminatingPod(now, tc.elapsed, containers...)
+			toStop, requeueAfter := planTermination(pod, sets.New(tc.running...), priorities, 20, now)
+			if !reflect.DeepEqual(toStop, tc.expectedStop) {
+				t.Fatalf("expected stop %v, got %v", tc.expectedStop, toStop)
+			}
+			if requeueAfter != tc.expectedRequeue {
+				t.Fatalf("expected requeue after %v, got %v", tc.expectedRequeue, requeueAfter)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	pod := newTerminatingPod(now, 5*time.Second, "app", "proxy")
+	fakeRuntime := critesting.NewFakeRuntimeService()
+	var fakeContainers []*critesting.FakeContainer
+	for _, name := range []string{"app", "proxy"} {
+		state := runtimeapi.ContainerState_CONTAINER_RUNNING
+		if name == "app" {
+			state = runtimeapi.ContainerState_CONTAINER_EXITED
+		}
+		fakeContainers = append(fakeContainers, &critesting.FakeContainer{ContainerStatus: runtimeapi.ContainerStatus{
+			Id:    name,
+			State: state,
+			Image: &runtimeapi.ImageSpec{Image: "nginx"},
+			Labels: map[string]string{
+				kubelettypes.KubernetesPodUIDLabel:        string(pod.UID),
+				kubelettypes.KubernetesContainerNameLabel: name,
+			},
+		}})
+	}
+	fakeRuntime.SetFakeContainers(fakeContainers)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(pod)
+	c := &Controller{
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		podLister:      corelisters.NewPodLister(indexer),
+		runtimeFactory: &fakeRuntimeFactory{runtimeService: fakeRuntime},
+		eventRecorder:  record.NewFakeRecorder(10),
+	}
+
+	requeueAfter, err := c.sync("default/pod-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != pollInterval {
+		t.Fatalf("expected requeue after %v, got %v", pollInterval, requeueAfter)
+	}
+	if state := fakeRuntime.Containers["proxy"].State; state != runtimeapi.ContainerState_CONTAINER_EXITED {
+		t.Fatalf("expected proxy stopped, got %v", state)
+	}
+
+	requeueAfter, err = c.sync("default/pod-0")
+	if err != nil || requeueAfter != 0 {
+		t.Fatalf("expected nothing to do, got %v %v", requeueAfter, err)
+	}
+}
@@ -15,6 +15,7 @@ package imageruntime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -155,6 +156,9 @@ func (c *commonCRIImageService) pullImageV1(ctx context.Context, imageName, tag
 			err = utilerrors.NewAggregate(pullErrs)
 		}
 	} else {
+		if errors.Is(err, secret.ErrKeyring) {
+			daemonutil.RecordSecretLookupFailure(daemonutil.SecretLookupSourceKeyring)
+		}
 		klog.ErrorS(err, "Failed to convert to auth info for registry")
 	}
 
@@ -275,6 +279,8 @@ func (c *commonCRIImageService) pullImageV1alpha2(ctx context.Context, imageName
 			if len(pullErrs) > 0 {
 				err = utilerrors.NewAggregate(pullErrs)
 			}
+		} else if errors.Is(err, secret.ErrKeyring) {
+			daemonutil.RecordSecretLookupFailure(daemonutil.SecretLookupSourceKeyring)
 		}
 	}
 
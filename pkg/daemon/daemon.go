@@ -41,6 +41,7 @@ import (
 	"github.com/openkruise/kruise/pkg/client"
 	"github.com/openkruise/kruise/pkg/daemon/containermeta"
 	"github.com/openkruise/kruise/pkg/daemon/containerrecreate"
+	"github.com/openkruise/kruise/pkg/daemon/containertermination"
 	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
 	"github.com/openkruise/kruise/pkg/daemon/imagepuller"
 	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
@@ -169,6 +170,14 @@ func NewDaemon(cfg *rest.Config, bindAddress string, MaxWorkersForPullImages int
 		runnables = append(runnables, containerMetaController)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.DaemonWatchingPod) && utilfeature.DefaultFeatureGate.Enabled(features.ContainerTerminationPriority) {
+		containerTerminationController, err := containertermination.NewController(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to new containertermination controller: %v", err)
+		}
+		runnables = append(runnables, containerTerminationController)
+	}
+
 	return &daemon{
 		runtimeFactory: runtimeFactory,
 		podInformer:    podInformer,
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	ImagePullDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "kruise_daemon_image_pull_duration_seconds",
+			Help: "Duration of pulling an image tag including all retries, by result Succeeded or Failed",
+			// 1s ~ 4096s
+			Buckets: prometheus.ExponentialBuckets(1, 2, 13),
+		}, []string{"node", "result"},
+	)
+	ImagePullBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kruise_daemon_image_pull_bytes_total",
+			Help: "Total size of images pulled",
+		}, []string{"node"},
+	)
+	ImagePullFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kruise_daemon_image_pull_failures_total",
+			Help: "Total number of failed attempts to pull images",
+		}, []string{"node"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(ImagePullDuration, ImagePullBytes, ImagePullFailures)
+}
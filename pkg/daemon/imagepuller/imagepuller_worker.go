@@ -368,6 +368,9 @@ func (w *pullWorker) Run() {
 		} else {
 			klog.InfoS("Successfully pull image", "name", w.name, "tag", tag, "cost", cost)
 		}
+		if newStatus.Phase == appsv1beta1.ImagePhaseFailed || newStatus.Phase == appsv1beta1.ImagePhaseSucceeded {
+			ImagePullDuration.WithLabelValues(daemonutil.MetricsNodeName(), string(newStatus.Phase)).Observe(cost.Seconds())
+		}
 		if w.IsActive() {
 			w.statusUpdater.UpdateStatus(newStatus)
 		}
@@ -411,6 +414,7 @@ func (w *pullWorker) Run() {
 		lastError = w.doPullImage(pullContext, newStatus, w.tagSpec.ImagePullPolicy)
 		if lastError != nil {
 			cancel()
+			ImagePullFailures.WithLabelValues(daemonutil.MetricsNodeName()).Inc()
 			if !w.IsActive() {
 				break
 			}
@@ -539,6 +543,9 @@ func (w *pullWorker) doPullImage(ctx context.Context, newStatus *appsv1beta1.Ima
 			klog.V(5).InfoS("Pulling image", "name", w.name, "tag", tag, "cost", time.Since(startTime.Time), "progress", progress, "detail", progressInfo)
 			if progressStatus.Finish {
 				if progressStatus.Err == nil {
+					if info, err := w.getImageInfo(ctx); err == nil {
+						ImagePullBytes.WithLabelValues(daemonutil.MetricsNodeName()).Add(float64(info.Size))
+					}
 					return nil
 				}
 				return fmt.Errorf("pulling image %s:%s error %v", w.name, tag, progressStatus.Err)
@@ -183,6 +183,20 @@ func (m *genericRuntimeManager) KillContainer(pod *v1.Pod, containerID kubeletco
 	return err
 }
 
+// StopContainer stops a container with the grace period, without running its pre-stop lifecycle hook.
+// It is used when the pre-stop hook has been or is being run by kubelet.
+func (m *genericRuntimeManager) StopContainer(containerID kubeletcontainer.ContainerID, gracePeriod int64) error {
+	if gracePeriod < minimumGracePeriodInSeconds {
+		gracePeriod = minimumGracePeriodInSeconds
+	}
+	klog.V(2).InfoS("Stopping container with grace period", "containerID", containerID.String(), "gracePeriod", gracePeriod)
+	err := m.runtimeService.StopContainer(context.TODO(), containerID.ID, gracePeriod)
+	if err != nil {
+		klog.ErrorS(err, "Container stopping failed with grace period", "containerID", containerID.String(), "gracePeriod", gracePeriod)
+	}
+	return err
+}
+
 // restoreSpecsFromContainerLabels restores all information needed for killing a container. In some
 // case we may not have pod and container spec when killing a container, e.g. pod is deleted during
 // kubelet restart.
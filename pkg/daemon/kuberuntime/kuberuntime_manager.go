@@ -41,6 +41,8 @@ type Runtime interface {
 	// * Run the pre-stop lifecycle hooks (if applicable).
 	// * Stop the container.
 	KillContainer(pod *v1.Pod, containerID kubeletcontainer.ContainerID, containerName string, message string, gracePeriodOverride *int64) error
+	// StopContainer stops a container with the grace period, without running its pre-stop lifecycle hook.
+	StopContainer(containerID kubeletcontainer.ContainerID, gracePeriod int64) error
 }
 
 func NewGenericRuntime(
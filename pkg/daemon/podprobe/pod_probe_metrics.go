@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podprobe
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+)
+
+var (
+	PodProbeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kruise_daemon_pod_probe_duration_seconds",
+			Help:    "Duration of pod probes, by probe type exec, http or tcp",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{"node", "type"},
+	)
+	PodProbeResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kruise_daemon_pod_probe_results_total",
+			Help: "Total number of pod probe results, by probe type and result Succeeded or Failed",
+		}, []string{"node", "type", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(PodProbeDuration, PodProbeResults)
+}
+
+func getProbeType(p *appsv1alpha1.ContainerProbeSpec) string {
+	switch {
+	case p.Exec != nil:
+		return "exec"
+	case p.HTTPGet != nil:
+		return "http"
+	case p.TCPSocket != nil:
+		return "tcp"
+	}
+	return "unknown"
+}
+
+func recordProbe(p *appsv1alpha1.ContainerProbeSpec, start time.Time, result appsv1alpha1.ProbeState) {
+	probeType := getProbeType(p)
+	node := daemonutil.MetricsNodeName()
+	PodProbeDuration.WithLabelValues(node, probeType).Observe(time.Since(start).Seconds())
+	PodProbeResults.WithLabelValues(node, probeType, string(result)).Inc()
+}
@@ -64,13 +64,16 @@ func newProber(runtimeService criapi.RuntimeService) *prober {
 
 // probe probes the container.
 func (pb *prober) probe(p *appsv1alpha1.ContainerProbeSpec, probeKey probeKey, containerRuntimeStatus *runtimeapi.ContainerStatus, containerID string) (appsv1alpha1.ProbeState, string, error) {
+	start := time.Now()
 	result, msg, err := pb.runProbe(p, probeKey, containerRuntimeStatus, containerID)
 	if bytes.Count([]byte(msg), nil)-1 > maxProbeMessageLength {
 		msg = msg[:maxProbeMessageLength]
 	}
 	if err != nil || (result != probe.Success && result != probe.Warning) {
+		recordProbe(p, start, appsv1alpha1.ProbeFailed)
 		return appsv1alpha1.ProbeFailed, msg, err
 	}
+	recordProbe(p, start, appsv1alpha1.ProbeSucceeded)
 	return appsv1alpha1.ProbeSucceeded, msg, nil
 }
 
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// SecretLookupSourceSecret means failed to get pull secrets from apiserver.
+	SecretLookupSourceSecret = "secret"
+	// SecretLookupSourceKeyring means failed to look up credentials in docker keyring,
+	// which contains pull secrets and credential provider plugins.
+	SecretLookupSourceKeyring = "keyring"
+	// SecretLookupSourceCredentialPlugin means failed to register credential provider plugins.
+	SecretLookupSourceCredentialPlugin = "credential_plugin"
+)
+
+var (
+	SecretLookupFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kruise_daemon_secret_lookup_failures_total",
+			Help: "Total number of failures to look up image pull secrets and credentials",
+		}, []string{"node", "source"},
+	)
+
+	metricsNodeName     string
+	metricsNodeNameOnce sync.Once
+)
+
+func init() {
+	metrics.Registry.MustRegister(SecretLookupFailures)
+}
+
+// MetricsNodeName returns the node label of kruise-daemon metrics.
+func MetricsNodeName() string {
+	metricsNodeNameOnce.Do(func() {
+		metricsNodeName, _ = NodeName()
+	})
+	return metricsNodeName
+}
+
+// RecordSecretLookupFailure counts a failure to look up image pull secrets and credentials from source.
+func RecordSecretLookupFailure(source string) {
+	SecretLookupFailures.WithLabelValues(MetricsNodeName(), source).Inc()
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordSecretLookupFailure(t *testing.T) {
+	t.Setenv("NODE_NAME", "node-1")
+	metricsNodeNameOnce = sync.Once{}
+
+	RecordSecretLookupFailure(SecretLookupSourceSecret)
+	RecordSecretLookupFailure(SecretLookupSourceSecret)
+	RecordSecretLookupFailure(SecretLookupSourceKeyring)
+	if got := testutil.ToFloat64(SecretLookupFailures.WithLabelValues("node-1", SecretLookupSourceSecret)); got != 2 {
+		t.Fatalf("expected 2 secret failures, got %v", got)
+	}
+	if got := testutil.ToFloat64(SecretLookupFailures.WithLabelValues("node-1", SecretLookupSourceKeyring)); got != 1 {
+		t.Fatalf("expected 1 keyring failure, got %v", got)
+	}
+}
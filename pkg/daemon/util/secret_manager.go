@@ -64,6 +64,7 @@ func (c *cacheBasedSecretManager) GetSecrets(secrets []appsv1beta1.ReferenceObje
 			s, err := c.client.CoreV1().Secrets(secret.Namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{ResourceVersion: "0"})
 			if err != nil {
 				klog.ErrorS(err, "failed to get secret", "secret", secret)
+				RecordSecretLookupFailure(SecretLookupSourceSecret)
 			} else {
 				// renew cache in 5~10 minutes
 				interval := time.Duration(rand.Int31n(6)+5) * time.Minute
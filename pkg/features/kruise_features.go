@@ -132,6 +132,9 @@ const (
 	// Enables policies auto resizing PVCs created by a StatefulSet when user expands volumeClaimTemplates.
 	StatefulSetAutoResizePVCGate featuregate.Feature = "StatefulSetAutoResizePVCGate"
 
+	// Enables policies auto resizing PVCs created by a CloneSet when user expands volumeClaimTemplates.
+	CloneSetAutoResizePVCGate featuregate.Feature = "CloneSetAutoResizePVCGate"
+
 	// ForceDeleteTimeoutExpectationFeatureGate enable delete timeout expectation, for example: cloneSet ScaleExpectation
 	ForceDeleteTimeoutExpectationFeatureGate = "ForceDeleteTimeoutExpectationGate"
 
@@ -150,6 +153,10 @@ const (
 	// Under this feature, kruise will think all legal pod-vertical-scaling actions must success.
 	// PodUnavailableBudget will specifically protect the resize actions of individual Pods.
 	InPlacePodVerticalScaling featuregate.Feature = "InPlacePodVerticalScaling"
+
+	// ContainerTerminationPriority enables kruise-daemon to stop containers of deleting pods by
+	// the priorities defined in apps.kruise.io/container-termination-priority annotation.
+	ContainerTerminationPriority featuregate.Feature = "ContainerTerminationPriority"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -185,11 +192,13 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	PodIndexLabel:                            {Default: true, PreRelease: featuregate.Beta},
 	EnableExternalCerts:                      {Default: false, PreRelease: featuregate.Alpha},
 	StatefulSetAutoResizePVCGate:             {Default: false, PreRelease: featuregate.Alpha},
+	CloneSetAutoResizePVCGate:                {Default: false, PreRelease: featuregate.Alpha},
 	ForceDeleteTimeoutExpectationFeatureGate: {Default: false, PreRelease: featuregate.Alpha},
 	InPlaceWorkloadVerticalScaling:           {Default: false, PreRelease: featuregate.Alpha},
 	EnablePodProbeMarkerOnServerless:         {Default: false, PreRelease: featuregate.Alpha},
 	EnableSortSidecarContainerByName:         {Default: false, PreRelease: featuregate.Alpha},
 	InPlacePodVerticalScaling:                {Default: false, PreRelease: featuregate.Alpha},
+	ContainerTerminationPriority:             {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
@@ -228,6 +237,7 @@ func SetDefaultFeatureGates() {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", SidecarTerminator))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ImagePullJobGate))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", EnhancedLivenessProbeGate))
+		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ContainerTerminationPriority))
 	}
 	if utilfeature.DefaultFeatureGate.Enabled(PreDownloadImageForInPlaceUpdate) || utilfeature.DefaultFeatureGate.Enabled(PreDownloadImageForDaemonSetUpdate) {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=true", ImagePullJobGate))
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunchpriority
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+const (
+	// dependencyKeyPrefix is the prefix of barrier keys for containers launched by dependencies
+	dependencyKeyPrefix = "d_"
+)
+
+// ContainerDependency is a container that another container depends on, and the condition to wait for.
+type ContainerDependency struct {
+	Name      string
+	Condition appspub.ContainerLaunchConditionType
+}
+
+// ParseContainerDependencies parses the dependencies declared in KRUISE_CONTAINER_DEPENDS_ON env of the container.
+func ParseContainerDependencies(c *v1.Container) ([]ContainerDependency, error) {
+	var value string
+	for _, e := range c.Env {
+		if e.Name == appspub.ContainerLaunchDependenciesEnvName {
+			value = e.Value
+		}
+	}
+	var dependencies []ContainerDependency
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		dependency := ContainerDependency{Name: item, Condition: appspub.ContainerLaunchConditionReady}
+		if idx := strings.Index(item, ":"); idx >= 0 {
+			dependency.Name = strings.TrimSpace(item[:idx])
+			dependency.Condition = appspub.ContainerLaunchConditionType(strings.TrimSpace(item[idx+1:]))
+		}
+		if dependency.Name == "" {
+			return nil, fmt.Errorf("empty container name in %s of container %s", appspub.ContainerLaunchDependenciesEnvName, c.Name)
+		}
+		switch dependency.Condition {
+		case appspub.ContainerLaunchConditionReady, appspub.ContainerLaunchConditionStarted:
+		default:
+			return nil, fmt.Errorf("unsupported condition %s of dependency %s in container %s", dependency.Condition, dependency.Name, c.Name)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// GetDependencyKey returns the barrier key of the container launched by dependencies.
+func GetDependencyKey(containerName string) string {
+	return dependencyKeyPrefix + containerName
+}
+
+// IsDependencyKey returns true if the barrier key is for a container launched by dependencies.
+func IsDependencyKey(key string) bool {
+	return strings.HasPrefix(key, dependencyKeyPrefix)
+}
+
+// GenerateDependencyEnv returns the barrier env of the container launched by dependencies.
+func GenerateDependencyEnv(containerName, podName string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: appspub.ContainerLaunchBarrierEnvName,
+		ValueFrom: &v1.EnvVarSource{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: podName + "-barrier"},
+				Key:                  GetDependencyKey(containerName),
+			},
+		},
+	}
+}
+
+// HasDependencyBarrier returns true if the container is launched by dependencies.
+func HasDependencyBarrier(c *v1.Container) bool {
+	for _, e := range c.Env {
+		if e.Name == appspub.ContainerLaunchBarrierEnvName && e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+			return IsDependencyKey(e.ValueFrom.ConfigMapKeyRef.Key)
+		}
+	}
+	return false
+}
+
+// IsDependencySatisfied returns true if the container of dependency meets the condition in pod status.
+func IsDependencySatisfied(pod *v1.Pod, dependency ContainerDependency) bool {
+	for i := range pod.Status.ContainerStatuses {
+		status := &pod.Status.ContainerStatuses[i]
+		if status.Name != dependency.Name {
+			continue
+		}
+		if dependency.Condition == appspub.ContainerLaunchConditionStarted {
+			return status.Started != nil && *status.Started
+		}
+		return status.Ready
+	}
+	return false
+}
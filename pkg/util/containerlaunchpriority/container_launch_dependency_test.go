@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunchpriority
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+func TestParseContainerDependencies(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected []ContainerDependency
+		wantErr  bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "default condition and started",
+			value: "a, b:Started ,c:Ready",
+			expected: []ContainerDependency{
+				{Name: "a", Condition: appspub.ContainerLaunchConditionReady},
+				{Name: "b", Condition: appspub.ContainerLaunchConditionStarted},
+				{Name: "c", Condition: appspub.ContainerLaunchConditionReady},
+			},
+		},
+		{
+			name:    "empty name",
+			value:   ":Ready",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported condition",
+			value:   "a:Running",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &v1.Container{Name: "main", Env: []v1.EnvVar{{Name: appspub.ContainerLaunchDependenciesEnvName, Value: tc.value}}}
+			got, err := ParseContainerDependencies(c)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDependencyBarrier(t *testing.T) {
+	c := &v1.Container{Name: "main", Env: []v1.EnvVar{GenerateDependencyEnv("main", "pod")}}
+	if !HasDependencyBarrier(c) {
+		t.Fatalf("expected dependency barrier")
+	}
+	if GetContainerPriority(c) != nil {
+		t.Fatalf("expected no priority for dependency barrier")
+	}
+	if HasDependencyBarrier(&v1.Container{Env: []v1.EnvVar{GeneratePriorityEnv(1, "pod")}}) {
+		t.Fatalf("expected no dependency barrier for priority")
+	}
+
+	pod := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "a", Started: ptr.To(true)}}}}
+	if !IsDependencySatisfied(pod, ContainerDependency{Name: "a", Condition: appspub.ContainerLaunchConditionStarted}) {
+		t.Fatalf("expected started dependency satisfied")
+	}
+	if IsDependencySatisfied(pod, ContainerDependency{Name: "a", Condition: appspub.ContainerLaunchConditionReady}) {
+		t.Fatalf("expected ready dependency not satisfied")
+	}
+	if IsDependencySatisfied(pod, ContainerDependency{Name: "b", Condition: appspub.ContainerLaunchConditionStarted}) {
+		t.Fatalf("expected missing container not satisfied")
+	}
+}
@@ -33,6 +33,9 @@ const (
 func GetContainerPriority(c *v1.Container) *int {
 	for _, e := range c.Env {
 		if e.Name == appspub.ContainerLaunchBarrierEnvName {
+			if IsDependencyKey(e.ValueFrom.ConfigMapKeyRef.Key) {
+				return nil
+			}
 			p, _ := strconv.Atoi(e.ValueFrom.ConfigMapKeyRef.Key[priorityStartIndex:])
 			return &p
 		}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+const (
+	// probeMarkerName is the marker part of the NodePodProbe probe names for enhanced liveness probes.
+	// It is not a valid object name, so it never conflicts with the name of a PodProbeMarker.
+	probeMarkerName = "_enhanced-liveness"
+)
+
+// ContainerLivenessProbe is the native liveness probe of a container, which is backed up in
+// apps.kruise.io/container-probe-context annotation by webhook.
+type ContainerLivenessProbe struct {
+	Name          string       `json:"name"`
+	LivenessProbe corev1.Probe `json:"livenessProbe"`
+}
+
+// UsingEnhancedLivenessProbe returns true if the enhanced liveness probe of pod is enabled.
+func UsingEnhancedLivenessProbe(pod *corev1.Pod) bool {
+	return pod.Annotations[appsv1alpha1.AnnotationUsingEnhancedLiveness] == "true"
+}
+
+// GetContainerLivenessProbes returns the native liveness probes of containers backed up in pod annotation.
+func GetContainerLivenessProbes(pod *corev1.Pod) ([]ContainerLivenessProbe, error) {
+	str := pod.Annotations[appsv1alpha1.AnnotationNativeContainerProbeContext]
+	if str == "" {
+		return nil, nil
+	}
+	var probes []ContainerLivenessProbe
+	if err := json.Unmarshal([]byte(str), &probes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation: %v", appsv1alpha1.AnnotationNativeContainerProbeContext, err)
+	}
+	return probes, nil
+}
+
+// GetProbeName returns the NodePodProbe probe name of the enhanced liveness probe for the container.
+func GetProbeName(containerName string) string {
+	return fmt.Sprintf("%s#%s", probeMarkerName, containerName)
+}
+
+// ParseProbeName returns the container name if the NodePodProbe probe name is an enhanced liveness probe.
+func ParseProbeName(name string) (string, bool) {
+	containerName, ok := strings.CutPrefix(name, probeMarkerName+"#")
+	return containerName, ok
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"sync"
+)
+
+// Executor runs the calls of http hooks in background with bounded concurrency,
+// and at most one call for each key at a time.
+type Executor struct {
+	workers chan struct{}
+	mu      sync.Mutex
+	running map[string]struct{}
+	wg      sync.WaitGroup
+}
+
+// NewExecutor returns an Executor running at most workers calls at the same time.
+func NewExecutor(workers int) *Executor {
+	if workers <= 0 {
+		workers = 1
+	}
+	return &Executor{
+		workers: make(chan struct{}, workers),
+		running: make(map[string]struct{}),
+	}
+}
+
+// Submit runs fn in background for the key. It returns false without running fn if a call of the key
+// is still running or all workers are busy.
+func (e *Executor) Submit(key string, fn func()) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.running[key]; ok {
+		return false
+	}
+	select {
+	case e.workers <- struct{}{}:
+	default:
+		return false
+	}
+	e.running[key] = struct{}{}
+	e.wg.Add(1)
+	go func() {
+		defer func() {
+			e.mu.Lock()
+			delete(e.running, key)
+			e.mu.Unlock()
+			<-e.workers
+			e.wg.Done()
+		}()
+		fn()
+	}()
+	return true
+}
+
+// IsRunning returns true if a call of the key is running.
+func (e *Executor) IsRunning(key string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.running[key]
+	return ok
+}
+
+// Wait waits for all the calls submitted to finish.
+func (e *Executor) Wait() {
+	e.wg.Wait()
+}
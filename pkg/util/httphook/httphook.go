@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	flag.StringVar(&AllowedHosts, "http-hook-allowed-hosts", AllowedHosts, "Comma-separated hosts that the http hooks of workloads are allowed to call, "+
+		"such as lifecycle hooks, scale-in rankers and update priority extenders. An entry starting with '.' matches the suffix of host, and '*' matches any host.")
+	flag.StringVar(&caFile, "http-hook-ca-file", "", "The CA file to verify the https endpoints of http hooks. System roots are used if empty.")
+	flag.StringVar(&certFile, "http-hook-cert-file", "", "The client certificate file to present to the https endpoints of http hooks.")
+	flag.StringVar(&keyFile, "http-hook-key-file", "", "The client key file to present to the https endpoints of http hooks.")
+}
+
+const (
+	// maxResponseBytes is the max size of response body to read.
+	maxResponseBytes = 1 << 20
+	// maxErrorMessageBytes is the max size of response body kept in the error of failed call.
+	maxErrorMessageBytes = 256
+)
+
+var (
+	// AllowedHosts are the comma-separated hosts allowed to call. By default only the services in cluster are allowed.
+	AllowedHosts = ".svc,.svc.cluster.local"
+
+	caFile   string
+	certFile string
+	keyFile  string
+
+	clientOnce sync.Once
+	hookClient *http.Client
+	clientErr  error
+)
+
+type podIPContextKey struct{}
+
+// Request is a POST request to the http hook.
+type Request struct {
+	URL    string
+	Header http.Header
+	// Body is encoded as JSON if not nil.
+	Body    interface{}
+	Timeout time.Duration
+	// PodIP is allowed as the target host besides AllowedHosts, for the hooks served by the pod itself.
+	PodIP string
+}
+
+// ValidateURL checks the URL is an absolute http or https URL, and the host is allowed.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http or https URL")
+	}
+	if !isAllowedHost(u.Hostname()) {
+		return fmt.Errorf("host %s is not allowed, the allowed hosts are %q", u.Hostname(), AllowedHosts)
+	}
+	return nil
+}
+
+// Post sends the request and decodes the response into out if it is not nil.
+// It fails if the host is not allowed, or the response status is not 2xx.
+func Post(req *Request, out interface{}) error {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if host := u.Hostname(); (req.PodIP == "" || host != req.PodIP) && !isAllowedHost(host) {
+		return fmt.Errorf("host %s is not allowed, the allowed hosts are %q", host, AllowedHosts)
+	}
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if req.Body != nil {
+		data, err := json.Marshal(req.Body)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.TODO(), podIPContextKey{}, req.PodIP), req.Timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return err
+	}
+	for name, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(name, value)
+		}
+	}
+	if req.Body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageBytes))
+		return fmt.Errorf("returned status %d: %s", resp.StatusCode, string(msg))
+	}
+	if out != nil {
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %v", err)
+		}
+	}
+	return nil
+}
+
+// isAllowedHost returns true if the host matches any entry of AllowedHosts.
+func isAllowedHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return false
+	}
+	for _, entry := range strings.Split(AllowedHosts, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) {
+				return true
+			}
+		case host == entry:
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowedIP returns false for the loopback, link-local, multicast and unspecified addresses resolved,
+// unless they are the target pod IP or explicitly listed in AllowedHosts.
+func isAllowedIP(ctx context.Context, ip net.IP) bool {
+	if podIP, _ := ctx.Value(podIPContextKey{}).(string); podIP != "" && podIP == ip.String() {
+		return true
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		for _, entry := range strings.Split(AllowedHosts, ",") {
+			if strings.TrimSpace(entry) == ip.String() {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// dialContext resolves the address and checks the IPs before dialing, so that the allowed hosts
+// can not be resolved to the addresses of node or metadata services.
+func dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no address found for %s", host)
+	}
+	for _, ip := range ips {
+		if !isAllowedIP(ctx, ip.IP) {
+			return nil, fmt.Errorf("address %s of %s is not allowed", ip.IP, host)
+		}
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].IP.String(), port))
+}
+
+func getClient() (*http.Client, error) {
+	clientOnce.Do(func() {
+		tlsConfig, err := newTLSConfig()
+		if err != nil {
+			clientErr = err
+			return
+		}
+		hookClient = &http.Client{
+			Transport: &http.Transport{
+				DialContext:         dialContext,
+				TLSClientConfig:     tlsConfig,
+				TLSHandshakeTimeout: 10 * time.Second,
+				MaxIdleConnsPerHost: 10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// redirects are not followed, to avoid bypassing the allowed hosts
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	})
+	return hookClient, clientErr
+}
+
+func newTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read http hook CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate found in http hook CA file %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load http hook client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValidateURL(t *testing.T) {
+	defer func(hosts string) { AllowedHosts = hosts }(AllowedHosts)
+	AllowedHosts = ".svc, .svc.cluster.local,hook.example.com"
+
+	cases := map[string]bool{
+		"http://hook.default.svc/ranker":                 true,
+		"https://hook.default.svc.cluster.local:8443/x":  true,
+		"http://HOOK.example.com./x":                     true,
+		"http://example.com/x":                           false,
+		"http://169.254.169.254/latest/meta-data":        false,
+		"http://10.0.0.1/x":                              false,
+		"ftp://hook.default.svc/x":                       false,
+		"/relative":                                      false,
+		"http://hook.default.svc.attacker.com/x":         false,
+		"http://svc/x":                                   false,
+		"http://hook.default.svc.cluster.local.evil.io/": false,
+	}
+	for u, expected := range cases {
+		if err := ValidateURL(u); (err == nil) != expected {
+			t.Errorf("expected allowed %v for %s, got %v", expected, u, err)
+		}
+	}
+
+	AllowedHosts = "*"
+	if err := ValidateURL("http://10.0.0.1/x"); err != nil {
+		t.Errorf("expected any host allowed, got %v", err)
+	}
+}
+
+func TestPost(t *testing.T) {
+	defer func(hosts string) { AllowedHosts = hosts }(AllowedHosts)
+
+	var gotBody map[string]string
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(strings.Repeat("x", 1000)))
+		default:
+			gotHeader = r.Header.Get("X-Test")
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			_, _ = w.Write([]byte(`{"result":"ok"}`))
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	// loopback is not allowed if the host is not listed
+	AllowedHosts = "localhost"
+	localURL := "http://localhost:" + u.Port() + "/ok"
+	if err := Post(&Request{URL: localURL, Timeout: time.Second}, nil); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("expected loopback address not allowed, got %v", err)
+	}
+	AllowedHosts = ""
+	if err := Post(&Request{URL: server.URL + "/ok", Timeout: time.Second}, nil); err == nil {
+		t.Fatalf("expected host not allowed")
+	}
+	// the pod IP is allowed
+	if err := Post(&Request{URL: server.URL + "/ok", Timeout: time.Second, PodIP: u.Hostname()}, nil); err != nil {
+		t.Fatalf("expected pod IP allowed, got %v", err)
+	}
+
+	AllowedHosts = u.Hostname()
+	out := map[string]string{}
+	err := Post(&Request{
+		URL:     server.URL + "/ok",
+		Header:  http.Header{"X-Test": []string{"v"}},
+		Body:    map[string]string{"name": "pod-0"},
+		Timeout: time.Second,
+	}, &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody["name"] != "pod-0" || gotHeader != "v" || out["result"] != "ok" {
+		t.Fatalf("unexpected request %v %v or response %v", gotBody, gotHeader, out)
+	}
+
+	if err := Post(&Request{URL: server.URL + "/redirect", Timeout: time.Second}, nil); err == nil || !strings.Contains(err.Error(), "302") {
+		t.Fatalf("expected redirect not followed, got %v", err)
+	}
+	if err := Post(&Request{URL: server.URL + "/fail", Timeout: time.Second}, nil); err == nil || len(err.Error()) > 300 {
+		t.Fatalf("expected trimmed error, got %v", err)
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	e := NewExecutor(1)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var calls []string
+	call := func(key string) func() {
+		return func() {
+			<-release
+			mu.Lock()
+			calls = append(calls, key)
+			mu.Unlock()
+		}
+	}
+
+	if !e.Submit("a", call("a")) {
+		t.Fatalf("expected a submitted")
+	}
+	if e.Submit("a", call("a")) {
+		t.Fatalf("expected a not submitted again while running")
+	}
+	if e.Submit("b", call("b")) {
+		t.Fatalf("expected b not submitted while workers busy")
+	}
+	if !e.IsRunning("a") {
+		t.Fatalf("expected a running")
+	}
+	close(release)
+	e.Wait()
+	if e.IsRunning("a") || !e.Submit("b", call("b")) {
+		t.Fatalf("expected b submitted after a finished")
+	}
+	e.Wait()
+	if len(calls) != 2 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
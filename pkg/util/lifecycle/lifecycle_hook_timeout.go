@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+var hookTimeoutNow = time.Now
+
+func isHookWaitingState(state appspub.LifecycleStateType) bool {
+	switch state {
+	case appspub.LifecycleStatePreparingNormal, appspub.LifecycleStatePreparingUpdate,
+		appspub.LifecycleStateUpdated, appspub.LifecycleStatePreparingDelete:
+		return true
+	}
+	return false
+}
+
+// GetHookTimeoutRemaining returns the remaining time before pod times out in the current lifecycle state of hook.
+// It returns false if the hook has no timeout or pod is not waiting for a hook.
+func GetHookTimeoutRemaining(hook *appspub.LifecycleHook, pod *v1.Pod) (time.Duration, bool) {
+	if hook == nil || hook.TimeoutSeconds == nil || pod == nil || !isHookWaitingState(GetPodLifecycleState(pod)) {
+		return 0, false
+	}
+	timestamp, err := time.Parse(time.RFC3339, pod.Annotations[appspub.LifecycleTimestampKey])
+	if err != nil {
+		return 0, false
+	}
+	remaining := timestamp.Add(time.Duration(*hook.TimeoutSeconds) * time.Second).Sub(hookTimeoutNow())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// IsHookTimedOut returns true if pod has stayed in the current lifecycle state of hook longer than its timeoutSeconds.
+func IsHookTimedOut(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
+	remaining, ok := GetHookTimeoutRemaining(hook, pod)
+	return ok && remaining == 0
+}
+
+// GetHookTimeoutAction returns the onTimeout action of hook.
+func GetHookTimeoutAction(hook *appspub.LifecycleHook) appspub.LifecycleHookTimeoutActionType {
+	if hook == nil || hook.OnTimeout == "" {
+		return appspub.LifecycleHookTimeoutBlock
+	}
+	return hook.OnTimeout
+}
+
+func isHookTimedOutToProceed(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
+	return GetHookTimeoutAction(hook) == appspub.LifecycleHookTimeoutProceed && IsHookTimedOut(hook, pod)
+}
+
+// IsPodMarkedHookTimedOut returns true if pod has been marked timed out in its current lifecycle state.
+func IsPodMarkedHookTimedOut(pod *v1.Pod) bool {
+	for i := range pod.Status.Conditions {
+		c := &pod.Status.Conditions[i]
+		if c.Type != appspub.LifecycleHookTimedOut {
+			continue
+		}
+		if c.Status != v1.ConditionTrue || c.Reason != string(GetPodLifecycleState(pod)) {
+			return false
+		}
+		timestamp, err := time.Parse(time.RFC3339, pod.Annotations[appspub.LifecycleTimestampKey])
+		return err != nil || !c.LastTransitionTime.Time.Before(timestamp)
+	}
+	return false
+}
+
+// MarkPodHookTimedOut sets the LifecycleHookTimedOut condition of pod if hook has timed out in its current lifecycle state.
+// It returns true only when the condition is newly set.
+func (c *realControl) MarkPodHookTimedOut(pod *v1.Pod, hook *appspub.LifecycleHook) (marked bool, err error) {
+	if !IsHookTimedOut(hook, pod) || IsPodMarkedHookTimedOut(pod) {
+		return false, nil
+	}
+	state := GetPodLifecycleState(pod)
+	condition := v1.PodCondition{
+		Type:               appspub.LifecycleHookTimedOut,
+		Status:             v1.ConditionTrue,
+		Reason:             string(state),
+		Message:            fmt.Sprintf("pod stayed in %s longer than %ds, onTimeout %s", state, *hook.TimeoutSeconds, GetHookTimeoutAction(hook)),
+		LastTransitionTime: metav1.NewTime(hookTimeoutNow()),
+	}
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		newPod, err := c.adp.GetPod(pod.Namespace, pod.Name)
+		if err != nil {
+			return err
+		}
+		var found bool
+		for i := range newPod.Status.Conditions {
+			if newPod.Status.Conditions[i].Type == appspub.LifecycleHookTimedOut {
+				newPod.Status.Conditions[i] = condition
+				found = true
+				break
+			}
+		}
+		if !found {
+			newPod.Status.Conditions = append(newPod.Status.Conditions, condition)
+		}
+		return c.adp.UpdatePodStatus(newPod)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePodHookFinalizers removes the finalizers of hook from pod, which would block the deletion of pod
+// proceeding after timed out in PreparingDelete. It returns true only when pod is updated.
+func (c *realControl) RemovePodHookFinalizers(pod *v1.Pod, hook *appspub.LifecycleHook) (updated bool, gotPod *v1.Pod, err error) {
+	if hook == nil || len(hook.FinalizersHandler) == 0 {
+		return false, pod, nil
+	}
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		newPod, err := c.adp.GetPod(pod.Namespace, pod.Name)
+		if err != nil {
+			return err
+		}
+		updated = false
+		for _, f := range hook.FinalizersHandler {
+			if controllerutil.RemoveFinalizer(newPod, f) {
+				updated = true
+			}
+		}
+		if !updated {
+			gotPod = newPod
+			return nil
+		}
+		gotPod, err = c.adp.UpdatePod(newPod)
+		return err
+	})
+	return updated, gotPod, err
+}
+
+// GetPodsFailedInHook returns the pods that timed out in a lifecycle hook with MarkFailed action,
+// formatted as name(state) and sorted by name.
+func GetPodsFailedInHook(lifecycle *appspub.Lifecycle, pods []*v1.Pod) []string {
+	var failed []string
+	for _, pod := range pods {
+		hook := GetPodLifecycleHook(lifecycle, pod)
+		if GetHookTimeoutAction(hook) == appspub.LifecycleHookTimeoutMarkFailed && IsHookTimedOut(hook, pod) {
+			failed = append(failed, fmt.Sprintf("%s(%s)", pod.Name, GetPodLifecycleState(pod)))
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// GetLifecycleHookFailedMessage returns the message of workload condition for pods failed in lifecycle hook.
+func GetLifecycleHookFailedMessage(failedPods []string) string {
+	return fmt.Sprintf("pods timed out in lifecycle hook: %s", strings.Join(failedPods, ", "))
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+type fakeStatusAdapter struct {
+	fakeAdapter
+	pod *corev1.Pod
+}
+
+func (f *fakeStatusAdapter) GetPod(_, _ string) (*corev1.Pod, error) {
+	return f.pod.DeepCopy(), nil
+}
+
+func (f *fakeStatusAdapter) UpdatePodStatus(pod *corev1.Pod) error {
+	f.updateStatusCalled = true
+	f.pod = pod
+	return nil
+}
+
+func newTimeoutTestPod(name string, state appspub.LifecycleStateType, timestamp time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Labels:      map[string]string{appspub.LifecycleStateKey: string(state), "hook": "true"},
+			Annotations: map[string]string{appspub.LifecycleTimestampKey: timestamp.Format(time.RFC3339)},
+		},
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	hookTimeoutNow = func() time.Time { return now }
+	defer func() { hookTimeoutNow = time.Now }()
+
+	cases := []struct {
+		name            string
+		hook            *appspub.LifecycleHook
+		pod             *corev1.Pod
+		expectRemaining time.Duration
+		expectOK        bool
+		expectHooked    bool
+		expectAllHooked bool
+	}{
+		{
+			name:            "no timeout",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, now.Add(-time.Hour)),
+			expectHooked:    true,
+			expectAllHooked: true,
+		},
+		{
+			name:            "not timed out yet",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}, TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, now.Add(-time.Minute)),
+			expectRemaining: 9 * time.Minute,
+			expectOK:        true,
+			expectHooked:    true,
+			expectAllHooked: true,
+		},
+		{
+			name:            "timed out with Proceed",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}, TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, now.Add(-time.Hour)),
+			expectOK:        true,
+			expectHooked:    false,
+			expectAllHooked: true,
+		},
+		{
+			name:            "timed out with Block",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}, TimeoutSeconds: ptr.To[int32](600)},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, now.Add(-time.Hour)),
+			expectOK:        true,
+			expectHooked:    true,
+			expectAllHooked: true,
+		},
+		{
+			name:            "timed out with Proceed in another state",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}, TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingUpdate, now.Add(-time.Hour)),
+			expectOK:        true,
+			expectHooked:    true,
+			expectAllHooked: true,
+		},
+		{
+			name:            "not in hooked state",
+			hook:            &appspub.LifecycleHook{LabelsHandler: map[string]string{"hook": "true"}, TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			pod:             newTimeoutTestPod("pod-0", appspub.LifecycleStateNormal, now.Add(-time.Hour)),
+			expectHooked:    true,
+			expectAllHooked: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			remaining, ok := GetHookTimeoutRemaining(tc.hook, tc.pod)
+			if remaining != tc.expectRemaining || ok != tc.expectOK {
+				t.Fatalf("expected remaining %v %v, got %v %v", tc.expectRemaining, tc.expectOK, remaining, ok)
+			}
+			if got := IsPodHooked(tc.hook, tc.pod, appspub.LifecycleStatePreparingDelete); got != tc.expectHooked {
+				t.Fatalf("expected IsPodHooked %v, got %v", tc.expectHooked, got)
+			}
+			if got := IsPodAllHooked(tc.hook, tc.pod, appspub.LifecycleStatePreparingDelete); got != tc.expectAllHooked {
+				t.Fatalf("expected IsPodAllHooked %v, got %v", tc.expectAllHooked, got)
+			}
+		})
+	}
+}
+
+func TestMarkPodHookTimedOut(t *testing.T) {
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	hookTimeoutNow = func() time.Time { return now }
+	defer func() { hookTimeoutNow = time.Now }()
+
+	hook := &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutMarkFailed}
+	pod := newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, now.Add(-time.Hour))
+	// a stale condition of previous state
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:               appspub.LifecycleHookTimedOut,
+		Status:             corev1.ConditionTrue,
+		Reason:             string(appspub.LifecycleStatePreparingUpdate),
+		LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+	}}
+	adp := &fakeStatusAdapter{pod: pod}
+	c := &realControl{adp: adp}
+
+	marked, err := c.MarkPodHookTimedOut(pod, hook)
+	if err != nil || !marked {
+		t.Fatalf("expected pod marked, got %v %v", marked, err)
+	}
+	if len(adp.pod.Status.Conditions) != 1 || adp.pod.Status.Conditions[0].Reason != string(appspub.LifecycleStatePreparingDelete) {
+		t.Fatalf("unexpected conditions %v", adp.pod.Status.Conditions)
+	}
+	if !IsPodMarkedHookTimedOut(adp.pod) {
+		t.Fatalf("expected pod marked timed out")
+	}
+
+	adp.updateStatusCalled = false
+	if marked, err = c.MarkPodHookTimedOut(adp.pod, hook); err != nil || marked || adp.updateStatusCalled {
+		t.Fatalf("expected pod not marked again, got %v %v", marked, err)
+	}
+}
+
+func TestRemovePodHookFinalizers(t *testing.T) {
+	hook := &appspub.LifecycleHook{FinalizersHandler: []string{"example.com/hook"}}
+	pod := newTimeoutTestPod("pod-0", appspub.LifecycleStatePreparingDelete, time.Now())
+	pod.Finalizers = []string{"example.com/hook", "example.com/other"}
+	adp := &fakeStatusAdapter{pod: pod}
+	c := &realControl{adp: adp}
+
+	updated, gotPod, err := c.RemovePodHookFinalizers(pod, hook)
+	if err != nil || !updated {
+		t.Fatalf("expected pod updated, got %v %v", updated, err)
+	}
+	if !reflect.DeepEqual(gotPod.Finalizers, []string{"example.com/other"}) {
+		t.Fatalf("unexpected finalizers %v", gotPod.Finalizers)
+	}
+
+	adp.pod = gotPod
+	adp.updateCalled = false
+	if updated, _, err = c.RemovePodHookFinalizers(gotPod, hook); err != nil || updated || adp.updateCalled {
+		t.Fatalf("expected pod not updated again, got %v %v", updated, err)
+	}
+}
+
+func TestGetPodsFailedInHook(t *testing.T) {
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	hookTimeoutNow = func() time.Time { return now }
+	defer func() { hookTimeoutNow = time.Now }()
+
+	lc := &appspub.Lifecycle{
+		PreDelete:     &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutMarkFailed},
+		InPlaceUpdate: &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutBlock},
+		PreNormal:     &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](600), OnTimeout: appspub.LifecycleHookTimeoutMarkFailed},
+	}
+	pods := []*corev1.Pod{
+		newTimeoutTestPod("pod-c", appspub.LifecycleStatePreparingDelete, now.Add(-time.Hour)),
+		newTimeoutTestPod("pod-a", appspub.LifecycleStatePreparingNormal, now.Add(-time.Hour)),
+		newTimeoutTestPod("pod-b", appspub.LifecycleStatePreparingDelete, now.Add(-time.Minute)),
+		newTimeoutTestPod("pod-d", appspub.LifecycleStateUpdated, now.Add(-time.Hour)),
+		newTimeoutTestPod("pod-e", appspub.LifecycleStateNormal, now.Add(-time.Hour)),
+	}
+	expected := []string{"pod-a(PreparingNormal)", "pod-c(PreparingDelete)"}
+	if got := GetPodsFailedInHook(lc, pods); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+	"github.com/openkruise/kruise/pkg/util/podadapter"
+)
+
+func init() {
+	flag.IntVar(&httpHookWorkers, "lifecycle-http-hook-workers", httpHookWorkers, "Max concurrent calls of lifecycle http hooks.")
+}
+
+const (
+	defaultHTTPHookTimeoutSeconds = 10
+	maxHTTPHookTimeoutSeconds     = 30
+	defaultHTTPHookRetryLimit     = 3
+
+	httpHookInitialBackoff = 5 * time.Second
+	httpHookMaxBackoff     = 5 * time.Minute
+)
+
+var (
+	httpHookWorkers      = 10
+	httpHookExecutor     *httphook.Executor
+	httpHookExecutorOnce sync.Once
+	httpHookNow          = time.Now
+)
+
+// HTTPHookRequest is the body POSTed to the HTTPHandler of lifecycle hook.
+type HTTPHookRequest struct {
+	Namespace string                     `json:"namespace"`
+	Name      string                     `json:"name"`
+	UID       types.UID                  `json:"uid"`
+	State     appspub.LifecycleStateType `json:"state"`
+}
+
+func getHTTPHookExecutor() *httphook.Executor {
+	httpHookExecutorOnce.Do(func() {
+		httpHookExecutor = httphook.NewExecutor(httpHookWorkers)
+	})
+	return httpHookExecutor
+}
+
+// GetPodLifecycleHook returns the hook that pod is waiting for in its current lifecycle state.
+func GetPodLifecycleHook(lifecycle *appspub.Lifecycle, pod *v1.Pod) *appspub.LifecycleHook {
+	if lifecycle == nil {
+		return nil
+	}
+	switch GetPodLifecycleState(pod) {
+	case appspub.LifecycleStatePreparingNormal:
+		return lifecycle.PreNormal
+	case appspub.LifecycleStatePreparingUpdate, appspub.LifecycleStateUpdated:
+		return lifecycle.InPlaceUpdate
+	case appspub.LifecycleStatePreparingDelete:
+		return lifecycle.PreDelete
+	}
+	return nil
+}
+
+// GetHTTPHookStatus returns the status of HTTPHandler for the current lifecycle state of pod,
+// or nil if the handler has not been called in this state.
+func GetHTTPHookStatus(pod *v1.Pod) *appspub.LifecycleHTTPHookStatus {
+	if pod == nil || pod.Annotations[appspub.LifecycleHTTPHookStatusKey] == "" {
+		return nil
+	}
+	status := &appspub.LifecycleHTTPHookStatus{}
+	if err := json.Unmarshal([]byte(pod.Annotations[appspub.LifecycleHTTPHookStatusKey]), status); err != nil {
+		klog.ErrorS(err, "Failed to unmarshal lifecycle http hook status", "pod", klog.KObj(pod))
+		return nil
+	}
+	// the status belongs to a previous state
+	if status.State != GetPodLifecycleState(pod) || status.Timestamp != pod.Annotations[appspub.LifecycleTimestampKey] {
+		return nil
+	}
+	return status
+}
+
+// IsHTTPHookCompleted returns true if HTTPHandler has completed for the current lifecycle state of pod.
+func IsHTTPHookCompleted(pod *v1.Pod) bool {
+	status := GetHTTPHookStatus(pod)
+	return status != nil && status.Completed
+}
+
+func getHTTPHookTimeout(handler *appspub.LifecycleHTTPHandler) time.Duration {
+	seconds := int32(defaultHTTPHookTimeoutSeconds)
+	if handler.TimeoutSeconds != nil && *handler.TimeoutSeconds > 0 {
+		seconds = *handler.TimeoutSeconds
+	}
+	if seconds > maxHTTPHookTimeoutSeconds {
+		seconds = maxHTTPHookTimeoutSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func getHTTPHookRetryLimit(handler *appspub.LifecycleHTTPHandler) int32 {
+	if handler.RetryLimit != nil && *handler.RetryLimit >= 0 {
+		return *handler.RetryLimit
+	}
+	return defaultHTTPHookRetryLimit
+}
+
+// getHTTPHookBackoff returns the backoff before the next call after the given number of failures.
+func getHTTPHookBackoff(failures int32) time.Duration {
+	backoff := httpHookInitialBackoff
+	for i := int32(1); i < failures; i++ {
+		backoff *= 2
+		if backoff >= httpHookMaxBackoff {
+			return httpHookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func callHTTPHook(handler *appspub.LifecycleHTTPHandler, pod *v1.Pod, state appspub.LifecycleStateType) error {
+	return httphook.Post(&httphook.Request{
+		URL:     handler.URL,
+		Body:    &HTTPHookRequest{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID, State: state},
+		Timeout: getHTTPHookTimeout(handler),
+	}, nil)
+}
+
+func getHTTPHookKey(pod *v1.Pod, status *appspub.LifecycleHTTPHookStatus) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", pod.Namespace, pod.Name, pod.UID, status.State, status.Timestamp)
+}
+
+// ExecuteHTTPHook starts to call the HTTPHandler of hook in background for the current lifecycle state of pod
+// if it has not completed. The time of call is recorded in the annotation of pod before the call, and the result
+// is recorded after the call, which triggers the workload to reconcile again.
+// It returns the duration after which the workload should check the result or retry.
+func (c *realControl) ExecuteHTTPHook(pod *v1.Pod, hook *appspub.LifecycleHook) (updated bool, gotPod *v1.Pod, retryAfter time.Duration, err error) {
+	if pod == nil || hook == nil || hook.HTTPHandler == nil {
+		return false, pod, 0, nil
+	}
+	handler := hook.HTTPHandler
+	now := httpHookNow()
+	status := GetHTTPHookStatus(pod)
+	if status == nil {
+		status = &appspub.LifecycleHTTPHookStatus{
+			State:     GetPodLifecycleState(pod),
+			Timestamp: pod.Annotations[appspub.LifecycleTimestampKey],
+		}
+	} else if status.Completed {
+		return false, pod, 0, nil
+	}
+
+	executor := getHTTPHookExecutor()
+	key := getHTTPHookKey(pod, status)
+	if executor.IsRunning(key) {
+		return false, pod, getHTTPHookTimeout(handler), nil
+	}
+	if status.LastCallTime != "" {
+		if lastCallTime, err := time.Parse(time.RFC3339, status.LastCallTime); err == nil {
+			if wait := lastCallTime.Add(getHTTPHookBackoff(status.Failures)).Sub(now); wait > 0 {
+				return false, pod, wait, nil
+			}
+		}
+	}
+
+	status.LastCallTime = now.Format(time.RFC3339)
+	gotPod, err = c.patchHTTPHookStatus(pod, status)
+	if err != nil {
+		return false, nil, 0, err
+	}
+	calling := *status
+	if !executor.Submit(key, func() { c.callHTTPHookInBackground(pod, handler, &calling) }) {
+		// all workers are busy, the call will be started again after backoff
+		return true, gotPod, httpHookInitialBackoff, nil
+	}
+	return true, gotPod, getHTTPHookTimeout(handler), nil
+}
+
+// callHTTPHookInBackground calls the HTTPHandler and records the result in the annotation of pod.
+func (c *realControl) callHTTPHookInBackground(pod *v1.Pod, handler *appspub.LifecycleHTTPHandler, status *appspub.LifecycleHTTPHookStatus) {
+	if callErr := callHTTPHook(handler, pod, status.State); callErr != nil {
+		status.Failures++
+		status.Message = callErr.Error()
+		klog.InfoS("Failed to call lifecycle http hook", "pod", klog.KObj(pod), "state", status.State,
+			"url", handler.URL, "failures", status.Failures, "err", callErr)
+		if status.Failures > getHTTPHookRetryLimit(handler) && handler.FailurePolicy == appspub.LifecycleHookFailurePolicyProceed {
+			status.Completed = true
+		}
+	} else {
+		status.Completed = true
+		status.Message = ""
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current, err := c.adp.GetPod(pod.Namespace, pod.Name)
+		if err != nil {
+			return err
+		}
+		// the pod has been recreated or moved to another state
+		if current.UID != pod.UID || GetPodLifecycleState(current) != status.State ||
+			current.Annotations[appspub.LifecycleTimestampKey] != status.Timestamp {
+			return nil
+		}
+		_, err = c.patchHTTPHookStatus(current, status)
+		return err
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to record the result of lifecycle http hook", "pod", klog.KObj(pod), "state", status.State)
+	}
+}
+
+func (c *realControl) patchHTTPHookStatus(pod *v1.Pod, status *appspub.LifecycleHTTPHookStatus) (*v1.Pod, error) {
+	value, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+	pod = pod.DeepCopy()
+	if adp, ok := c.adp.(podadapter.AdapterWithPatch); ok {
+		body, _ := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{appspub.LifecycleHTTPHookStatusKey: string(value)},
+			},
+		})
+		return adp.PatchPod(pod, client.RawPatch(types.StrategicMergePatchType, body))
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[appspub.LifecycleHTTPHookStatusKey] = string(value)
+	return c.adp.UpdatePod(pod)
+}
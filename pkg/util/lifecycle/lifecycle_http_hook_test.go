@@ -0,0 +1,288 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+	"github.com/openkruise/kruise/pkg/util/podadapter"
+)
+
+func newHTTPHookTestPod(state appspub.LifecycleStateType, status *appspub.LifecycleHTTPHookStatus) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "pod-0",
+			Labels:      map[string]string{appspub.LifecycleStateKey: string(state)},
+			Annotations: map[string]string{appspub.LifecycleTimestampKey: "2025-01-01T00:00:00Z"},
+		},
+	}
+	if status != nil {
+		value, _ := json.Marshal(status)
+		pod.Annotations[appspub.LifecycleHTTPHookStatusKey] = string(value)
+	}
+	return pod
+}
+
+func TestGetHTTPHookStatus(t *testing.T) {
+	cases := []struct {
+		name          string
+		pod           *corev1.Pod
+		expectNil     bool
+		expectHooked  bool
+		expectAllHook bool
+	}{
+		{
+			name:          "no status",
+			pod:           newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, nil),
+			expectNil:     true,
+			expectHooked:  true,
+			expectAllHook: false,
+		},
+		{
+			name: "completed in current state",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2025-01-01T00:00:00Z", Completed: true,
+			}),
+			expectHooked:  false,
+			expectAllHook: true,
+		},
+		{
+			name: "completed in previous state",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingUpdate, Timestamp: "2025-01-01T00:00:00Z", Completed: true,
+			}),
+			expectNil:     true,
+			expectHooked:  true,
+			expectAllHook: false,
+		},
+		{
+			name: "completed in the same state of previous round",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2024-01-01T00:00:00Z", Completed: true,
+			}),
+			expectNil:     true,
+			expectHooked:  true,
+			expectAllHook: false,
+		},
+	}
+
+	hook := &appspub.LifecycleHook{HTTPHandler: &appspub.LifecycleHTTPHandler{URL: "http://127.0.0.1"}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := GetHTTPHookStatus(tc.pod); (got == nil) != tc.expectNil {
+				t.Fatalf("expected nil status %v, got %v", tc.expectNil, got)
+			}
+			if got := IsPodHooked(hook, tc.pod, appspub.LifecycleStatePreparingDelete); got != tc.expectHooked {
+				t.Fatalf("expected IsPodHooked %v, got %v", tc.expectHooked, got)
+			}
+			if got := IsPodAllHooked(hook, tc.pod, appspub.LifecycleStatePreparingDelete); got != tc.expectAllHook {
+				t.Fatalf("expected IsPodAllHooked %v, got %v", tc.expectAllHook, got)
+			}
+		})
+	}
+}
+
+func TestExecuteHTTPHook(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC)
+	httpHookNow = func() time.Time { return now }
+	allowedHosts := httphook.AllowedHosts
+	httphook.AllowedHosts = "127.0.0.1"
+	defer func() {
+		httpHookNow = time.Now
+		httphook.AllowedHosts = allowedHosts
+	}()
+
+	var calls int32
+	var gotRequest HTTPHookRequest
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewDecoder(r.Body).Decode(&gotRequest)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name             string
+		pod              *corev1.Pod
+		handler          *appspub.LifecycleHTTPHandler
+		statusCode       int
+		expectCalls      int32
+		expectUpdated    bool
+		expectCompleted  bool
+		expectFailures   int32
+		expectRetryAfter time.Duration
+	}{
+		{
+			name:             "call succeeded",
+			pod:              newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, nil),
+			handler:          &appspub.LifecycleHTTPHandler{URL: server.URL},
+			statusCode:       http.StatusOK,
+			expectCalls:      1,
+			expectUpdated:    true,
+			expectCompleted:  true,
+			expectRetryAfter: 10 * time.Second,
+		},
+		{
+			name: "already completed",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2025-01-01T00:00:00Z", Completed: true,
+			}),
+			handler:    &appspub.LifecycleHTTPHandler{URL: server.URL},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:             "call failed",
+			pod:              newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, nil),
+			handler:          &appspub.LifecycleHTTPHandler{URL: server.URL, TimeoutSeconds: ptr.To[int32](5)},
+			statusCode:       http.StatusInternalServerError,
+			expectCalls:      1,
+			expectUpdated:    true,
+			expectFailures:   1,
+			expectRetryAfter: 5 * time.Second,
+		},
+		{
+			name: "in backoff",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2025-01-01T00:00:00Z",
+				Failures: 2, LastCallTime: now.Add(-4 * time.Second).Format(time.RFC3339),
+			}),
+			handler:          &appspub.LifecycleHTTPHandler{URL: server.URL},
+			statusCode:       http.StatusOK,
+			expectRetryAfter: 6 * time.Second,
+		},
+		{
+			name: "retries exhausted with Proceed",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2025-01-01T00:00:00Z",
+				Failures: 1, LastCallTime: now.Add(-time.Minute).Format(time.RFC3339),
+			}),
+			handler: &appspub.LifecycleHTTPHandler{
+				URL: server.URL, RetryLimit: ptr.To[int32](1), FailurePolicy: appspub.LifecycleHookFailurePolicyProceed,
+			},
+			statusCode:       http.StatusInternalServerError,
+			expectCalls:      1,
+			expectUpdated:    true,
+			expectCompleted:  true,
+			expectFailures:   2,
+			expectRetryAfter: 10 * time.Second,
+		},
+		{
+			name: "retries exhausted with Block",
+			pod: newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, &appspub.LifecycleHTTPHookStatus{
+				State: appspub.LifecycleStatePreparingDelete, Timestamp: "2025-01-01T00:00:00Z",
+				Failures: 1, LastCallTime: now.Add(-time.Minute).Format(time.RFC3339),
+			}),
+			handler: &appspub.LifecycleHTTPHandler{
+				URL: server.URL, RetryLimit: ptr.To[int32](1), FailurePolicy: appspub.LifecycleHookFailurePolicyBlock,
+			},
+			statusCode:       http.StatusInternalServerError,
+			expectCalls:      1,
+			expectUpdated:    true,
+			expectFailures:   2,
+			expectRetryAfter: 10 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			statusCode = tc.statusCode
+			tc.pod.UID = "pod-uid"
+			kubeClient := fake.NewSimpleClientset(tc.pod)
+			c := &realControl{adp: &podadapter.AdapterTypedClient{Client: kubeClient}}
+
+			updated, gotPod, retryAfter, err := c.ExecuteHTTPHook(tc.pod, &appspub.LifecycleHook{HTTPHandler: tc.handler})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			getHTTPHookExecutor().Wait()
+			if got := atomic.LoadInt32(&calls); got != tc.expectCalls {
+				t.Fatalf("expected %d calls, got %d", tc.expectCalls, got)
+			}
+			if updated != tc.expectUpdated {
+				t.Fatalf("expected updated %v, got %v", tc.expectUpdated, updated)
+			}
+			if retryAfter != tc.expectRetryAfter {
+				t.Fatalf("expected retryAfter %v, got %v", tc.expectRetryAfter, retryAfter)
+			}
+			if !updated {
+				return
+			}
+			expectRequest := HTTPHookRequest{Namespace: "default", Name: tc.pod.Name, UID: "pod-uid", State: appspub.LifecycleStatePreparingDelete}
+			if gotRequest != expectRequest {
+				t.Fatalf("unexpected request %+v", gotRequest)
+			}
+			if status := GetHTTPHookStatus(gotPod); status == nil || status.LastCallTime != now.Format(time.RFC3339) {
+				t.Fatalf("expected call time recorded before the call, got %+v", status)
+			}
+
+			pod, err := kubeClient.CoreV1().Pods(tc.pod.Namespace).Get(context.TODO(), tc.pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			status := GetHTTPHookStatus(pod)
+			if status == nil {
+				t.Fatalf("expected http hook status recorded")
+			}
+			if status.Completed != tc.expectCompleted || status.Failures != tc.expectFailures {
+				t.Fatalf("unexpected status %+v", status)
+			}
+			if IsPodHooked(&appspub.LifecycleHook{HTTPHandler: tc.handler}, pod, appspub.LifecycleStatePreparingDelete) == tc.expectCompleted {
+				t.Fatalf("expected IsPodHooked %v", !tc.expectCompleted)
+			}
+		})
+	}
+}
+
+func TestGetPodLifecycleHook(t *testing.T) {
+	lc := &appspub.Lifecycle{
+		PreDelete:     &appspub.LifecycleHook{FinalizersHandler: []string{"pre-delete"}},
+		InPlaceUpdate: &appspub.LifecycleHook{FinalizersHandler: []string{"in-place-update"}},
+		PreNormal:     &appspub.LifecycleHook{FinalizersHandler: []string{"pre-normal"}},
+	}
+	cases := map[appspub.LifecycleStateType]*appspub.LifecycleHook{
+		appspub.LifecycleStatePreparingNormal: lc.PreNormal,
+		appspub.LifecycleStateNormal:          nil,
+		appspub.LifecycleStatePreparingUpdate: lc.InPlaceUpdate,
+		appspub.LifecycleStateUpdating:        nil,
+		appspub.LifecycleStateUpdated:         lc.InPlaceUpdate,
+		appspub.LifecycleStatePreparingDelete: lc.PreDelete,
+	}
+	for state, expected := range cases {
+		if got := GetPodLifecycleHook(lc, newHTTPHookTestPod(state, nil)); got != expected {
+			t.Fatalf("state %s: expected hook %v, got %v", state, expected, got)
+		}
+	}
+	if got := GetPodLifecycleHook(nil, newHTTPHookTestPod(appspub.LifecycleStatePreparingDelete, nil)); got != nil {
+		t.Fatalf("expected nil hook for nil lifecycle, got %v", got)
+	}
+}
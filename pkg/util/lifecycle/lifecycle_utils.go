@@ -44,6 +44,9 @@ const (
 type Interface interface {
 	UpdatePodLifecycle(pod *v1.Pod, state appspub.LifecycleStateType, markPodNotReady bool) (bool, *v1.Pod, error)
 	UpdatePodLifecycleWithHandler(pod *v1.Pod, state appspub.LifecycleStateType, inPlaceUpdateHandler *appspub.LifecycleHook) (bool, *v1.Pod, error)
+	ExecuteHTTPHook(pod *v1.Pod, hook *appspub.LifecycleHook) (bool, *v1.Pod, time.Duration, error)
+	MarkPodHookTimedOut(pod *v1.Pod, hook *appspub.LifecycleHook) (bool, error)
+	RemovePodHookFinalizers(pod *v1.Pod, hook *appspub.LifecycleHook) (bool, *v1.Pod, error)
 }
 
 type realControl struct {
@@ -210,10 +213,16 @@ func (c *realControl) UpdatePodLifecycleWithHandler(pod *v1.Pod, state appspub.L
 	return true, gotPod, err
 }
 
-func IsPodHooked(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
+// IsPodHooked returns true if any handler of hook holds pod, where state is the lifecycle state in which pod waits for the hook.
+// The HTTPHandler holds pod until it completes in state, and no handler holds pod once it times out in state with Proceed action.
+func IsPodHooked(hook *appspub.LifecycleHook, pod *v1.Pod, state appspub.LifecycleStateType) bool {
 	if hook == nil || pod == nil {
 		return false
 	}
+	waiting := GetPodLifecycleState(pod) == state
+	if waiting && isHookTimedOutToProceed(hook, pod) {
+		return false
+	}
 	for _, f := range hook.FinalizersHandler {
 		if controllerutil.ContainsFinalizer(pod, f) {
 			return true
@@ -224,13 +233,22 @@ func IsPodHooked(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
 			return true
 		}
 	}
+	if hook.HTTPHandler != nil && !(waiting && IsHTTPHookCompleted(pod)) {
+		return true
+	}
 	return false
 }
 
-func IsPodAllHooked(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
+// IsPodAllHooked returns true if all handlers of hook are satisfied, where state is the lifecycle state in which pod waits for the hook.
+// The HTTPHandler is satisfied only if it completes in state, and all handlers are satisfied once pod times out in state with Proceed action.
+func IsPodAllHooked(hook *appspub.LifecycleHook, pod *v1.Pod, state appspub.LifecycleStateType) bool {
 	if hook == nil || pod == nil {
 		return false
 	}
+	waiting := GetPodLifecycleState(pod) == state
+	if waiting && isHookTimedOutToProceed(hook, pod) {
+		return true
+	}
 	for _, f := range hook.FinalizersHandler {
 		if !controllerutil.ContainsFinalizer(pod, f) {
 			return false
@@ -241,6 +259,9 @@ func IsPodAllHooked(hook *appspub.LifecycleHook, pod *v1.Pod) bool {
 			return false
 		}
 	}
+	if hook.HTTPHandler != nil && !(waiting && IsHTTPHookCompleted(pod)) {
+		return false
+	}
 	return true
 }
 
@@ -416,7 +416,7 @@ func TestIsPodHooked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := IsPodHooked(tt.args.hook, tt.args.pod); got != tt.want {
+			if got := IsPodHooked(tt.args.hook, tt.args.pod, appspub.LifecycleStatePreparingDelete); got != tt.want {
 				t.Errorf("IsPodHooked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -517,7 +517,7 @@ func TestIsPodAllHooked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := IsPodAllHooked(tt.args.hook, tt.args.pod); got != tt.want {
+			if got := IsPodAllHooked(tt.args.hook, tt.args.pod, appspub.LifecycleStatePreparingNormal); got != tt.want {
 				t.Errorf("IsPodAllHooked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	KindCloneSet          = "CloneSet"
+	KindStatefulSet       = "StatefulSet"
+	KindDaemonSet         = "DaemonSet"
+	KindSidecarSet        = "SidecarSet"
+	KindUnitedDeployment  = "UnitedDeployment"
+	reasonTimeout         = "Timeout"
+	reasonCanceled        = "Canceled"
+	reasonOther           = "Other"
+	replicasTypeDesired   = "desired"
+	replicasTypeUpdated   = "updated"
+	replicasTypeReady     = "ready"
+	replicasTypeAvailable = "available"
+)
+
+var (
+	workloadReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kruise_workload_replicas",
+		Help: "Number of desired, updated, ready and available replicas of Kruise workloads",
+	}, []string{"kind", "namespace", "name", "type"})
+	workloadRevision = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kruise_workload_revision",
+		Help: "Current and update revisions of Kruise workloads, the value is always 1",
+	}, []string{"kind", "namespace", "name", "current_revision", "update_revision"})
+	workloadPaused = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kruise_workload_paused",
+		Help: "Whether the rollout of Kruise workloads is paused",
+	}, []string{"kind", "namespace", "name"})
+	workloadRolloutStartTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kruise_workload_rollout_start_time_seconds",
+		Help: "Unix timestamp when the rollout of the update revision started, 0 if the rollout has completed",
+	}, []string{"kind", "namespace", "name"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kruise_controller_reconcile_errors_total",
+		Help: "Total number of reconcile errors of Kruise controllers by reason",
+	}, []string{"controller", "reason"})
+
+	// rolloutStarts records when the rollout of the update revision of each workload was first observed.
+	rolloutStarts   = map[string]rolloutStart{}
+	rolloutStartsMu sync.Mutex
+
+	nowFunc = time.Now
+
+	// apiReasons are the API status reasons counted as they are, any other error is counted as Other.
+	apiReasons = map[metav1.StatusReason]bool{
+		metav1.StatusReasonConflict:           true,
+		metav1.StatusReasonNotFound:           true,
+		metav1.StatusReasonAlreadyExists:      true,
+		metav1.StatusReasonForbidden:          true,
+		metav1.StatusReasonUnauthorized:       true,
+		metav1.StatusReasonInvalid:            true,
+		metav1.StatusReasonBadRequest:         true,
+		metav1.StatusReasonTooManyRequests:    true,
+		metav1.StatusReasonTimeout:            true,
+		metav1.StatusReasonServerTimeout:      true,
+		metav1.StatusReasonServiceUnavailable: true,
+		metav1.StatusReasonInternalError:      true,
+	}
+)
+
+type rolloutStart struct {
+	revision string
+	time     time.Time
+}
+
+func init() {
+	metrics.Registry.MustRegister(workloadReplicas, workloadRevision, workloadPaused, workloadRolloutStartTime, reconcileErrors)
+}
+
+// Status is the rollout status of a workload.
+type Status struct {
+	Desired int32
+	Updated int32
+	Ready   int32
+	// Available is nil for workloads that have no availability in status.
+	Available *int32
+
+	// CurrentRevision may be empty for workloads that only track the update revision.
+	CurrentRevision string
+	UpdateRevision  string
+	Paused          bool
+}
+
+// RolloutCompleted returns true if all desired replicas have been updated to the update revision.
+func (s *Status) RolloutCompleted() bool {
+	if s.Updated < s.Desired {
+		return false
+	}
+	return s.CurrentRevision == "" || s.CurrentRevision == s.UpdateRevision
+}
+
+// RecordStatus exports the status of a workload.
+func RecordStatus(kind, namespace, name string, status Status) {
+	workloadReplicas.WithLabelValues(kind, namespace, name, replicasTypeDesired).Set(float64(status.Desired))
+	workloadReplicas.WithLabelValues(kind, namespace, name, replicasTypeUpdated).Set(float64(status.Updated))
+	workloadReplicas.WithLabelValues(kind, namespace, name, replicasTypeReady).Set(float64(status.Ready))
+	if status.Available != nil {
+		workloadReplicas.WithLabelValues(kind, namespace, name, replicasTypeAvailable).Set(float64(*status.Available))
+	}
+
+	workloadRevision.DeletePartialMatch(prometheus.Labels{"kind": kind, "namespace": namespace, "name": name})
+	workloadRevision.WithLabelValues(kind, namespace, name, status.CurrentRevision, status.UpdateRevision).Set(1)
+
+	var paused float64
+	if status.Paused {
+		paused = 1
+	}
+	workloadPaused.WithLabelValues(kind, namespace, name).Set(paused)
+
+	var startTime float64
+	if start := observeRollout(kind, namespace, name, &status); !start.IsZero() {
+		startTime = float64(start.Unix())
+	}
+	workloadRolloutStartTime.WithLabelValues(kind, namespace, name).Set(startTime)
+}
+
+// observeRollout returns when the rollout of the update revision started, or zero time if it has completed.
+// The start of a rollout is the first time it is observed, so it restarts with kruise-manager.
+func observeRollout(kind, namespace, name string, status *Status) time.Time {
+	key := getKey(kind, namespace, name)
+	rolloutStartsMu.Lock()
+	defer rolloutStartsMu.Unlock()
+	if status.RolloutCompleted() {
+		delete(rolloutStarts, key)
+		return time.Time{}
+	}
+	start, ok := rolloutStarts[key]
+	if !ok || start.revision != status.UpdateRevision {
+		start = rolloutStart{revision: status.UpdateRevision, time: nowFunc()}
+		rolloutStarts[key] = start
+	}
+	return start.time
+}
+
+// DeleteStatus removes the exported status of a deleted workload.
+func DeleteStatus(kind, namespace, name string) {
+	labels := prometheus.Labels{"kind": kind, "namespace": namespace, "name": name}
+	workloadReplicas.DeletePartialMatch(labels)
+	workloadRevision.DeletePartialMatch(labels)
+	workloadPaused.Delete(labels)
+	workloadRolloutStartTime.Delete(labels)
+
+	rolloutStartsMu.Lock()
+	defer rolloutStartsMu.Unlock()
+	delete(rolloutStarts, getKey(kind, namespace, name))
+}
+
+// RecordReconcileError counts a reconcile error of controller by its reason.
+func RecordReconcileError(controller string, err error) {
+	if err == nil {
+		return
+	}
+	reconcileErrors.WithLabelValues(controller, classifyError(err)).Inc()
+}
+
+// classifyError returns a bounded reason of err. Errors wrapped by fmt.Errorf are classified by
+// the error they wrap, and an aggregate error is classified by its first error.
+func classifyError(err error) string {
+	var agg utilerrors.Aggregate
+	if errors.As(err, &agg) && len(agg.Errors()) > 0 {
+		return classifyError(agg.Errors()[0])
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return reasonTimeout
+	case errors.Is(err, context.Canceled):
+		return reasonCanceled
+	}
+	if reason := apierrors.ReasonForError(err); apiReasons[reason] {
+		return string(reason)
+	}
+	return reasonOther
+}
+
+func getKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
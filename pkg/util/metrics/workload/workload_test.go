@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+)
+
+func TestRecordStatus(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	status := Status{
+		Desired:         5,
+		Updated:         2,
+		Ready:           4,
+		Available:       ptr.To[int32](3),
+		CurrentRevision: "rev-1",
+		UpdateRevision:  "rev-2",
+		Paused:          true,
+	}
+	RecordStatus(KindCloneSet, "default", "demo", status)
+	if got := testutil.ToFloat64(workloadReplicas.WithLabelValues(KindCloneSet, "default", "demo", replicasTypeAvailable)); got != 3 {
+		t.Fatalf("expected 3 available replicas, got %v", got)
+	}
+	if got := testutil.ToFloat64(workloadPaused.WithLabelValues(KindCloneSet, "default", "demo")); got != 1 {
+		t.Fatalf("expected paused, got %v", got)
+	}
+
+	started := now
+	now = now.Add(time.Minute)
+	RecordStatus(KindCloneSet, "default", "demo", status)
+	if got := testutil.ToFloat64(workloadRolloutStartTime.WithLabelValues(KindCloneSet, "default", "demo")); got != float64(started.Unix()) {
+		t.Fatalf("expected rollout started at %v, got %v", started.Unix(), got)
+	}
+
+	// a new update revision restarts the rollout
+	status.UpdateRevision = "rev-3"
+	now = now.Add(time.Minute)
+	RecordStatus(KindCloneSet, "default", "demo", status)
+	if got := testutil.ToFloat64(workloadRolloutStartTime.WithLabelValues(KindCloneSet, "default", "demo")); got != float64(now.Unix()) {
+		t.Fatalf("expected rollout started at %v, got %v", now.Unix(), got)
+	}
+	if got := testutil.CollectAndCount(workloadRevision); got != 1 {
+		t.Fatalf("expected 1 revision series, got %v", got)
+	}
+
+	status.Updated = 5
+	status.CurrentRevision = "rev-3"
+	now = now.Add(time.Minute)
+	RecordStatus(KindCloneSet, "default", "demo", status)
+	if got := testutil.ToFloat64(workloadRolloutStartTime.WithLabelValues(KindCloneSet, "default", "demo")); got != 0 {
+		t.Fatalf("expected rollout completed, got %v", got)
+	}
+
+	DeleteStatus(KindCloneSet, "default", "demo")
+	if got := testutil.CollectAndCount(workloadReplicas) + testutil.CollectAndCount(workloadRevision) +
+		testutil.CollectAndCount(workloadPaused) + testutil.CollectAndCount(workloadRolloutStartTime); got != 0 {
+		t.Fatalf("expected metrics deleted, got %v series", got)
+	}
+	if len(rolloutStarts) != 0 {
+		t.Fatalf("expected rollout starts deleted, got %v", rolloutStarts)
+	}
+}
+
+func TestRecordReconcileError(t *testing.T) {
+	RecordReconcileError("cloneset", nil)
+	RecordReconcileError("cloneset", errors.NewConflict(schema.GroupResource{Resource: "clonesets"}, "demo", fmt.Errorf("conflict")))
+	RecordReconcileError("cloneset", fmt.Errorf("failed"))
+	if got := testutil.ToFloat64(reconcileErrors.WithLabelValues("cloneset", "Conflict")); got != 1 {
+		t.Fatalf("expected 1 Conflict error, got %v", got)
+	}
+	if got := testutil.ToFloat64(reconcileErrors.WithLabelValues("cloneset", reasonOther)); got != 1 {
+		t.Fatalf("expected 1 Other error, got %v", got)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	conflict := errors.NewConflict(schema.GroupResource{Resource: "clonesets"}, "demo", fmt.Errorf("conflict"))
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "api error", err: errors.NewNotFound(schema.GroupResource{Resource: "pods"}, "demo"), want: "NotFound"},
+		{name: "wrapped api error", err: fmt.Errorf("failed to update: %w", conflict), want: "Conflict"},
+		{name: "aggregate error", err: utilerrors.NewAggregate([]error{conflict, fmt.Errorf("failed")}), want: "Conflict"},
+		{name: "deadline exceeded", err: fmt.Errorf("failed to list: %w", context.DeadlineExceeded), want: reasonTimeout},
+		{name: "canceled", err: context.Canceled, want: reasonCanceled},
+		{name: "unbounded api reason", err: &errors.StatusError{ErrStatus: metav1.Status{Reason: "SomethingNew"}}, want: reasonOther},
+		{name: "other error", err: fmt.Errorf("failed"), want: reasonOther},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classifyError(tc.err); got != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
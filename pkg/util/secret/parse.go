@@ -2,6 +2,7 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,9 @@ func AuthInfos(ctx context.Context, imageName, tag string, pullSecrets []corev1.
 
 var (
 	keyring credentialprovider.DockerKeyring
+
+	// ErrKeyring is the kind of error when failed to make docker keyring from pull secrets.
+	ErrKeyring = errors.New("failed to make docker keyring")
 )
 
 // make and set new docker keyring
@@ -39,7 +43,7 @@ func ConvertToRegistryAuths(pullSecrets []corev1.Secret, repo string) (infos []d
 	}
 	keyring, err := credentialprovidersecrets.MakeDockerKeyring(pullSecrets, keyring)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrKeyring, err)
 	}
 	creds, withCredentials := keyring.Lookup(repo)
 	if !withCredentials {
@@ -19,6 +19,7 @@ package secret
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -114,6 +115,19 @@ func TestConvertToRegistryAuths(t *testing.T) {
 			expectError: false,
 			expectEmpty: false,
 		},
+		{
+			name: "invalid docker config secret",
+			pullSecrets: []corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "docker-secret"},
+					Type:       corev1.SecretTypeDockerConfigJson,
+					Data:       map[string][]byte{".dockerconfigjson": []byte("invalid")},
+				},
+			},
+			repo:        "docker.io",
+			expectError: true,
+			expectEmpty: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,8 +136,8 @@ func TestConvertToRegistryAuths(t *testing.T) {
 
 			result, err := ConvertToRegistryAuths(tt.pullSecrets, tt.repo)
 
-			if tt.expectError && err == nil {
-				t.Error("ConvertToRegistryAuths() expected error, got nil")
+			if tt.expectError && !errors.Is(err, ErrKeyring) {
+				t.Errorf("ConvertToRegistryAuths() expected keyring error, got %v", err)
 			}
 			if !tt.expectError && err != nil {
 				t.Errorf("ConvertToRegistryAuths() unexpected error: %v", err)
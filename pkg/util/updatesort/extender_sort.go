@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updatesort
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+const (
+	defaultExtenderTimeout = time.Second
+	maxExtenderTimeout     = 10 * time.Second
+
+	// extenderCacheTTL is the max duration to reuse the ranking of extender for the same candidates.
+	extenderCacheTTL = 5 * time.Minute
+)
+
+var (
+	extenderCache = &extenderRankCache{entries: make(map[string][]*extenderRankEntry)}
+	extenderNow   = time.Now
+)
+
+// ExtenderRequest is the body POSTed to the HTTPHandler of UpdatePriorityExtender.
+type ExtenderRequest struct {
+	Pods []ExtenderPod `json:"pods"`
+}
+
+// ExtenderPod is the name of a pod waiting for update.
+type ExtenderPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ExtenderResponse is the body responded by the HTTPHandler of UpdatePriorityExtender.
+type ExtenderResponse struct {
+	// PodNames are the names of pods in update order.
+	PodNames []string `json:"podNames"`
+}
+
+// extenderRankCache caches the rankings of each extender, so that the extender is called once
+// for the pods of a revision rather than every reconcile.
+type extenderRankCache struct {
+	sync.Mutex
+	entries map[string][]*extenderRankEntry
+}
+
+type extenderRankEntry struct {
+	// candidates are the pods ranked, from namespace/name to uid/revision
+	candidates map[string]string
+	ranks      map[string]int
+	expireAt   time.Time
+}
+
+func getCandidateName(pod *v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+func getCandidateRevision(pod *v1.Pod) string {
+	return string(pod.UID) + "/" + pod.Labels[apps.ControllerRevisionHashLabelKey]
+}
+
+// covers returns true if all the candidates have been ranked in this entry with the same revision.
+func (e *extenderRankEntry) covers(candidates []*v1.Pod) bool {
+	for _, pod := range candidates {
+		if revision, ok := e.candidates[getCandidateName(pod)]; !ok || revision != getCandidateRevision(pod) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps returns true if any of the candidates has been ranked in this entry.
+func (e *extenderRankEntry) overlaps(candidates []*v1.Pod) bool {
+	for _, pod := range candidates {
+		if _, ok := e.candidates[getCandidateName(pod)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *extenderRankCache) get(url string, candidates []*v1.Pod) (map[string]int, bool) {
+	c.Lock()
+	defer c.Unlock()
+	now := extenderNow()
+	for _, entry := range c.entries[url] {
+		if now.Before(entry.expireAt) && entry.covers(candidates) {
+			return entry.ranks, true
+		}
+	}
+	return nil, false
+}
+
+// set records the ranking of candidates, and removes the expired entries and the ones ranked any of the candidates.
+func (c *extenderRankCache) set(url string, candidates []*v1.Pod, ranks map[string]int) {
+	c.Lock()
+	defer c.Unlock()
+	now := extenderNow()
+	for key, entries := range c.entries {
+		var kept []*extenderRankEntry
+		for _, entry := range entries {
+			if now.Before(entry.expireAt) && (key != url || !entry.overlaps(candidates)) {
+				kept = append(kept, entry)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.entries, key)
+		} else {
+			c.entries[key] = kept
+		}
+	}
+
+	entry := &extenderRankEntry{
+		candidates: make(map[string]string, len(candidates)),
+		ranks:      ranks,
+		expireAt:   now.Add(extenderCacheTTL),
+	}
+	for _, pod := range candidates {
+		entry.candidates[getCandidateName(pod)] = getCandidateRevision(pod)
+	}
+	c.entries[url] = append(c.entries[url], entry)
+}
+
+type extenderSort struct {
+	extender *appspub.UpdatePriorityExtender
+}
+
+func NewExtenderSorter(e *appspub.UpdatePriorityExtender) Sorter {
+	return &extenderSort{extender: e}
+}
+
+// Sort helps sort the indexes of pods by UpdatePriorityExtender.
+// The indexes keep their order if the extender fails.
+func (es *extenderSort) Sort(pods []*v1.Pod, indexes []int) []int {
+	if es.extender == nil || len(indexes) <= 1 {
+		return indexes
+	}
+
+	var ranks map[string]int
+	if es.extender.HTTPHandler != nil {
+		candidates := make([]*v1.Pod, 0, len(indexes))
+		for _, idx := range indexes {
+			candidates = append(candidates, pods[idx])
+		}
+		var err error
+		if ranks, err = getExtenderRanks(es.extender.HTTPHandler, candidates); err != nil {
+			klog.ErrorS(err, "Failed to call update priority extender, ignore it", "url", es.extender.HTTPHandler.URL)
+			return indexes
+		}
+	}
+
+	getRank := func(pod *v1.Pod) int {
+		if es.extender.HTTPHandler != nil {
+			if rank, ok := ranks[pod.Name]; ok {
+				return rank
+			}
+			return len(ranks)
+		}
+		for _, c := range pod.Status.Conditions {
+			if string(c.Type) == es.extender.PodConditionType && c.Status == v1.ConditionTrue {
+				return 1
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return getRank(pods[indexes[i]]) < getRank(pods[indexes[j]])
+	})
+	return indexes
+}
+
+func getExtenderTimeout(handler *appspub.UpdatePriorityHTTPHandler) time.Duration {
+	if handler.TimeoutSeconds == nil || *handler.TimeoutSeconds <= 0 {
+		return defaultExtenderTimeout
+	}
+	if timeout := time.Duration(*handler.TimeoutSeconds) * time.Second; timeout < maxExtenderTimeout {
+		return timeout
+	}
+	return maxExtenderTimeout
+}
+
+// getExtenderRanks returns the ranks of candidates from the cache, or calls the extender if any of the
+// candidates has not been ranked in the same revision.
+func getExtenderRanks(handler *appspub.UpdatePriorityHTTPHandler, candidates []*v1.Pod) (map[string]int, error) {
+	if ranks, ok := extenderCache.get(handler.URL, candidates); ok {
+		return ranks, nil
+	}
+
+	request := &ExtenderRequest{Pods: make([]ExtenderPod, 0, len(candidates))}
+	for _, pod := range candidates {
+		request.Pods = append(request.Pods, ExtenderPod{Namespace: pod.Namespace, Name: pod.Name})
+	}
+	response := &ExtenderResponse{}
+	if err := httphook.Post(&httphook.Request{URL: handler.URL, Body: request, Timeout: getExtenderTimeout(handler)}, response); err != nil {
+		return nil, err
+	}
+	ranks := make(map[string]int, len(response.PodNames))
+	for i, name := range response.PodNames {
+		if _, ok := ranks[name]; !ok {
+			ranks[name] = i
+		}
+	}
+	extenderCache.set(handler.URL, candidates, ranks)
+	return ranks, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updatesort
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+func newExtenderTestPods(leader string) []*v1.Pod {
+	var pods []*v1.Pod
+	for _, name := range []string{"pod-0", "pod-1", "pod-2", "pod-3"} {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"order": name}}}
+		if name == leader {
+			pod.Status.Conditions = []v1.PodCondition{{Type: "Leader", Status: v1.ConditionTrue}}
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+func resetExtenderCache() {
+	extenderCache = &extenderRankCache{entries: make(map[string][]*extenderRankEntry)}
+}
+
+func TestExtenderSort(t *testing.T) {
+	allowedHosts := httphook.AllowedHosts
+	httphook.AllowedHosts = "127.0.0.1"
+	defer func() { httphook.AllowedHosts = allowedHosts }()
+
+	var gotRequest ExtenderRequest
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = ExtenderRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&gotRequest)
+		if statusCode != http.StatusOK {
+			w.WriteHeader(statusCode)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ExtenderResponse{PodNames: []string{"pod-x", "pod-1", "pod-3"}})
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name       string
+		strategy   *appspub.UpdatePriorityStrategy
+		statusCode int
+		indexes    []int
+		expected   []int
+	}{
+		{
+			name: "http handler",
+			strategy: &appspub.UpdatePriorityStrategy{
+				Extender: &appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: server.URL}},
+			},
+			statusCode: http.StatusOK,
+			indexes:    []int{0, 1, 2, 3},
+			expected:   []int{1, 3, 0, 2},
+		},
+		{
+			name: "http handler with order priority",
+			strategy: &appspub.UpdatePriorityStrategy{
+				OrderPriority: []appspub.UpdatePriorityOrderTerm{{OrderedKey: "order"}},
+				Extender:      &appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: server.URL}},
+			},
+			statusCode: http.StatusOK,
+			indexes:    []int{0, 1, 2, 3},
+			expected:   []int{1, 3, 2, 0},
+		},
+		{
+			name: "http handler failed",
+			strategy: &appspub.UpdatePriorityStrategy{
+				OrderPriority: []appspub.UpdatePriorityOrderTerm{{OrderedKey: "order"}},
+				Extender:      &appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: server.URL}},
+			},
+			statusCode: http.StatusInternalServerError,
+			indexes:    []int{0, 1, 2, 3},
+			expected:   []int{3, 2, 1, 0},
+		},
+		{
+			name: "pod condition",
+			strategy: &appspub.UpdatePriorityStrategy{
+				Extender: &appspub.UpdatePriorityExtender{PodConditionType: "Leader"},
+			},
+			indexes:  []int{0, 1, 2, 3},
+			expected: []int{1, 2, 3, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resetExtenderCache()
+			statusCode = tc.statusCode
+			pods := newExtenderTestPods("pod-0")
+			got := NewPrioritySorter(tc.strategy).Sort(pods, tc.indexes)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+			if tc.strategy.Extender.HTTPHandler != nil && len(gotRequest.Pods) != len(tc.indexes) {
+				t.Fatalf("unexpected request %v", gotRequest)
+			}
+		})
+	}
+}
+
+func TestExtenderSortCache(t *testing.T) {
+	allowedHosts := httphook.AllowedHosts
+	httphook.AllowedHosts = "127.0.0.1"
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	extenderNow = func() time.Time { return now }
+	defer func() {
+		httphook.AllowedHosts = allowedHosts
+		extenderNow = time.Now
+		resetExtenderCache()
+	}()
+	resetExtenderCache()
+
+	var calls int
+	var gotRequest ExtenderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotRequest = ExtenderRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&gotRequest)
+		_ = json.NewEncoder(w).Encode(&ExtenderResponse{PodNames: []string{"pod-3", "pod-2", "pod-1", "pod-0"}})
+	}))
+	defer server.Close()
+
+	sorter := NewExtenderSorter(&appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: server.URL}})
+	pods := newExtenderTestPods("")
+	for _, pod := range pods {
+		pod.Namespace = "default"
+	}
+
+	if got := sorter.Sort(pods, []int{0, 1, 2, 3}); !reflect.DeepEqual(got, []int{3, 2, 1, 0}) || calls != 1 {
+		t.Fatalf("unexpected order %v with %d calls", got, calls)
+	}
+	if expected := []ExtenderPod{{"default", "pod-0"}, {"default", "pod-1"}, {"default", "pod-2"}, {"default", "pod-3"}}; !reflect.DeepEqual(gotRequest.Pods, expected) {
+		t.Fatalf("expected request pods %v, got %v", expected, gotRequest.Pods)
+	}
+
+	// reuse the ranking for the same or less candidates
+	if got := sorter.Sort(pods, []int{0, 1, 2, 3}); !reflect.DeepEqual(got, []int{3, 2, 1, 0}) || calls != 1 {
+		t.Fatalf("unexpected order %v with %d calls", got, calls)
+	}
+	if got := sorter.Sort(pods, []int{0, 2}); !reflect.DeepEqual(got, []int{2, 0}) || calls != 1 {
+		t.Fatalf("unexpected order %v with %d calls", got, calls)
+	}
+
+	// call again if a candidate has been recreated or the ranking expired
+	pods[1].UID = "new-uid"
+	if sorter.Sort(pods, []int{0, 1}); calls != 2 {
+		t.Fatalf("expected extender called again for the recreated pod, got %d calls", calls)
+	}
+	now = now.Add(extenderCacheTTL)
+	if sorter.Sort(pods, []int{0, 1}); calls != 3 {
+		t.Fatalf("expected extender called again after expired, got %d calls", calls)
+	}
+}
+
+func TestExtenderFieldsValidation(t *testing.T) {
+	cases := []struct {
+		name      string
+		extender  *appspub.UpdatePriorityExtender
+		expectErr bool
+	}{
+		{
+			name:     "pod condition",
+			extender: &appspub.UpdatePriorityExtender{PodConditionType: "Leader"},
+		},
+		{
+			name:     "http handler",
+			extender: &appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: "http://ranker.default.svc"}},
+		},
+		{
+			name:      "empty",
+			extender:  &appspub.UpdatePriorityExtender{},
+			expectErr: true,
+		},
+		{
+			name: "both",
+			extender: &appspub.UpdatePriorityExtender{
+				PodConditionType: "Leader",
+				HTTPHandler:      &appspub.UpdatePriorityHTTPHandler{URL: "http://ranker.default.svc"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "relative url",
+			extender:  &appspub.UpdatePriorityExtender{HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: "/rank"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := &appspub.UpdatePriorityStrategy{Extender: tc.extender}
+			if err := strategy.FieldsValidation(); (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -37,10 +37,17 @@ func NewPrioritySorter(s *appspub.UpdatePriorityStrategy) Sorter {
 
 // Sort helps sort the indexes of pods by UpdatePriorityStrategy.
 func (ps *prioritySort) Sort(pods []*v1.Pod, indexes []int) []int {
-	if ps.strategy == nil || (len(ps.strategy.WeightPriority) == 0 && len(ps.strategy.OrderPriority) == 0) {
+	if ps.strategy == nil {
 		return indexes
 	}
+	if len(ps.strategy.WeightPriority) > 0 || len(ps.strategy.OrderPriority) > 0 {
+		indexes = ps.sortByTerms(pods, indexes)
+	}
+	// the order by extender comes first, and the order by terms is kept among pods with the same rank
+	return NewExtenderSorter(ps.strategy.Extender).Sort(pods, indexes)
+}
 
+func (ps *prioritySort) sortByTerms(pods []*v1.Pod, indexes []int) []int {
 	f := func(i, j int) bool {
 		podI := pods[indexes[i]]
 		podJ := pods[indexes[j]]
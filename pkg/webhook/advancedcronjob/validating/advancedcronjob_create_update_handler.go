@@ -51,6 +51,10 @@ const (
 	validateAdvancedCronJobNameMsg = "AdvancedCronJob name must consist of alphanumeric characters or '-'"
 	validAdvancedCronJobNameFmt    = `^[a-zA-Z0-9\-]+$`
 	MaxActiveDeadLineSeconds       = 3600 * 24
+	// MaxRunHistoryLimit is the max number of runs recorded in status.
+	MaxRunHistoryLimit = 100
+	// MaxBackfill is the max number of missed schedules to backfill, the controller considers at most 100 missed schedules.
+	MaxBackfill = 99
 )
 
 var (
@@ -83,6 +87,33 @@ func validateAdvancedCronJobSpec(spec *appsv1beta1.AdvancedCronJobSpec, fldPath
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.FailedJobsHistoryLimit), fldPath.Child("failedJobsHistoryLimit"))...)
 	}
 	allErrs = append(allErrs, validateTimeZone(spec.TimeZone, fldPath.Child("timeZone"))...)
+	allErrs = append(allErrs, validateMissedSchedulePolicy(spec.MissedSchedulePolicy, fldPath.Child("missedSchedulePolicy"))...)
+	if spec.RunHistoryLimit != nil && (*spec.RunHistoryLimit < 0 || *spec.RunHistoryLimit > MaxRunHistoryLimit) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("runHistoryLimit"), *spec.RunHistoryLimit,
+			fmt.Sprintf("must be between 0 and %d", MaxRunHistoryLimit)))
+	}
+	return allErrs
+}
+
+func validateMissedSchedulePolicy(policy *appsv1beta1.MissedSchedulePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	switch policy.Type {
+	case "", appsv1beta1.MissedSchedulePolicySkip, appsv1beta1.MissedSchedulePolicyRunOnce:
+		if policy.MaxBackfill != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxBackfill"), "only allowed for Backfill type"))
+		}
+	case appsv1beta1.MissedSchedulePolicyBackfill:
+		if policy.MaxBackfill != nil && (*policy.MaxBackfill < 1 || *policy.MaxBackfill > MaxBackfill) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackfill"), *policy.MaxBackfill,
+				fmt.Sprintf("must be between 1 and %d", MaxBackfill)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), policy.Type,
+			[]string{string(appsv1beta1.MissedSchedulePolicySkip), string(appsv1beta1.MissedSchedulePolicyRunOnce), string(appsv1beta1.MissedSchedulePolicyBackfill)}))
+	}
 	return allErrs
 }
 
@@ -111,6 +111,69 @@ func TestValidateCronJobSpec(t *testing.T) {
 	}
 }
 
+func TestValidateMissedSchedulePolicyAndRunHistory(t *testing.T) {
+	cases := map[string]struct {
+		policy          *appsv1beta1.MissedSchedulePolicy
+		runHistoryLimit *int32
+		expectErr       bool
+	}{
+		"nil policy": {},
+		"skip": {
+			policy: &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicySkip},
+		},
+		"backfill": {
+			policy:          &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: int32Ptr(5)},
+			runHistoryLimit: int32Ptr(10),
+		},
+		"unsupported type": {
+			policy:    &appsv1beta1.MissedSchedulePolicy{Type: "Unknown"},
+			expectErr: true,
+		},
+		"maxBackfill with run once": {
+			policy:    &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyRunOnce, MaxBackfill: int32Ptr(1)},
+			expectErr: true,
+		},
+		"zero maxBackfill": {
+			policy:    &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: int32Ptr(0)},
+			expectErr: true,
+		},
+		"too large maxBackfill": {
+			policy:    &appsv1beta1.MissedSchedulePolicy{Type: appsv1beta1.MissedSchedulePolicyBackfill, MaxBackfill: int32Ptr(100)},
+			expectErr: true,
+		},
+		"negative runHistoryLimit": {
+			runHistoryLimit: int32Ptr(-1),
+			expectErr:       true,
+		},
+		"too large runHistoryLimit": {
+			runHistoryLimit: int32Ptr(101),
+			expectErr:       true,
+		},
+	}
+
+	for k, v := range cases {
+		spec := &appsv1beta1.AdvancedCronJobSpec{
+			Schedule:             "0 * * * *",
+			ConcurrencyPolicy:    appsv1beta1.AllowConcurrent,
+			MissedSchedulePolicy: v.policy,
+			RunHistoryLimit:      v.runHistoryLimit,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: createValidPodTemplateSpec(),
+					},
+				},
+			},
+		}
+		errs := validateAdvancedCronJobSpec(spec, field.NewPath("spec"))
+		if len(errs) > 0 && !v.expectErr {
+			t.Errorf("unexpected error for %s: %v", k, errs)
+		} else if len(errs) == 0 && v.expectErr {
+			t.Errorf("expected error for %s but got nil", k)
+		}
+	}
+}
+
 func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 	utilruntime.Must(apis.AddToScheme(scheme.Scheme))
 
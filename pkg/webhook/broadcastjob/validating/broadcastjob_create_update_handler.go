@@ -20,12 +20,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
 	"regexp"
 
 	v1 "k8s.io/api/core/v1"
 	genericvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	validationutil "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	corevalidation "k8s.io/kubernetes/pkg/apis/core/validation"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -110,9 +115,113 @@ func validateBroadcastJobSpec(spec *appsv1beta1.BroadcastJobSpec, fldPath *field
 				fmt.Sprintf("\"%s\" and \"%s\" are not allowed to preset in pod labels", broadcastjob.JobNameLabelKey, broadcastjob.ControllerUIDLabelKey)))
 		}
 	}
+	if spec.NodeSelector != nil {
+		allErrs = append(allErrs, metavalidation.ValidateLabelSelector(spec.NodeSelector, metavalidation.LabelSelectorValidationOptions{}, fldPath.Child("nodeSelector"))...)
+	}
+	if spec.NodeParameters != nil {
+		allErrs = append(allErrs, validateNodeParameters(spec, fldPath.Child("nodeParameters"))...)
+	}
+	if spec.FailurePolicy.BackoffLimitPerNode != nil {
+		allErrs = append(allErrs, corevalidation.ValidateNonnegativeField(int64(*spec.FailurePolicy.BackoffLimitPerNode),
+			fldPath.Child("failurePolicy").Child("backoffLimitPerNode"))...)
+	}
+	if spec.FailurePolicy.BackoffSeconds != nil && *spec.FailurePolicy.BackoffSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failurePolicy").Child("backoffSeconds"),
+			*spec.FailurePolicy.BackoffSeconds, "backoffSeconds must be positive"))
+	}
+	if spec.RollingStrategy != nil {
+		allErrs = append(allErrs, validateRollingStrategy(spec.RollingStrategy, fldPath.Child("rollingStrategy"))...)
+	}
+	if spec.NodeResults != nil {
+		switch spec.NodeResults.Type {
+		case "", appsv1beta1.BroadcastJobNodeResultsStatus, appsv1beta1.BroadcastJobNodeResultsConfigMap:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("nodeResults").Child("type"), spec.NodeResults.Type,
+				[]string{string(appsv1beta1.BroadcastJobNodeResultsStatus), string(appsv1beta1.BroadcastJobNodeResultsConfigMap)}))
+		}
+	}
 	return append(allErrs, corevalidation.ValidatePodTemplateSpec(coreTemplate, fldPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
 }
 
+func validateNodeParameters(spec *appsv1beta1.BroadcastJobSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	params := spec.NodeParameters
+	if !params.InjectEnv && params.MountPath == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "one of injectEnv and mountPath must be set"))
+	}
+
+	names := sets.NewString(appsv1beta1.BroadcastJobNodeNameParam, appsv1beta1.BroadcastJobNodeIndexParam)
+	for i, param := range params.Params {
+		paramPath := fldPath.Child("params").Index(i)
+		if names.Has(param.Name) {
+			allErrs = append(allErrs, field.Duplicate(paramPath.Child("name"), param.Name))
+		}
+		names.Insert(param.Name)
+		for _, msg := range validationutil.IsCIdentifier(param.Name) {
+			allErrs = append(allErrs, field.Invalid(paramPath.Child("name"), param.Name, msg))
+		}
+		// the parameter is recorded in the annotation of pod to mount as file
+		for _, msg := range validationutil.IsQualifiedName(appsv1beta1.BroadcastJobNodeParamAnnotationPrefix + param.Name) {
+			allErrs = append(allErrs, field.Invalid(paramPath.Child("name"), param.Name, msg))
+		}
+		switch {
+		case param.NodeLabel == "" && param.NodeAnnotation == "":
+			allErrs = append(allErrs, field.Required(paramPath, "one of nodeLabel and nodeAnnotation must be set"))
+		case param.NodeLabel != "" && param.NodeAnnotation != "":
+			allErrs = append(allErrs, field.Forbidden(paramPath, "only one of nodeLabel and nodeAnnotation can be set"))
+		case param.NodeLabel != "":
+			allErrs = append(allErrs, metavalidation.ValidateLabelName(param.NodeLabel, paramPath.Child("nodeLabel"))...)
+		default:
+			for _, msg := range validationutil.IsQualifiedName(param.NodeAnnotation) {
+				allErrs = append(allErrs, field.Invalid(paramPath.Child("nodeAnnotation"), param.NodeAnnotation, msg))
+			}
+		}
+	}
+
+	if params.MountPath != "" {
+		if !path.IsAbs(params.MountPath) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), params.MountPath, "must be an absolute path"))
+		}
+		for _, volume := range spec.Template.Spec.Volumes {
+			if volume.Name == broadcastjob.NodeParamsVolumeName {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), params.MountPath,
+					fmt.Sprintf("volume %s is reserved for node parameters in template", broadcastjob.NodeParamsVolumeName)))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateRollingStrategy(strategy *appsv1beta1.BroadcastJobRollingStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(strategy.Batches) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("batches"), "batches must not be empty"))
+	}
+	var previous int
+	for i := range strategy.Batches {
+		batch := strategy.Batches[i]
+		batchPath := fldPath.Child("batches").Index(i)
+		allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(batch, batchPath)...)
+		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(batch, batchPath)...)
+		// the value of int or the percentage
+		value, err := intstrutil.GetScaledValueFromIntOrPercent(&batch, 100, true)
+		if err != nil {
+			continue
+		}
+		if value == 0 {
+			allErrs = append(allErrs, field.Invalid(batchPath, batch.String(), "batch must be greater than 0"))
+		}
+		if i > 0 && batch.Type == strategy.Batches[i-1].Type && value < previous {
+			allErrs = append(allErrs, field.Invalid(batchPath, batch.String(), "batches must be cumulative and non-decreasing"))
+		}
+		previous = value
+	}
+	if strategy.MinSuccessPercent != nil && (*strategy.MinSuccessPercent < 0 || *strategy.MinSuccessPercent > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minSuccessPercent"), *strategy.MinSuccessPercent, "must be between 0 and 100"))
+	}
+	return allErrs
+}
+
 func validateBroadcastJobName(name string, prefix bool) (allErrs []string) {
 	if !validateBroadcastJobNameRegex.MatchString(name) {
 		allErrs = append(allErrs, validationutil.RegexError(validateBroadcastJobNameMsg, validBroadcastJobNameFmt, "example-com"))
@@ -26,9 +26,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/openkruise/kruise/apis"
@@ -69,6 +71,139 @@ func TestValidateBroadcastJobSpec(t *testing.T) {
 	assert.Equal(t, fieldErrorList[3].Field, "spec.template.metadata.labels")
 }
 
+func TestValidateNodeRetryAndResults(t *testing.T) {
+	spec := &appsv1beta1.BroadcastJobSpec{
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				RestartPolicy: v1.RestartPolicyNever,
+				Containers:    []v1.Container{{Name: "main", Image: "busybox", ImagePullPolicy: v1.PullAlways, TerminationMessagePolicy: v1.TerminationMessageReadFile}},
+				DNSPolicy:     v1.DNSClusterFirst,
+			},
+		},
+		FailurePolicy: appsv1beta1.FailurePolicy{
+			BackoffLimitPerNode: ptr.To(int32(-1)),
+			BackoffSeconds:      ptr.To(int32(0)),
+		},
+		NodeResults: &appsv1beta1.BroadcastJobNodeResultsPolicy{Type: "Unknown"},
+	}
+	var fields []string
+	for _, err := range validateBroadcastJobSpec(spec, field.NewPath("spec")) {
+		fields = append(fields, err.Field)
+	}
+	assert.Equal(t, []string{"spec.failurePolicy.backoffLimitPerNode", "spec.failurePolicy.backoffSeconds", "spec.nodeResults.type"}, fields)
+
+	spec.FailurePolicy.BackoffLimitPerNode = ptr.To(int32(3))
+	spec.FailurePolicy.BackoffSeconds = ptr.To(int32(10))
+	spec.NodeResults.Type = appsv1beta1.BroadcastJobNodeResultsConfigMap
+	assert.Empty(t, validateBroadcastJobSpec(spec, field.NewPath("spec")))
+}
+
+func TestValidateRollingStrategy(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     *appsv1beta1.BroadcastJobRollingStrategy
+		expectFields []string
+	}{
+		{
+			name: "valid rolling strategy",
+			strategy: &appsv1beta1.BroadcastJobRollingStrategy{
+				Batches:           []intstr.IntOrString{intstr.FromInt32(1), intstr.FromString("5%"), intstr.FromString("50%"), intstr.FromString("100%")},
+				MinSuccessPercent: ptr.To(int32(95)),
+			},
+		},
+		{
+			name:         "empty batches",
+			strategy:     &appsv1beta1.BroadcastJobRollingStrategy{},
+			expectFields: []string{"spec.rollingStrategy.batches"},
+		},
+		{
+			name: "invalid batches and threshold",
+			strategy: &appsv1beta1.BroadcastJobRollingStrategy{
+				Batches:           []intstr.IntOrString{intstr.FromString("0%"), intstr.FromString("20%"), intstr.FromString("10%"), intstr.FromString("120%")},
+				MinSuccessPercent: ptr.To(int32(101)),
+			},
+			expectFields: []string{
+				"spec.rollingStrategy.batches[0]",
+				"spec.rollingStrategy.batches[2]",
+				"spec.rollingStrategy.batches[3]",
+				"spec.rollingStrategy.minSuccessPercent",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			for _, err := range validateRollingStrategy(tc.strategy, field.NewPath("spec").Child("rollingStrategy")) {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, tc.expectFields, fields)
+		})
+	}
+}
+
+func TestValidateNodeParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       *appsv1beta1.BroadcastJobNodeParameters
+		volumes      []v1.Volume
+		expectFields []string
+	}{
+		{
+			name: "valid node parameters",
+			params: &appsv1beta1.BroadcastJobNodeParameters{
+				Params: []appsv1beta1.BroadcastJobNodeParam{
+					{Name: "ZONE", NodeLabel: "topology.kubernetes.io/zone"},
+					{Name: "MAINTENANCE", NodeAnnotation: "example.com/maintenance"},
+				},
+				InjectEnv: true,
+				MountPath: "/etc/node-params",
+			},
+		},
+		{
+			name:         "neither env nor files",
+			params:       &appsv1beta1.BroadcastJobNodeParameters{},
+			expectFields: []string{"spec.nodeParameters"},
+		},
+		{
+			name: "invalid params",
+			params: &appsv1beta1.BroadcastJobNodeParameters{
+				Params: []appsv1beta1.BroadcastJobNodeParam{
+					{Name: "BROADCASTJOB_NODE_NAME", NodeLabel: "zone"},
+					{Name: "node-zone", NodeLabel: "zone"},
+					{Name: "RACK"},
+					{Name: "POOL", NodeLabel: "pool", NodeAnnotation: "pool"},
+				},
+				InjectEnv: true,
+			},
+			expectFields: []string{
+				"spec.nodeParameters.params[0].name",
+				"spec.nodeParameters.params[1].name",
+				"spec.nodeParameters.params[2]",
+				"spec.nodeParameters.params[3]",
+			},
+		},
+		{
+			name:         "invalid mount path",
+			params:       &appsv1beta1.BroadcastJobNodeParameters{MountPath: "etc/node-params"},
+			volumes:      []v1.Volume{{Name: "broadcastjob-node-params"}},
+			expectFields: []string{"spec.nodeParameters.mountPath", "spec.nodeParameters.mountPath"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.BroadcastJobSpec{NodeParameters: tc.params}
+			spec.Template.Spec.Volumes = tc.volumes
+			var fields []string
+			for _, err := range validateNodeParameters(spec, field.NewPath("spec").Child("nodeParameters")) {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, tc.expectFields, fields)
+		})
+	}
+}
+
 func TestBroadcastJobCreateUpdateHandler_Handle(t *testing.T) {
 	utilruntime.Must(apis.AddToScheme(scheme.Scheme))
 
@@ -6,6 +6,7 @@ import (
 	"math"
 
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,7 +20,11 @@ import (
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	clonesetcore "github.com/openkruise/kruise/pkg/controller/cloneset/core"
+	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+	"github.com/openkruise/kruise/pkg/util/pvc"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 	"github.com/openkruise/kruise/pkg/webhook/util/convertor"
 )
@@ -92,6 +97,14 @@ func (h *CloneSetCreateUpdateHandler) validateCloneSetSpec(spec, oldSpec *appsv1
 
 	allErrs = append(allErrs, h.validateScaleStrategy(&spec.ScaleStrategy, oldScaleStrategy, metadata, fldPath.Child("scaleStrategy"))...)
 	allErrs = append(allErrs, h.validateUpdateStrategy(&spec.UpdateStrategy, int(*spec.Replicas), fldPath.Child("updateStrategy"))...)
+	allErrs = append(allErrs, webhookutil.ValidateLifecycle(spec.Lifecycle, fldPath.Child("lifecycle"))...)
+
+	switch spec.VolumeClaimUpdateStrategy.Type {
+	case "", appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType, appsv1alpha1.OnPVCDeleteCloneSetVolumeClaimUpdateStrategyType:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("volumeClaimUpdateStrategy", "type"), spec.VolumeClaimUpdateStrategy.Type,
+			[]string{string(appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType), string(appsv1alpha1.OnPVCDeleteCloneSetVolumeClaimUpdateStrategyType)}))
+	}
 
 	if spec.ProgressDeadlineSeconds != nil {
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.ProgressDeadlineSeconds), fldPath.Child("progressDeadlineSeconds"))...)
@@ -128,6 +141,64 @@ func (h *CloneSetCreateUpdateHandler) validateScaleStrategy(strategy, oldStrateg
 		}
 	}
 
+	if strategy.ScaleInRanker != nil {
+		allErrs = append(allErrs, validateScaleInRanker(strategy.ScaleInRanker, fldPath.Child("scaleInRanker"))...)
+	}
+
+	return allErrs
+}
+
+// validateVolumeClaimTemplatesResize only allows to expand the storage of existing templates whose storage class
+// supports volume expansion, for the PVCs will be resized in-place rather than recreated.
+func (h *CloneSetCreateUpdateHandler) validateVolumeClaimTemplatesResize(templates, oldTemplates []v1.PersistentVolumeClaim, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(templates) != len(oldTemplates) {
+		return append(allErrs, field.Forbidden(fldPath, "volumeClaimTemplates can not be added or deleted when volumeClaimUpdateStrategy is OnPodRollingUpdate"))
+	}
+	oldTemplateByName := make(map[string]*v1.PersistentVolumeClaim, len(oldTemplates))
+	for i := range oldTemplates {
+		oldTemplateByName[oldTemplates[i].Name] = &oldTemplates[i]
+	}
+	for i := range templates {
+		oldTemplate, ok := oldTemplateByName[templates[i].Name]
+		if !ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("name"), "volumeClaimTemplate name can not be modified when volumeClaimUpdateStrategy is OnPodRollingUpdate"))
+			continue
+		}
+		if !pvc.IsClaimCompatibleWithoutSize(oldTemplate, &templates[i]) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "only storage of volumeClaimTemplate can be modified when volumeClaimUpdateStrategy is OnPodRollingUpdate"))
+		} else if pvc.IsPVCNeedExpand(&templates[i], oldTemplate) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("spec", "resources", "requests", "storage"), "storage of volumeClaimTemplate can not be shrunk"))
+		} else if pvc.IsPVCNeedExpand(oldTemplate, &templates[i]) {
+			allErrs = append(allErrs, h.validateStorageClassExpansion(templates[i].Spec.StorageClassName, fldPath.Index(i).Child("spec"))...)
+		}
+	}
+	return allErrs
+}
+
+func (h *CloneSetCreateUpdateHandler) validateStorageClassExpansion(scName *string, fldPath *field.Path) field.ErrorList {
+	// nil storageClassName means using the default storage class, which is checked by the controller when resizing the claims
+	if scName == nil {
+		return nil
+	}
+	sc := &storagev1.StorageClass{}
+	if err := h.Client.Get(context.TODO(), types.NamespacedName{Name: *scName}, sc); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Child("storageClassName"), *scName, fmt.Sprintf("failed to get storage class: %v", err))}
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return field.ErrorList{field.Forbidden(fldPath.Child("resources", "requests", "storage"), fmt.Sprintf("storage class %s does not support volume expansion", sc.Name))}
+	}
+	return nil
+}
+
+func validateScaleInRanker(ranker *appsv1alpha1.CloneSetScaleInRanker, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := httphook.ValidateURL(ranker.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), ranker.URL, err.Error()))
+	}
+	if ranker.TimeoutSeconds != nil && (*ranker.TimeoutSeconds <= 0 || *ranker.TimeoutSeconds > 10) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *ranker.TimeoutSeconds, "must be in range (0, 10]"))
+	}
 	return allErrs
 }
 
@@ -156,6 +227,7 @@ func (h *CloneSetCreateUpdateHandler) validateUpdateStrategy(strategy *appsv1alp
 	if err := strategy.PriorityStrategy.FieldsValidation(); err != nil {
 		allErrs = append(allErrs, field.Required(fldPath.Child("priorityStrategy"), err.Error()))
 	}
+	allErrs = append(allErrs, webhookutil.ValidateUpdatePriorityExtender(strategy.PriorityStrategy, fldPath.Child("priorityStrategy"))...)
 
 	if err := strategy.ScatterStrategy.FieldsValidation(); err != nil {
 		allErrs = append(allErrs, field.Required(fldPath.Child("scatterStrategy"), err.Error()))
@@ -203,9 +275,14 @@ func (h *CloneSetCreateUpdateHandler) validateCloneSetUpdate(cloneSet, oldCloneS
 	clone.Spec.Lifecycle = oldCloneSet.Spec.Lifecycle
 	clone.Spec.RevisionHistoryLimit = oldCloneSet.Spec.RevisionHistoryLimit
 	clone.Spec.VolumeClaimTemplates = oldCloneSet.Spec.VolumeClaimTemplates
+	clone.Spec.VolumeClaimUpdateStrategy = oldCloneSet.Spec.VolumeClaimUpdateStrategy
 	clone.Spec.ProgressDeadlineSeconds = oldCloneSet.Spec.ProgressDeadlineSeconds
 	if !apiequality.Semantic.DeepEqual(clone.Spec, oldCloneSet.Spec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to cloneset spec for fields other than 'replicas', 'template', 'lifecycle', 'scaleStrategy', 'updateStrategy', 'minReadySeconds', 'progressDeadlineSeconds', 'volumeClaimTemplates' and 'revisionHistoryLimit' are forbidden"))
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to cloneset spec for fields other than 'replicas', 'template', 'lifecycle', 'scaleStrategy', 'updateStrategy', 'minReadySeconds', 'progressDeadlineSeconds', 'volumeClaimTemplates', 'volumeClaimUpdateStrategy' and 'revisionHistoryLimit' are forbidden"))
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.CloneSetAutoResizePVCGate) &&
+		cloneSet.Spec.VolumeClaimUpdateStrategy.Type == appsv1alpha1.OnPodRollingUpdateCloneSetVolumeClaimUpdateStrategyType {
+		allErrs = append(allErrs, h.validateVolumeClaimTemplatesResize(cloneSet.Spec.VolumeClaimTemplates, oldCloneSet.Spec.VolumeClaimTemplates, field.NewPath("spec", "volumeClaimTemplates"))...)
 	}
 
 	coreControl := clonesetcore.New(cloneSet)
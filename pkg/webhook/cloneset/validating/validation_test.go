@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -674,3 +675,111 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateScaleInRanker(t *testing.T) {
+	cases := []struct {
+		name       string
+		ranker     *appsv1alpha1.CloneSetScaleInRanker
+		expectErrs int
+	}{
+		{
+			name:   "valid ranker",
+			ranker: &appsv1alpha1.CloneSetScaleInRanker{URL: "http://ranker.default.svc/rank", TimeoutSeconds: ptr.To[int32](3)},
+		},
+		{
+			name:       "relative url",
+			ranker:     &appsv1alpha1.CloneSetScaleInRanker{URL: "/rank"},
+			expectErrs: 1,
+		},
+		{
+			name:       "timeout too long",
+			ranker:     &appsv1alpha1.CloneSetScaleInRanker{URL: "https://ranker.default.svc/rank", TimeoutSeconds: ptr.To[int32](30)},
+			expectErrs: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateScaleInRanker(tc.ranker, field.NewPath("spec", "scaleStrategy", "scaleInRanker"))
+			if len(errs) != tc.expectErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrs, errs)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeClaimTemplatesResize(t *testing.T) {
+	newTemplate := func(name, size string, accessMode v1.PersistentVolumeAccessMode) v1.PersistentVolumeClaim {
+		return v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PersistentVolumeClaimSpec{
+				AccessModes: []v1.PersistentVolumeAccessMode{accessMode},
+				Resources: v1.VolumeResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceStorage: resource.MustParse(size),
+				}},
+			},
+		}
+	}
+	oldTemplates := []v1.PersistentVolumeClaim{newTemplate("data", "10Gi", v1.ReadWriteOnce)}
+
+	cases := []struct {
+		name       string
+		templates  []v1.PersistentVolumeClaim
+		expectErrs int
+	}{
+		{
+			name:      "expand storage",
+			templates: []v1.PersistentVolumeClaim{newTemplate("data", "20Gi", v1.ReadWriteOnce)},
+		},
+		{
+			name:       "shrink storage",
+			templates:  []v1.PersistentVolumeClaim{newTemplate("data", "5Gi", v1.ReadWriteOnce)},
+			expectErrs: 1,
+		},
+		{
+			name:       "modify access modes",
+			templates:  []v1.PersistentVolumeClaim{newTemplate("data", "20Gi", v1.ReadWriteMany)},
+			expectErrs: 1,
+		},
+		{
+			name:       "rename template",
+			templates:  []v1.PersistentVolumeClaim{newTemplate("log", "10Gi", v1.ReadWriteOnce)},
+			expectErrs: 1,
+		},
+		{
+			name: "expand storage of storage class allowing expansion",
+			templates: func() []v1.PersistentVolumeClaim {
+				template := newTemplate("data", "20Gi", v1.ReadWriteOnce)
+				template.Spec.StorageClassName = ptr.To("expandable")
+				return []v1.PersistentVolumeClaim{template}
+			}(),
+		},
+		{
+			name: "expand storage of storage class not allowing expansion",
+			templates: func() []v1.PersistentVolumeClaim {
+				template := newTemplate("data", "20Gi", v1.ReadWriteOnce)
+				template.Spec.StorageClassName = ptr.To("fixed")
+				return []v1.PersistentVolumeClaim{template}
+			}(),
+			expectErrs: 1,
+		},
+		{
+			name:       "add template",
+			templates:  []v1.PersistentVolumeClaim{newTemplate("data", "10Gi", v1.ReadWriteOnce), newTemplate("log", "10Gi", v1.ReadWriteOnce)},
+			expectErrs: 1,
+		},
+	}
+
+	h := CloneSetCreateUpdateHandler{Client: fake.NewClientBuilder().WithObjects(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "expandable"}, AllowVolumeExpansion: ptr.To(true)},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}},
+	).Build()}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := h.validateVolumeClaimTemplatesResize(tc.templates, oldTemplates, field.NewPath("spec", "volumeClaimTemplates"))
+			if len(errs) != tc.expectErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrs, errs)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
@@ -18,6 +19,7 @@ import (
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/controller/daemonset"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 	"github.com/openkruise/kruise/pkg/webhook/util/convertor"
 )
@@ -76,6 +78,7 @@ func validateDaemonSetSpec(spec *appsv1alpha1.DaemonSetSpec, fldPath *field.Path
 		if spec.Lifecycle.InPlaceUpdate != nil {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("lifecycle", "inPlaceUpdate"), "inPlaceUpdate hook has not supported yet"))
 		}
+		allErrs = append(allErrs, webhookutil.ValidateLifecycle(spec.Lifecycle, fldPath.Child("lifecycle"))...)
 	}
 	return allErrs
 }
@@ -228,6 +231,61 @@ func validateDaemonSetSpecV1beta1(spec *appsv1beta1.DaemonSetSpec, fldPath *fiel
 		if spec.Lifecycle.InPlaceUpdate != nil {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("lifecycle", "inPlaceUpdate"), "inPlaceUpdate hook has not supported yet"))
 		}
+		allErrs = append(allErrs, webhookutil.ValidateLifecycle(spec.Lifecycle, fldPath.Child("lifecycle"))...)
+	}
+
+	if len(spec.NodeProfiles) > 0 {
+		allErrs = append(allErrs, validateNodeProfiles(spec, fldPath.Child("nodeProfiles"))...)
+	}
+	return allErrs
+}
+
+func validateNodeProfiles(spec *appsv1beta1.DaemonSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	containerNames := sets.NewString()
+	for _, c := range spec.Template.Spec.Containers {
+		containerNames.Insert(c.Name)
+	}
+	profileNames := sets.NewString()
+	for i := range spec.NodeProfiles {
+		profile := &spec.NodeProfiles[i]
+		idxPath := fldPath.Index(i)
+		var profileErrs field.ErrorList
+		if profile.Name == "" {
+			profileErrs = append(profileErrs, field.Required(idxPath.Child("name"), ""))
+		} else if profileNames.Has(profile.Name) {
+			profileErrs = append(profileErrs, field.Duplicate(idxPath.Child("name"), profile.Name))
+		} else {
+			for _, msg := range validation.IsValidLabelValue(profile.Name) {
+				profileErrs = append(profileErrs, field.Invalid(idxPath.Child("name"), profile.Name, msg))
+			}
+		}
+		profileNames.Insert(profile.Name)
+
+		if profile.NodeSelector == nil {
+			profileErrs = append(profileErrs, field.Required(idxPath.Child("nodeSelector"), ""))
+		} else {
+			profileErrs = append(profileErrs, metavalidation.ValidateLabelSelector(profile.NodeSelector, metavalidation.LabelSelectorValidationOptions{}, idxPath.Child("nodeSelector"))...)
+		}
+		for j := range profile.Containers {
+			if !containerNames.Has(profile.Containers[j].Name) {
+				profileErrs = append(profileErrs, field.NotFound(idxPath.Child("containers").Index(j).Child("name"), profile.Containers[j].Name))
+			}
+		}
+		allErrs = append(allErrs, profileErrs...)
+		if len(profileErrs) > 0 {
+			continue
+		}
+
+		// the template with the profile applied should be valid
+		template := spec.Template.DeepCopy()
+		daemonset.ApplyNodeProfile(template, profile)
+		coreTemplate, err := convertor.ConvertPodTemplateSpec(template)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, profile.Name, fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
+			continue
+		}
+		allErrs = append(allErrs, corevalidation.ValidatePodTemplateSpec(coreTemplate, idxPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
 	}
 	return allErrs
 }
@@ -287,5 +345,68 @@ func validateRollingUpdateDaemonSetV1beta1(rollingUpdate *appsv1beta1.RollingUpd
 		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*rollingUpdate.Partition, fldPath.Child("rollingUpdate").Child("partition"))...)
 	}
 
+	if len(rollingUpdate.Waves) > 0 {
+		if rollingUpdate.Selector != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("waves"), "may not be set together with selector"))
+		}
+		allErrs = append(allErrs, validateDaemonSetUpdateWaves(rollingUpdate.Waves, hasSurge, fldPath.Child("waves"))...)
+	}
+
+	if rollingUpdate.UnschedulableNodes != nil {
+		allErrs = append(allErrs, validateUnschedulableNodesStrategy(rollingUpdate.UnschedulableNodes, fldPath.Child("unschedulableNodes"))...)
+	}
+
+	return allErrs
+}
+
+func validateUnschedulableNodesStrategy(strategy *appsv1beta1.DaemonSetUnschedulableNodesStrategy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch strategy.Policy {
+	case "", appsv1beta1.SkipUnschedulableNodesPolicyType, appsv1beta1.DeprioritizeUnschedulableNodesPolicyType:
+	default:
+		validValues := []string{string(appsv1beta1.SkipUnschedulableNodesPolicyType), string(appsv1beta1.DeprioritizeUnschedulableNodesPolicyType)}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), strategy.Policy, validValues))
+	}
+	for i, taint := range strategy.Taints {
+		idxPath := fldPath.Child("taints").Index(i)
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(taint.Key, idxPath.Child("key"))...)
+		switch taint.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			validValues := []string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("effect"), taint.Effect, validValues))
+		}
+	}
+	return allErrs
+}
+
+func validateDaemonSetUpdateWaves(waves []appsv1beta1.DaemonSetUpdateWave, hasSurge bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i := range waves {
+		wave := &waves[i]
+		idxPath := fldPath.Index(i)
+		if wave.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if names.Has(wave.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), wave.Name))
+		}
+		names.Insert(wave.Name)
+
+		if wave.NodeSelector == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("nodeSelector"), ""))
+		} else {
+			allErrs = append(allErrs, metavalidation.ValidateLabelSelector(wave.NodeSelector, metavalidation.LabelSelectorValidationOptions{}, idxPath.Child("nodeSelector"))...)
+		}
+		if wave.MaxUnavailable != nil && hasSurge {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("maxUnavailable"), "may not be set when maxSurge is non-zero"))
+		} else if wave.MaxUnavailable != nil {
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*wave.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+			allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*wave.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+		}
+		if wave.SoakSeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("soakSeconds"), wave.SoakSeconds, "must be non-negative"))
+		}
+	}
 	return allErrs
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -989,6 +990,106 @@ func TestValidateRollingUpdateDaemonSetV1beta1(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Valid waves",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}, MaxUnavailable: &maxUnavailable, SoakSeconds: 600},
+					{Name: "all", NodeSelector: &metav1.LabelSelector{}, MaxUnavailable: &percentValue},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Waves with selector",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}},
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Duplicate wave names",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}}},
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave without nodeSelector",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves:          []appsv1beta1.DaemonSetUpdateWave{{Name: "canary"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave with invalid maxUnavailable",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{}, MaxUnavailable: &invalidPercent},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave with maxUnavailable when maxSurge is non-zero",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxSurge: &maxSurge,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{}, MaxUnavailable: &maxUnavailable},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid unschedulableNodes",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{
+					Policy: appsv1beta1.SkipUnschedulableNodesPolicyType,
+					Taints: []appsv1beta1.UnschedulableNodeTaint{{Key: "node.example.com/draining", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Invalid unschedulableNodes policy",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable:     &maxUnavailable,
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{Policy: "Ignore"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalid unschedulableNodes taint",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				UnschedulableNodes: &appsv1beta1.DaemonSetUnschedulableNodesStrategy{
+					Taints: []appsv1beta1.UnschedulableNodeTaint{{Key: "", Effect: "Evict"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Wave with negative soakSeconds",
+			rollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+				Waves: []appsv1beta1.DaemonSetUpdateWave{
+					{Name: "canary", NodeSelector: &metav1.LabelSelector{}, SoakSeconds: -1},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1126,3 +1227,111 @@ func TestValidateDaemonSetSpecV1beta1(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNodeProfiles(t *testing.T) {
+	validLabels := map[string]string{"app": "test"}
+	maxUnavailable := intstr.FromInt(1)
+	newSpec := func(profiles ...appsv1beta1.DaemonSetNodeProfile) *appsv1beta1.DaemonSetSpec {
+		return &appsv1beta1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: validLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: validLabels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyAlways,
+					Containers: []corev1.Container{{
+						Name:  "test",
+						Image: "test:v1",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+						},
+					}},
+				},
+			},
+			UpdateStrategy: appsv1beta1.DaemonSetUpdateStrategy{
+				Type:          appsv1beta1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1beta1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+			},
+			NodeProfiles: profiles,
+		}
+	}
+	largeSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"node-class": "large"}}
+	largeResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+	}
+
+	tests := []struct {
+		name      string
+		spec      *appsv1beta1.DaemonSetSpec
+		expectErr bool
+	}{
+		{
+			name: "valid profiles",
+			spec: newSpec(appsv1beta1.DaemonSetNodeProfile{
+				Name:         "large",
+				NodeSelector: largeSelector,
+				Containers: []appsv1beta1.DaemonSetContainerProfile{{
+					Name:      "test",
+					Resources: largeResources,
+					Env:       []corev1.EnvVar{{Name: "WORKERS", Value: "16"}},
+				}},
+			}),
+		},
+		{
+			name: "duplicate names",
+			spec: newSpec(
+				appsv1beta1.DaemonSetNodeProfile{Name: "large", NodeSelector: largeSelector},
+				appsv1beta1.DaemonSetNodeProfile{Name: "large", NodeSelector: largeSelector},
+			),
+			expectErr: true,
+		},
+		{
+			name:      "no nodeSelector",
+			spec:      newSpec(appsv1beta1.DaemonSetNodeProfile{Name: "large"}),
+			expectErr: true,
+		},
+		{
+			name: "container not found",
+			spec: newSpec(appsv1beta1.DaemonSetNodeProfile{
+				Name:         "large",
+				NodeSelector: largeSelector,
+				Containers:   []appsv1beta1.DaemonSetContainerProfile{{Name: "missing", Resources: largeResources}},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "requests exceed limits",
+			spec: newSpec(appsv1beta1.DaemonSetNodeProfile{
+				Name:         "large",
+				NodeSelector: largeSelector,
+				Containers: []appsv1beta1.DaemonSetContainerProfile{{
+					Name:      "test",
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}},
+				}},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "invalid env name",
+			spec: newSpec(appsv1beta1.DaemonSetNodeProfile{
+				Name:         "large",
+				NodeSelector: largeSelector,
+				Containers:   []appsv1beta1.DaemonSetContainerProfile{{Name: "test", Env: []corev1.EnvVar{{Name: ""}}}},
+			}),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateDaemonSetSpecV1beta1(tt.spec, nil)
+			if tt.expectErr && len(errs) == 0 {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectErr && len(errs) != 0 {
+				t.Errorf("expected no error but got: %v", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagenames "k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/klog/v2"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+)
+
+// start containers based on their declared dependencies, containers without dependencies start immediately
+func (h *PodCreateHandler) containerLaunchDependencyInitialization(pod *corev1.Pod) (skip bool, err error) {
+	dependencies, err := getContainerDependencies(pod)
+	if err != nil {
+		return false, err
+	}
+	if len(dependencies) == 0 {
+		return true, nil
+	}
+
+	if pod.Annotations[appspub.ContainerLaunchPriorityKey] == appspub.ContainerLaunchOrdered {
+		return false, fmt.Errorf("%s can not be used together with annotation %s", appspub.ContainerLaunchDependenciesEnvName, appspub.ContainerLaunchPriorityKey)
+	}
+	if _, priorityFlag, err := h.getPriority(pod); err != nil {
+		return false, err
+	} else if priorityFlag {
+		return false, fmt.Errorf("%s can not be used together with %s", appspub.ContainerLaunchDependenciesEnvName, appspub.ContainerLaunchPriorityEnvName)
+	}
+
+	// Generate name for pods that only have generateName field
+	if len(pod.Name) == 0 && len(pod.GenerateName) > 0 {
+		pod.Name = storagenames.SimpleNameGenerator.GenerateName(pod.GenerateName)
+	}
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if len(dependencies[c.Name]) > 0 {
+			c.Env = append(c.Env, utilcontainerlaunchpriority.GenerateDependencyEnv(c.Name, pod.Name))
+		}
+	}
+	klog.V(3).InfoS("Injected container launch dependencies for Pod", "namespace", pod.Namespace, "name", pod.Name)
+	return false, nil
+}
+
+// getContainerDependencies returns the dependencies of each container, and validates they form a DAG of pod containers.
+func getContainerDependencies(pod *corev1.Pod) (map[string][]utilcontainerlaunchpriority.ContainerDependency, error) {
+	containerNames := make(map[string]struct{}, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		containerNames[pod.Spec.Containers[i].Name] = struct{}{}
+	}
+
+	var dependencies map[string][]utilcontainerlaunchpriority.ContainerDependency
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		deps, err := utilcontainerlaunchpriority.ParseContainerDependencies(c)
+		if err != nil {
+			return nil, err
+		}
+		if len(deps) == 0 {
+			continue
+		}
+		for _, dep := range deps {
+			if _, ok := containerNames[dep.Name]; !ok {
+				return nil, fmt.Errorf("container %s depends on %s which is not a container of pod", c.Name, dep.Name)
+			}
+		}
+		if dependencies == nil {
+			dependencies = make(map[string][]utilcontainerlaunchpriority.ContainerDependency)
+		}
+		dependencies[c.Name] = deps
+	}
+
+	// detect cycles by DFS, 1 means visiting and 2 means visited
+	visitState := make(map[string]int, len(dependencies))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch visitState[name] {
+		case 1:
+			return fmt.Errorf("container launch dependencies have a cycle through container %s", name)
+		case 2:
+			return nil
+		}
+		visitState[name] = 1
+		for _, dep := range dependencies[name] {
+			if err := visit(dep.Name); err != nil {
+				return err
+			}
+		}
+		visitState[name] = 2
+		return nil
+	}
+	for i := range pod.Spec.Containers {
+		if err := visit(pod.Spec.Containers[i].Name); err != nil {
+			return nil, err
+		}
+	}
+	return dependencies, nil
+}
@@ -26,6 +26,11 @@ func (h *PodCreateHandler) containerLaunchPriorityInitialization(_ context.Conte
 		return true, nil
 	}
 
+	// if containers have declared dependencies, launch them by the dependency graph instead of priority
+	if skip, err := h.containerLaunchDependencyInitialization(pod); err != nil || !skip {
+		return skip, err
+	}
+
 	// if ordered flag has been set, then just process ordered logic and skip check for priority
 	if pod.Annotations[appspub.ContainerLaunchPriorityKey] == appspub.ContainerLaunchOrdered {
 		priority := make([]int, len(pod.Spec.Containers))
@@ -99,3 +99,116 @@ func TestContainerLaunchPriorityInitialization(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerLaunchDependencyInitialization(t *testing.T) {
+	dependsOn := func(value string) corev1.EnvVar {
+		return corev1.EnvVar{Name: appspub.ContainerLaunchDependenciesEnvName, Value: value}
+	}
+	cases := []struct {
+		name               string
+		pod                *corev1.Pod
+		expectedErr        bool
+		expectedSkip       bool
+		expectedContainers []corev1.Container
+	}{
+		{
+			name: "inject barrier into dependent containers",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("b:Started,c")}},
+					{Name: "b"},
+					{Name: "c", Env: []corev1.EnvVar{dependsOn("b")}},
+				}},
+			},
+			expectedSkip: false,
+			expectedContainers: []corev1.Container{
+				{Name: "a", Env: []corev1.EnvVar{dependsOn("b:Started,c"), utilcontainerlaunchpriority.GenerateDependencyEnv("a", "fake")}},
+				{Name: "b"},
+				{Name: "c", Env: []corev1.EnvVar{dependsOn("b"), utilcontainerlaunchpriority.GenerateDependencyEnv("c", "fake")}},
+			},
+		},
+		{
+			name: "unknown container",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("x")}},
+					{Name: "b"},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "unsupported condition",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("b:Running")}},
+					{Name: "b"},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "cycle",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("c")}},
+					{Name: "b", Env: []corev1.EnvVar{dependsOn("a")}},
+					{Name: "c", Env: []corev1.EnvVar{dependsOn("b")}},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "combined with ordered annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "fake",
+					Annotations: map[string]string{appspub.ContainerLaunchPriorityKey: appspub.ContainerLaunchOrdered},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("b")}},
+					{Name: "b"},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "combined with priority",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "a", Env: []corev1.EnvVar{dependsOn("b")}},
+					{Name: "b", Env: []corev1.EnvVar{{Name: appspub.ContainerLaunchPriorityEnvName, Value: "10"}}},
+				}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	h := &PodCreateHandler{}
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Resource:  metav1.GroupVersionResource{Resource: "pods", Version: "v1"},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			skip, err := h.containerLaunchPriorityInitialization(context.TODO(), req, tc.pod)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if skip != tc.expectedSkip {
+				t.Fatalf("expected skip %v, got %v", tc.expectedSkip, skip)
+			}
+			if !reflect.DeepEqual(tc.expectedContainers, tc.pod.Spec.Containers) {
+				t.Fatalf("expected containers\n%v\ngot\n%v", util.DumpJSON(tc.expectedContainers), util.DumpJSON(tc.pod.Spec.Containers))
+			}
+		})
+	}
+}
@@ -70,8 +70,31 @@ func (h *PodCreateHandler) sidecarsetMutatingPod(ctx context.Context, req admiss
 		}
 	}
 
-	// DisableDeepCopy:true, indicates must be deep copy before update sidecarSet objection
+	matchedSidecarSets, err := h.getMatchedSidecarSets(ctx, pod, oldPod, req.AdmissionRequest.Operation)
+	if err != nil {
+		return false, err
+	}
+	if len(matchedSidecarSets) == 0 {
+		return true, nil
+	}
 
+	// check pod
+	if isUpdated {
+		if !matchedSidecarSets[0].IsPodAvailabilityChanged(pod, oldPod) {
+			klog.V(3).InfoS("pod availability unchanged for sidecarSet, and ignore", "namespace", pod.Namespace, "name", pod.Name)
+			return true, nil
+		}
+	}
+
+	klog.V(4).InfoS("begin to operate resource", "func", "sidecar inject",
+		"operation", req.Operation, "namespace", req.Namespace, "name", req.Name, "resource", req.Resource, "subResource", req.SubResource)
+	return injectSidecarSets(pod, oldPod, isUpdated, matchedSidecarSets)
+}
+
+// getMatchedSidecarSets returns the controls of the active SidecarSets matching the pod, each at the revision
+// that should be injected into it.
+func (h *PodCreateHandler) getMatchedSidecarSets(ctx context.Context, pod, oldPod *corev1.Pod, operation admissionv1.Operation) ([]sidecarcontrol.SidecarControl, error) {
+	// DisableDeepCopy:true, indicates must be deep copy before update sidecarSet objection
 	sidecarSetList := &appsv1beta1.SidecarSetList{}
 	sidecarSetList2 := &appsv1beta1.SidecarSetList{}
 	podNamespace := pod.Namespace
@@ -79,10 +102,10 @@ func (h *PodCreateHandler) sidecarsetMutatingPod(ctx context.Context, req admiss
 		podNamespace = "default"
 	}
 	if err := h.Client.List(ctx, sidecarSetList, client.MatchingFields{fieldindex.IndexNameForSidecarSetNamespace: podNamespace}, utilclient.DisableDeepCopy); err != nil {
-		return false, err
+		return nil, err
 	}
 	if err := h.Client.List(ctx, sidecarSetList2, client.MatchingFields{fieldindex.IndexNameForSidecarSetNamespace: fieldindex.IndexValueSidecarSetClusterScope}, utilclient.DisableDeepCopy); err != nil {
-		return false, err
+		return nil, err
 	}
 	matchedSidecarSets := make([]sidecarcontrol.SidecarControl, 0)
 	for _, sidecarSet := range append(sidecarSetList.Items, sidecarSetList2.Items...) {
@@ -90,14 +113,14 @@ func (h *PodCreateHandler) sidecarsetMutatingPod(ctx context.Context, req admiss
 			continue
 		}
 		if matched, err := sidecarcontrol.PodMatchedSidecarSet(h.Client, pod, &sidecarSet); err != nil {
-			return false, err
+			return nil, err
 		} else if !matched {
 			continue
 		}
 		// get user-specific revision or the latest revision of SidecarSet
-		suitableSidecarSet, err := h.getSuitableRevisionSidecarSet(&sidecarSet, oldPod, pod, req.AdmissionRequest.Operation)
+		suitableSidecarSet, err := h.getSuitableRevisionSidecarSet(&sidecarSet, oldPod, pod, operation)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		// check whether sidecarSet is active
 		// when sidecarSet is not active, it will not perform injections and upgrades process.
@@ -107,20 +130,11 @@ func (h *PodCreateHandler) sidecarsetMutatingPod(ctx context.Context, req admiss
 		}
 		matchedSidecarSets = append(matchedSidecarSets, control)
 	}
-	if len(matchedSidecarSets) == 0 {
-		return true, nil
-	}
-
-	// check pod
-	if isUpdated {
-		if !matchedSidecarSets[0].IsPodAvailabilityChanged(pod, oldPod) {
-			klog.V(3).InfoS("pod availability unchanged for sidecarSet, and ignore", "namespace", pod.Namespace, "name", pod.Name)
-			return true, nil
-		}
-	}
+	return matchedSidecarSets, nil
+}
 
-	klog.V(4).InfoS("begin to operate resource", "func", "sidecar inject",
-		"operation", req.Operation, "namespace", req.Namespace, "name", req.Name, "resource", req.Resource, "subResource", req.SubResource)
+// injectSidecarSets injects the sidecar containers, volumes, secrets and annotations of matchedSidecarSets into pod.
+func injectSidecarSets(pod, oldPod *corev1.Pod, isUpdated bool, matchedSidecarSets []sidecarcontrol.SidecarControl) (skip bool, err error) {
 	// patch pod metadata, annotations & labels
 	// When the Pod main container is upgraded in place, and the sidecarSet configuration does not change at this time,
 	// at this point, it can also patch pod metadata
@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/history"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+	historyutil "github.com/openkruise/kruise/pkg/util/history"
+)
+
+// SidecarSetPreviewPath is the path on the webhook server that serves sidecarSet injection previews.
+const SidecarSetPreviewPath = "/preview-sidecarset-injection"
+
+// SidecarSetInjectionPreviewRequest is the body accepted by the preview endpoint.
+// Exactly one of Pod and Template should be set.
+type SidecarSetInjectionPreviewRequest struct {
+	// Namespace of the pod, it overrides the namespace in Pod or Template when not empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Pod to preview injection for.
+	Pod *corev1.Pod `json:"pod,omitempty"`
+	// Template is a pod template, e.g. from a workload, to preview injection for.
+	Template *corev1.PodTemplateSpec `json:"template,omitempty"`
+}
+
+// SidecarSetInjectionPreview is the result of a dry-run injection of SidecarSets into a pod.
+type SidecarSetInjectionPreview struct {
+	// MatchedSidecarSets contains the active SidecarSets that will be injected into the pod.
+	MatchedSidecarSets []SidecarSetPreviewItem `json:"matchedSidecarSets"`
+	// Conflicts contains the conflicts detected between the SidecarSets and the pod.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Pod is the pod after injection.
+	Pod *corev1.Pod `json:"pod"`
+}
+
+// SidecarSetPreviewItem describes the revision of a SidecarSet chosen for the pod.
+type SidecarSetPreviewItem struct {
+	Name string `json:"name"`
+	// Hash is the sidecarSet hash recorded in the pod annotations.
+	Hash string `json:"hash"`
+	// ControllerRevision is the name of the ControllerRevision chosen for the pod,
+	// it is empty when the latest spec is chosen and has no revision recorded yet.
+	ControllerRevision string `json:"controllerRevision,omitempty"`
+	// Latest indicates whether the chosen revision is the latest revision of the SidecarSet,
+	// i.e. the newest ControllerRevision or the spec not recorded yet.
+	Latest         bool     `json:"latest"`
+	InitContainers []string `json:"initContainers,omitempty"`
+	Containers     []string `json:"containers,omitempty"`
+}
+
+// PreviewSidecarSetInjection calculates which SidecarSets would be injected into the pod on creation
+// and how the pod would look like afterward. Nothing is persisted, and the given pod is not modified.
+// Note that when a SidecarSet injects a canary revision by percentage partition, the revision is chosen randomly
+// just like the webhook does.
+func PreviewSidecarSetInjection(ctx context.Context, c client.Client, pod *corev1.Pod) (*SidecarSetInjectionPreview, error) {
+	h := &PodCreateHandler{Client: c}
+	pod = pod.DeepCopy()
+	preview := &SidecarSetInjectionPreview{MatchedSidecarSets: []SidecarSetPreviewItem{}, Pod: pod}
+	if !sidecarcontrol.IsActivePod(pod) {
+		return preview, nil
+	}
+
+	matchedSidecarSets, err := h.getMatchedSidecarSets(ctx, pod, nil, admissionv1.Create)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchedSidecarSets) == 0 {
+		return preview, nil
+	}
+	preview.Conflicts = detectSidecarSetConflicts(pod, matchedSidecarSets)
+
+	if _, err = injectSidecarSets(pod, nil, false, matchedSidecarSets); err != nil {
+		return nil, err
+	}
+
+	for _, control := range matchedSidecarSets {
+		sidecarSet := control.GetSidecarset()
+		item := SidecarSetPreviewItem{
+			Name: sidecarSet.Name,
+			Hash: sidecarcontrol.GetSidecarSetRevision(sidecarSet),
+		}
+		if item.ControllerRevision, item.Latest, err = resolveControllerRevision(c, sidecarSet); err != nil {
+			return nil, err
+		}
+		for i := range sidecarSet.Spec.InitContainers {
+			item.InitContainers = append(item.InitContainers, sidecarSet.Spec.InitContainers[i].Name)
+		}
+		for i := range sidecarSet.Spec.Containers {
+			item.Containers = append(item.Containers, sidecarSet.Spec.Containers[i].Name)
+		}
+		preview.MatchedSidecarSets = append(preview.MatchedSidecarSets, item)
+	}
+	sort.SliceStable(preview.MatchedSidecarSets, func(i, j int) bool {
+		return preview.MatchedSidecarSets[i].Name < preview.MatchedSidecarSets[j].Name
+	})
+	return preview, nil
+}
+
+// resolveControllerRevision returns the name of the newest ControllerRevision recording the hash of sidecarSet,
+// and whether it is the latest revision. The latest spec not recorded by the controller yet has no ControllerRevision.
+func resolveControllerRevision(c client.Client, sidecarSet *appsv1beta1.SidecarSet) (string, bool, error) {
+	hc := sidecarcontrol.NewHistoryControl(c)
+	revisions, err := historyutil.NewHistory(c).ListControllerRevisions(sidecarcontrol.MockSidecarSetForRevision(sidecarSet), hc.GetRevisionSelector(sidecarSet))
+	if err != nil {
+		return "", false, err
+	}
+	history.SortControllerRevisions(revisions)
+	hash := sidecarcontrol.GetSidecarSetRevision(sidecarSet)
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Annotations[sidecarcontrol.SidecarSetHashAnnotation] == hash {
+			return revisions[i].Name, i == len(revisions)-1, nil
+		}
+	}
+	return "", true, nil
+}
+
+// detectSidecarSetConflicts finds the containers and volumes of matchedSidecarSets that will override,
+// or be silently dropped in favor of, the ones of the pod or of other sidecarSets.
+func detectSidecarSetConflicts(pod *corev1.Pod, matchedSidecarSets []sidecarcontrol.SidecarControl) []string {
+	var conflicts []string
+	podContainers := make(map[string]bool)
+	for _, c := range pod.Spec.InitContainers {
+		podContainers[c.Name] = true
+	}
+	for _, c := range pod.Spec.Containers {
+		podContainers[c.Name] = true
+	}
+	podVolumes := make(map[string]*corev1.Volume)
+	for i := range pod.Spec.Volumes {
+		podVolumes[pod.Spec.Volumes[i].Name] = &pod.Spec.Volumes[i]
+	}
+
+	// container name -> sidecarSet name
+	injectedContainers := make(map[string]string)
+	// volume name -> sidecarSet name
+	injectedVolumes := make(map[string]string)
+	injectedVolumeSources := make(map[string]*corev1.Volume)
+	for _, control := range matchedSidecarSets {
+		sidecarSet := control.GetSidecarset()
+		var sidecarContainers []appsv1beta1.SidecarContainer
+		sidecarContainers = append(sidecarContainers, sidecarSet.Spec.InitContainers...)
+		sidecarContainers = append(sidecarContainers, sidecarSet.Spec.Containers...)
+		for _, sidecar := range sidecarContainers {
+			if podContainers[sidecar.Name] {
+				conflicts = append(conflicts, fmt.Sprintf("container %s of sidecarSet %s will override the container with the same name in pod", sidecar.Name, sidecarSet.Name))
+			}
+			if other, ok := injectedContainers[sidecar.Name]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("container %s is injected by both sidecarSet %s and %s", sidecar.Name, other, sidecarSet.Name))
+				continue
+			}
+			injectedContainers[sidecar.Name] = sidecarSet.Name
+		}
+
+		for i := range sidecarSet.Spec.Volumes {
+			volume := &sidecarSet.Spec.Volumes[i]
+			if podVolume, ok := podVolumes[volume.Name]; ok {
+				if !reflect.DeepEqual(podVolume.VolumeSource, volume.VolumeSource) {
+					conflicts = append(conflicts, fmt.Sprintf("volume %s of sidecarSet %s differs from the one in pod and will be ignored", volume.Name, sidecarSet.Name))
+				}
+				continue
+			}
+			if other, ok := injectedVolumes[volume.Name]; ok {
+				if !reflect.DeepEqual(injectedVolumeSources[volume.Name].VolumeSource, volume.VolumeSource) {
+					conflicts = append(conflicts, fmt.Sprintf("volume %s of sidecarSet %s differs from the one of sidecarSet %s", volume.Name, sidecarSet.Name, other))
+				}
+				continue
+			}
+			injectedVolumes[volume.Name] = sidecarSet.Name
+			injectedVolumeSources[volume.Name] = volume
+		}
+	}
+	return conflicts
+}
+
+// SidecarSetPreviewHandler serves PreviewSidecarSetInjection over HTTP.
+// The caller is authenticated by the bearer token with TokenReview, and must be allowed to list SidecarSets.
+type SidecarSetPreviewHandler struct {
+	Client client.Client
+}
+
+var _ http.Handler = &SidecarSetPreviewHandler{}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+func (h *SidecarSetPreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if code, err := h.authorize(r); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	req := &SidecarSetInjectionPreviewRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	pod, err := req.toPod()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preview, err := PreviewSidecarSetInjection(r.Context(), h.Client, pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to preview sidecarSet injection", "pod", klog.KObj(pod))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(preview); err != nil {
+		klog.ErrorS(err, "Failed to write sidecarSet injection preview", "pod", klog.KObj(pod))
+	}
+}
+
+// authorize reviews the bearer token of the request, and checks whether the user is allowed to list SidecarSets.
+// It returns the http status code to respond if not authorized.
+func (h *SidecarSetPreviewHandler) authorize(r *http.Request) (int, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized, fmt.Errorf("bearer token is required")
+	}
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := h.Client.Create(r.Context(), tokenReview); err != nil {
+		klog.ErrorS(err, "Failed to review token for sidecarSet injection preview")
+		return http.StatusInternalServerError, fmt.Errorf("failed to review token: %v", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("unauthenticated: %s", tokenReview.Status.Error)
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    appsv1beta1.GroupVersion.Group,
+				Resource: "sidecarsets",
+				Verb:     "list",
+			},
+		},
+	}
+	if err := h.Client.Create(r.Context(), accessReview); err != nil {
+		klog.ErrorS(err, "Failed to review access for sidecarSet injection preview", "user", user.Username)
+		return http.StatusInternalServerError, fmt.Errorf("failed to review access: %v", err)
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s is not allowed to list sidecarsets", user.Username)
+	}
+	return http.StatusOK, nil
+}
+
+func (r *SidecarSetInjectionPreviewRequest) toPod() (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	switch {
+	case r.Pod != nil && r.Template != nil:
+		return nil, fmt.Errorf("only one of pod and template can be specified")
+	case r.Pod != nil:
+		pod = r.Pod
+	case r.Template != nil:
+		pod = &corev1.Pod{
+			ObjectMeta: r.Template.ObjectMeta,
+			Spec:       r.Template.Spec,
+		}
+	default:
+		return nil, fmt.Errorf("either pod or template must be specified")
+	}
+	if r.Namespace != "" {
+		pod.Namespace = r.Namespace
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = "default"
+	}
+	return pod, nil
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
+	"github.com/openkruise/kruise/pkg/util/fieldindex"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+func newPreviewClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).WithIndex(
+		&appsv1beta1.SidecarSet{}, fieldindex.IndexNameForSidecarSetNamespace, fieldindex.IndexSidecarSetV1Beta1,
+	).Build()
+}
+
+// newAuthPreviewClient reviews the token "admin" as an authenticated user, who is allowed if allowed is true.
+func newAuthPreviewClient(allowed bool, objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).WithIndex(
+		&appsv1beta1.SidecarSet{}, fieldindex.IndexNameForSidecarSetNamespace, fieldindex.IndexSidecarSetV1Beta1,
+	).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "admin" {
+					review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "admin"}}
+				}
+				return nil
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = allowed && review.Spec.User == "admin" && review.Spec.ResourceAttributes.Resource == "sidecarsets"
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func TestPreviewSidecarSetInjection(t *testing.T) {
+	conflicted := sidecarSet1.DeepCopy()
+	conflicted.Name = "sidecarset-conflicted"
+	conflicted.Spec.InitContainers = nil
+	conflicted.Spec.Containers = []appsv1beta1.SidecarContainer{
+		{Container: corev1.Container{Name: "nginx", Image: "nginx:1.0"}},
+		{Container: corev1.Container{Name: "log-agent", Image: "log-agent-image:2.0"}},
+	}
+
+	cases := []struct {
+		name               string
+		sidecarSets        []client.Object
+		podLabels          map[string]string
+		expectedSidecarSet []string
+		expectedContainers int
+		expectedConflicts  int
+	}{
+		{
+			name:               "no matched sidecarSet",
+			sidecarSets:        []client.Object{sidecarSet1.DeepCopy()},
+			podLabels:          map[string]string{"app": "doesnt-match"},
+			expectedSidecarSet: []string{},
+			expectedContainers: 1,
+		},
+		{
+			name:               "one matched sidecarSet",
+			sidecarSets:        []client.Object{sidecarSet1.DeepCopy()},
+			podLabels:          map[string]string{"app": "suxing-test"},
+			expectedSidecarSet: []string{"sidecarset1"},
+			expectedContainers: 3,
+		},
+		{
+			name:               "conflicted sidecarSets",
+			sidecarSets:        []client.Object{sidecarSet1.DeepCopy(), conflicted},
+			podLabels:          map[string]string{"app": "suxing-test"},
+			expectedSidecarSet: []string{"sidecarset-conflicted", "sidecarset1"},
+			expectedContainers: 4,
+			expectedConflicts:  2,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			podIn := pod1.DeepCopy()
+			podIn.Labels = cs.podLabels
+			origin := podIn.DeepCopy()
+
+			preview, err := PreviewSidecarSetInjection(context.TODO(), newPreviewClient(cs.sidecarSets...), podIn)
+			if err != nil {
+				t.Fatalf("preview failed: %v", err)
+			}
+			if !reflect.DeepEqual(origin, podIn) {
+				t.Fatalf("expect pod unchanged by preview")
+			}
+			var names []string
+			for _, item := range preview.MatchedSidecarSets {
+				names = append(names, item.Name)
+				if !item.Latest {
+					t.Fatalf("expect latest revision of sidecarSet %s", item.Name)
+				}
+			}
+			if len(names) != len(cs.expectedSidecarSet) || (len(names) > 0 && !reflect.DeepEqual(names, cs.expectedSidecarSet)) {
+				t.Fatalf("expect matched sidecarSets %v, but got %v", cs.expectedSidecarSet, names)
+			}
+			if len(preview.Pod.Spec.Containers) != cs.expectedContainers {
+				t.Fatalf("expect %d containers, but got %d", cs.expectedContainers, len(preview.Pod.Spec.Containers))
+			}
+			if len(preview.Conflicts) != cs.expectedConflicts {
+				t.Fatalf("expect %d conflicts, but got %v", cs.expectedConflicts, preview.Conflicts)
+			}
+			if len(names) > 0 && preview.Pod.Annotations[sidecarcontrol.SidecarSetListAnnotation] == "" {
+				t.Fatalf("expect sidecarSet list annotation injected")
+			}
+		})
+	}
+}
+
+func TestPreviewSidecarSetInjectionRevision(t *testing.T) {
+	newRevision := func(name, hash string, revision int64) *apps.ControllerRevision {
+		return &apps.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   webhookutil.GetNamespace(),
+				Name:        name,
+				Labels:      map[string]string{sidecarcontrol.SidecarSetKindName: sidecarSet1.Name},
+				Annotations: map[string]string{sidecarcontrol.SidecarSetHashAnnotation: hash},
+			},
+			Revision: revision,
+		}
+	}
+	hash := sidecarcontrol.GetSidecarSetRevision(sidecarSet1)
+
+	cases := []struct {
+		name             string
+		revisions        []client.Object
+		expectedRevision string
+		expectedLatest   bool
+	}{
+		{
+			name:           "latest spec not recorded",
+			revisions:      []client.Object{newRevision("sidecarset1-old", "old-hash", 1)},
+			expectedLatest: true,
+		},
+		{
+			name:             "latest spec recorded",
+			revisions:        []client.Object{newRevision("sidecarset1-old", "old-hash", 1), newRevision("sidecarset1-new", hash, 2)},
+			expectedRevision: "sidecarset1-new",
+			expectedLatest:   true,
+		},
+		{
+			name:             "history spec recorded",
+			revisions:        []client.Object{newRevision("sidecarset1-old", hash, 1), newRevision("sidecarset1-new", "new-hash", 2)},
+			expectedRevision: "sidecarset1-old",
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			sidecarSet := sidecarSet1.DeepCopy()
+			sidecarSet.Status.LatestRevision = "stale-revision"
+			podIn := pod1.DeepCopy()
+			podIn.Labels = map[string]string{"app": "suxing-test"}
+
+			preview, err := PreviewSidecarSetInjection(context.TODO(), newPreviewClient(append(cs.revisions, sidecarSet)...), podIn)
+			if err != nil {
+				t.Fatalf("preview failed: %v", err)
+			}
+			if len(preview.MatchedSidecarSets) != 1 {
+				t.Fatalf("expect 1 matched sidecarSet, but got %v", preview.MatchedSidecarSets)
+			}
+			item := preview.MatchedSidecarSets[0]
+			if item.ControllerRevision != cs.expectedRevision || item.Latest != cs.expectedLatest {
+				t.Fatalf("expect revision %q and latest %v, but got %q and %v", cs.expectedRevision, cs.expectedLatest, item.ControllerRevision, item.Latest)
+			}
+		})
+	}
+}
+
+func TestSidecarSetPreviewHandler(t *testing.T) {
+	handler := &SidecarSetPreviewHandler{Client: newAuthPreviewClient(true, sidecarSet1.DeepCopy())}
+	podIn := pod1.DeepCopy()
+
+	cases := []struct {
+		name         string
+		method       string
+		token        string
+		body         interface{}
+		expectedCode int
+	}{
+		{
+			name:         "get method",
+			method:       http.MethodGet,
+			token:        "admin",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "no token",
+			method:       http.MethodPost,
+			body:         &SidecarSetInjectionPreviewRequest{Pod: podIn},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid token",
+			method:       http.MethodPost,
+			token:        "invalid",
+			body:         &SidecarSetInjectionPreviewRequest{Pod: podIn},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "empty request",
+			method:       http.MethodPost,
+			token:        "admin",
+			body:         &SidecarSetInjectionPreviewRequest{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "both pod and template",
+			method: http.MethodPost,
+			token:  "admin",
+			body: &SidecarSetInjectionPreviewRequest{
+				Pod:      podIn,
+				Template: &corev1.PodTemplateSpec{ObjectMeta: podIn.ObjectMeta, Spec: podIn.Spec},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "pod",
+			method:       http.MethodPost,
+			token:        "admin",
+			body:         &SidecarSetInjectionPreviewRequest{Pod: podIn},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "template",
+			method: http.MethodPost,
+			token:  "admin",
+			body: &SidecarSetInjectionPreviewRequest{
+				Namespace: defaultNs,
+				Template:  &corev1.PodTemplateSpec{ObjectMeta: podIn.ObjectMeta, Spec: podIn.Spec},
+			},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			body, _ := json.Marshal(cs.body)
+			req := httptest.NewRequest(cs.method, SidecarSetPreviewPath, bytes.NewReader(body))
+			if cs.token != "" {
+				req.Header.Set("Authorization", "Bearer "+cs.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != cs.expectedCode {
+				t.Fatalf("expect code %d, but got %d: %s", cs.expectedCode, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			preview := &SidecarSetInjectionPreview{}
+			if err := json.Unmarshal(w.Body.Bytes(), preview); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(preview.MatchedSidecarSets) != 1 || preview.MatchedSidecarSets[0].Name != sidecarSet1.Name {
+				t.Fatalf("expect sidecarSet %s matched, but got %v", sidecarSet1.Name, preview.MatchedSidecarSets)
+			}
+			if len(preview.Pod.Spec.InitContainers) != 3 {
+				t.Fatalf("expect 3 initContainers, but got %d", len(preview.Pod.Spec.InitContainers))
+			}
+		})
+	}
+}
+
+func TestSidecarSetPreviewHandlerForbidden(t *testing.T) {
+	handler := &SidecarSetPreviewHandler{Client: newAuthPreviewClient(false, sidecarSet1.DeepCopy())}
+	body, _ := json.Marshal(&SidecarSetInjectionPreviewRequest{Pod: pod1.DeepCopy()})
+	req := httptest.NewRequest(http.MethodPost, SidecarSetPreviewPath, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expect code %d, but got %d: %s", http.StatusForbidden, w.Code, w.Body.String())
+	}
+}
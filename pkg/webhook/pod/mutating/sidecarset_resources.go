@@ -18,11 +18,13 @@ package mutating
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	"github.com/openkruise/kruise/pkg/control/sidecarcontrol"
@@ -67,43 +69,20 @@ func applyResourcesPolicy(
 	// Calculate limits
 	if policy.ResourceExpr.Limits != nil {
 		limits := corev1.ResourceList{}
-
-		// CPU limits
-		if policy.ResourceExpr.Limits.CPU != "" {
-			// Check if aggregated CPU limit exists (not unlimited)
-			if cpuValue, exists := aggregatedLimits[corev1.ResourceCPU]; exists {
-				cpuLimit, err := evaluateResourceExpression(
-					policy.ResourceExpr.Limits.CPU,
-					cpuValue,
-					true, // isLimit
-				)
-				if err != nil {
-					return fmt.Errorf("failed to evaluate CPU limits expression: %v", err)
-				}
-				if cpuLimit != nil {
-					limits[corev1.ResourceCPU] = *cpuLimit
-				}
+		for name, expr := range sidecarcontrol.GetResourceExprLimits(policy.ResourceExpr.Limits) {
+			// Check if aggregated limit exists (not unlimited)
+			value, exists := aggregatedLimits[name]
+			if !exists {
+				// aggregated limit doesn't exist (unlimited), so don't set it
+				continue
 			}
-			// else: aggregated CPU limit doesn't exist (unlimited), so don't set it
-		}
-
-		// Memory limits
-		if policy.ResourceExpr.Limits.Memory != "" {
-			// Check if aggregated Memory limit exists (not unlimited)
-			if memValue, exists := aggregatedLimits[corev1.ResourceMemory]; exists {
-				memLimit, err := evaluateResourceExpression(
-					policy.ResourceExpr.Limits.Memory,
-					memValue,
-					true, // isLimit
-				)
-				if err != nil {
-					return fmt.Errorf("failed to evaluate memory limits expression: %v", err)
-				}
-				if memLimit != nil {
-					limits[corev1.ResourceMemory] = *memLimit
-				}
+			limit, err := evaluateResourceExpression(name, expr, value, true)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate %s limits expression: %v", name, err)
+			}
+			if limit != nil {
+				limits[name] = *limit
 			}
-			// else: aggregated Memory limit doesn't exist (unlimited), so don't set it
 		}
 
 		if len(limits) > 0 {
@@ -114,34 +93,20 @@ func applyResourcesPolicy(
 	// Calculate requests
 	if policy.ResourceExpr.Requests != nil {
 		requests := corev1.ResourceList{}
-
-		// CPU requests
-		if policy.ResourceExpr.Requests.CPU != "" {
-			cpuRequest, err := evaluateResourceExpression(
-				policy.ResourceExpr.Requests.CPU,
-				aggregatedRequests[corev1.ResourceCPU],
-				false, // isLimit
-			)
-			if err != nil {
-				return fmt.Errorf("failed to evaluate CPU requests expression: %v", err)
-			}
-			if cpuRequest != nil {
-				requests[corev1.ResourceCPU] = *cpuRequest
+		for name, expr := range sidecarcontrol.GetResourceExprRequests(policy.ResourceExpr.Requests) {
+			// Extended resources can't be overcommitted, the request is always equal to the limit
+			if !isOvercommitAllowed(name) {
+				if limit, ok := resources.Limits[name]; ok {
+					requests[name] = limit
+				}
+				continue
 			}
-		}
-
-		// Memory requests
-		if policy.ResourceExpr.Requests.Memory != "" {
-			memRequest, err := evaluateResourceExpression(
-				policy.ResourceExpr.Requests.Memory,
-				aggregatedRequests[corev1.ResourceMemory],
-				false, // isLimit
-			)
+			request, err := evaluateResourceExpression(name, expr, aggregatedRequests[name], false)
 			if err != nil {
-				return fmt.Errorf("failed to evaluate memory requests expression: %v", err)
+				return fmt.Errorf("failed to evaluate %s requests expression: %v", name, err)
 			}
-			if memRequest != nil {
-				requests[corev1.ResourceMemory] = *memRequest
+			if request != nil {
+				requests[name] = *request
 			}
 		}
 
@@ -245,63 +210,40 @@ func aggregateResourcesBySum(containers []corev1.Container) (limits, requests co
 	limits = corev1.ResourceList{}
 	requests = corev1.ResourceList{}
 
-	cpuLimit := resource.NewQuantity(0, resource.DecimalSI)
-	memLimit := resource.NewQuantity(0, resource.DecimalSI)
-	cpuRequest := resource.NewQuantity(0, resource.DecimalSI)
-	memRequest := resource.NewQuantity(0, resource.DecimalSI)
-
-	// Track whether ALL containers have limits configured
+	// Track how many containers have each limit configured
 	// If any container doesn't have a limit, the aggregated result should be unlimited
-	allHaveLimitCPU := true
-	allHaveLimitMemory := true
+	limitCount := make(map[corev1.ResourceName]int)
 
 	for _, container := range containers {
-		// Sum CPU limits - but track if any container doesn't have it
-		if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
-			cpuLimit.Add(cpu)
-		} else {
-			// This container has no CPU limit (unlimited), so aggregate should be unlimited
-			allHaveLimitCPU = false
-		}
-
-		// Sum Memory limits - but track if any container doesn't have it
-		if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
-			memLimit.Add(mem)
-		} else {
-			// This container has no memory limit (unlimited), so aggregate should be unlimited
-			allHaveLimitMemory = false
-		}
-
-		// Sum CPU requests - treat missing as 0
-		if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
-			cpuRequest.Add(cpu)
+		// Sum limits - but track if any container doesn't have it
+		for name, value := range container.Resources.Limits {
+			sum := limits[name]
+			sum.Add(value)
+			limits[name] = sum
+			limitCount[name]++
 		}
-		// If not present, treat as 0 (no need to add)
 
-		// Sum Memory requests - treat missing as 0
-		if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
-			memRequest.Add(mem)
+		// Sum requests - treat missing as 0
+		for name, value := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(value)
+			requests[name] = sum
 		}
-		// If not present, treat as 0 (no need to add)
 	}
 
-	// Set aggregated limits only if ALL containers have limits configured
-	if allHaveLimitCPU {
-		limits[corev1.ResourceCPU] = *cpuLimit
-	}
-	// else: at least one container is unlimited, so don't set limit (unlimited)
-
-	if allHaveLimitMemory {
-		limits[corev1.ResourceMemory] = *memLimit
+	// Keep aggregated limits only if ALL containers have limits configured
+	for name := range limits {
+		if limitCount[name] != len(containers) {
+			// at least one container is unlimited, so don't set limit (unlimited)
+			delete(limits, name)
+		}
 	}
-	// else: at least one container is unlimited, so don't set limit (unlimited)
 
-	// Set aggregated requests (0 is valid for requests)
-	if !cpuRequest.IsZero() {
-		requests[corev1.ResourceCPU] = *cpuRequest
-	}
-	if !memRequest.IsZero() {
-		requests[corev1.ResourceMemory] = *memRequest
+	// Drop zero requests, missing requests are treated as 0
+	for name, value := range requests {
+		if value.IsZero() {
+			delete(requests, name)
+		}
 	}
 
 	return limits, requests
@@ -312,55 +254,32 @@ func aggregateResourcesByMax(containers []corev1.Container) (limits, requests co
 	limits = corev1.ResourceList{}
 	requests = corev1.ResourceList{}
 
-	// Track whether ALL containers have limits configured
+	// Track how many containers have each limit configured
 	// If any container doesn't have a limit, the aggregated result should be unlimited
-	allHaveLimitCPU := true
-	allHaveLimitMemory := true
+	limitCount := make(map[corev1.ResourceName]int)
 
 	for _, container := range containers {
-		// Max CPU limits - but track if any container doesn't have it
-		if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
-			if maxCPU, exists := limits[corev1.ResourceCPU]; !exists || cpu.Cmp(maxCPU) > 0 {
-				limits[corev1.ResourceCPU] = cpu
-			}
-		} else {
-			// This container has no CPU limit (unlimited), so aggregate should be unlimited
-			allHaveLimitCPU = false
-		}
-
-		// Max Memory limits - but track if any container doesn't have it
-		if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
-			if maxMem, exists := limits[corev1.ResourceMemory]; !exists || mem.Cmp(maxMem) > 0 {
-				limits[corev1.ResourceMemory] = mem
-			}
-		} else {
-			// This container has no memory limit (unlimited), so aggregate should be unlimited
-			allHaveLimitMemory = false
-		}
-
-		// Max CPU requests - treat missing as 0
-		if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
-			if maxCPU, exists := requests[corev1.ResourceCPU]; !exists || cpu.Cmp(maxCPU) > 0 {
-				requests[corev1.ResourceCPU] = cpu
+		// Max limits - but track if any container doesn't have it
+		for name, value := range container.Resources.Limits {
+			if maxValue, exists := limits[name]; !exists || value.Cmp(maxValue) > 0 {
+				limits[name] = value
 			}
+			limitCount[name]++
 		}
-		// If not present, treat as 0 (implicitly handled by not updating requests map)
 
-		// Max Memory requests - treat missing as 0
-		if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
-			if maxMem, exists := requests[corev1.ResourceMemory]; !exists || mem.Cmp(maxMem) > 0 {
-				requests[corev1.ResourceMemory] = mem
+		// Max requests - treat missing as 0
+		for name, value := range container.Resources.Requests {
+			if maxValue, exists := requests[name]; !exists || value.Cmp(maxValue) > 0 {
+				requests[name] = value
 			}
 		}
-		// If not present, treat as 0 (implicitly handled by not updating requests map)
 	}
 
 	// If any container doesn't have limit configured, remove it to indicate unlimited
-	if !allHaveLimitCPU {
-		delete(limits, corev1.ResourceCPU)
-	}
-	if !allHaveLimitMemory {
-		delete(limits, corev1.ResourceMemory)
+	for name := range limits {
+		if limitCount[name] != len(containers) {
+			delete(limits, name)
+		}
 	}
 
 	return limits, requests
@@ -370,12 +289,11 @@ func aggregateResourcesByMax(containers []corev1.Container) (limits, requests co
 // Returns nil if the result is unlimited
 // Note: This function should only be called when aggregatedValue is valid (not unlimited)
 func evaluateResourceExpression(
+	name corev1.ResourceName,
 	expr string,
 	aggregatedValue resource.Quantity,
 	isLimit bool,
 ) (*resource.Quantity, error) {
-	// Prepare variable for calculator
-	varName := "cpu"
 	if expr == "" {
 		// Empty expression means:
 		// - For limits: unlimited (return nil)
@@ -386,15 +304,10 @@ func evaluateResourceExpression(
 		return resource.NewQuantity(0, resource.DecimalSI), nil
 	}
 
-	// Detect if expression contains "cpu" or "memory" variable
-	if regexp.MustCompile(`\bmemory\b`).MatchString(expr) {
-		varName = "memory"
-	}
-
-	// Create calculator with variable
+	// Create calculator with the variable of the resource, e.g. cpu, memory, ephemeral_storage
 	calc := calculator.NewCalculator()
 	vars := make(map[string]*calculator.Value)
-	vars[varName] = &calculator.Value{
+	vars[sidecarcontrol.GetResourceExpressionVariable(name)] = &calculator.Value{
 		IsQuantity: true,
 		Quantity:   aggregatedValue,
 	}
@@ -407,21 +320,35 @@ func evaluateResourceExpression(
 	}
 
 	// Convert result to Quantity
+	quantity := &result.Quantity
 	if !result.IsQuantity {
 		// If result is a number, convert to Quantity
 		// For CPU: use milli (m) unit
-		// For memory: use binary (Mi/Gi) unit based on magnitude
-		if varName == "cpu" {
+		// For others: use DecimalSI
+		if name == corev1.ResourceCPU {
 			// Convert to millicores
 			millis := int64(result.Number * 1000)
 			return resource.NewMilliQuantity(millis, resource.DecimalSI), nil
-		} else {
-			// For memory, use DecimalSI
-			return resource.NewQuantity(int64(result.Number), resource.DecimalSI), nil
 		}
+		number := result.Number
+		if !isOvercommitAllowed(name) {
+			number = math.Ceil(number)
+		}
+		quantity = resource.NewQuantity(int64(number), resource.DecimalSI)
+	}
+
+	// Extended resources must be whole numbers, so the result is rounded up
+	if !isOvercommitAllowed(name) {
+		quantity.RoundUp(0)
 	}
 
-	return &result.Quantity, nil
+	return quantity, nil
+}
+
+// isOvercommitAllowed returns whether the resource can be overcommitted, which is true for the native resources
+// and false for the extended resources.
+func isOvercommitAllowed(name corev1.ResourceName) bool {
+	return !v1helper.IsExtendedResourceName(name) && !v1helper.IsHugePageResourceName(name)
 }
 
 // getContainerNames returns a slice of container names (for logging)
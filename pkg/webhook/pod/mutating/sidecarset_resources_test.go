@@ -611,6 +611,82 @@ func TestApplyResourcesPolicy(t *testing.T) {
 	}
 }
 
+func TestApplyResourcesPolicyWithOtherResources(t *testing.T) {
+	gpu := corev1.ResourceName("nvidia.com/gpu")
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app1",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+							gpu:                             resource.MustParse("2"),
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceEphemeralStorage: resource.MustParse("4Gi"),
+							gpu:                             resource.MustParse("2"),
+						},
+					},
+				},
+				{
+					Name: "app2",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+							gpu:                             resource.MustParse("2"),
+						},
+						Requests: corev1.ResourceList{
+							gpu: resource.MustParse("2"),
+						},
+					},
+				},
+			},
+		},
+	}
+	sidecarContainer := &appsv1beta1.SidecarContainer{
+		Container: corev1.Container{Name: "sidecar1"},
+		ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+			TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+			TargetContainersNameRegex: ".*",
+			ResourceExpr: appsv1beta1.ResourceExpr{
+				Limits: &appsv1beta1.ResourceExprLimits{
+					EphemeralStorage:  "ephemeral_storage*10%",
+					ExtendedResources: map[corev1.ResourceName]string{gpu: "nvidia_com_gpu/4"},
+				},
+				Requests: &appsv1beta1.ResourceExprRequests{
+					EphemeralStorage:  "max(ephemeral_storage*25%, 512Mi)",
+					ExtendedResources: map[corev1.ResourceName]string{gpu: "nvidia_com_gpu/4"},
+				},
+			},
+		},
+	}
+
+	if err := applyResourcesPolicy(pod, sidecarContainer, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+			gpu:                             resource.MustParse("1"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+			gpu:                             resource.MustParse("1"),
+		},
+	}
+	for name, value := range expected.Limits {
+		if actual := sidecarContainer.Resources.Limits[name]; actual.Cmp(value) != 0 {
+			t.Errorf("Expected %s limit %s, got %s", name, value.String(), actual.String())
+		}
+	}
+	for name, value := range expected.Requests {
+		if actual := sidecarContainer.Resources.Requests[name]; actual.Cmp(value) != 0 {
+			t.Errorf("Expected %s request %s, got %s", name, value.String(), actual.String())
+		}
+	}
+}
+
 func TestAggregateResourcesBySum(t *testing.T) {
 	t.Run("all containers have limits", func(t *testing.T) {
 		containers := []corev1.Container{
@@ -1045,6 +1121,7 @@ func TestGetTargetContainers(t *testing.T) {
 func TestEvaluateResourceExpression(t *testing.T) {
 	tests := []struct {
 		name            string
+		resourceName    corev1.ResourceName
 		expr            string
 		aggregatedValue resource.Quantity
 		isLimit         bool
@@ -1080,6 +1157,7 @@ func TestEvaluateResourceExpression(t *testing.T) {
 		},
 		{
 			name:            "percentage expression - memory*30%",
+			resourceName:    corev1.ResourceMemory,
 			expr:            "memory*30%",
 			aggregatedValue: resource.MustParse("600Mi"),
 			isLimit:         true,
@@ -1116,6 +1194,7 @@ func TestEvaluateResourceExpression(t *testing.T) {
 		},
 		{
 			name:            "complex expression - max(memory*20% + 100Mi, 200Mi)",
+			resourceName:    corev1.ResourceMemory,
 			expr:            "max(memory*20% + 100Mi, 200Mi)",
 			aggregatedValue: resource.MustParse("800Mi"),
 			isLimit:         true,
@@ -1134,6 +1213,7 @@ func TestEvaluateResourceExpression(t *testing.T) {
 		},
 		{
 			name:            "memory constant - 1Gi",
+			resourceName:    corev1.ResourceMemory,
 			expr:            "1Gi",
 			aggregatedValue: resource.MustParse("500Mi"),
 			isLimit:         true,
@@ -1160,6 +1240,7 @@ func TestEvaluateResourceExpression(t *testing.T) {
 		},
 		{
 			name:            "percentage of memory request",
+			resourceName:    corev1.ResourceMemory,
 			expr:            "memory*25%",
 			aggregatedValue: resource.MustParse("400Mi"),
 			isLimit:         false,
@@ -1167,6 +1248,46 @@ func TestEvaluateResourceExpression(t *testing.T) {
 			expectError:     false,
 			expectedValue:   "100Mi",
 		},
+		{
+			name:            "ephemeral-storage expression",
+			resourceName:    corev1.ResourceEphemeralStorage,
+			expr:            "max(ephemeral_storage*10%, 1Gi)",
+			aggregatedValue: resource.MustParse("20Gi"),
+			isLimit:         true,
+			expectedValue:   "2Gi",
+		},
+		{
+			name:            "extended resource expression",
+			resourceName:    "nvidia.com/gpu",
+			expr:            "nvidia_com_gpu / 2",
+			aggregatedValue: resource.MustParse("4"),
+			isLimit:         true,
+			expectedValue:   "2",
+		},
+		{
+			name:            "extended resource with fractional result should be rounded up",
+			resourceName:    "nvidia.com/gpu",
+			expr:            "nvidia_com_gpu / 2",
+			aggregatedValue: resource.MustParse("3"),
+			isLimit:         true,
+			expectedValue:   "2",
+		},
+		{
+			name:            "extended resource with fractional number should be rounded up",
+			resourceName:    "nvidia.com/gpu",
+			expr:            "0.5",
+			aggregatedValue: resource.MustParse("3"),
+			isLimit:         true,
+			expectedValue:   "1",
+		},
+		{
+			name:            "variable of another resource should return error",
+			resourceName:    corev1.ResourceEphemeralStorage,
+			expr:            "memory * 2",
+			aggregatedValue: resource.MustParse("1Gi"),
+			isLimit:         true,
+			expectError:     true,
+		},
 		{
 			name:            "number cpu",
 			expr:            "1",
@@ -1178,6 +1299,7 @@ func TestEvaluateResourceExpression(t *testing.T) {
 		},
 		{
 			name:            "number memory",
+			resourceName:    corev1.ResourceMemory,
 			expr:            "1",
 			aggregatedValue: resource.MustParse("2Mi"),
 			isLimit:         false,
@@ -1189,7 +1311,11 @@ func TestEvaluateResourceExpression(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := evaluateResourceExpression(tt.expr, tt.aggregatedValue, tt.isLimit)
+			resourceName := tt.resourceName
+			if resourceName == "" {
+				resourceName = corev1.ResourceCPU
+			}
+			result, err := evaluateResourceExpression(resourceName, tt.expr, tt.aggregatedValue, tt.isLimit)
 
 			// Check error expectation
 			if tt.expectError {
@@ -183,6 +183,43 @@ func TestValidateUpdatePodForPub(t *testing.T) {
 				return pubStatus
 			},
 		},
+		{
+			name: "valid update pod, maintenance window ended, reject",
+			oldPod: func() *corev1.Pod {
+				pod := podDemo.DeepCopy()
+				return pod
+			},
+			newPod: func() *corev1.Pod {
+				pod := podDemo.DeepCopy()
+				pod.Spec.Containers[0].Image = "nginx:1.18"
+				return pod
+			},
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				pub.Status.CurrentAvailable = 8
+				pub.Status.UnavailableAllowed = 1
+				// 2025-01-01 02:00 - 03:00 UTC
+				pub.Status.CurrentMaintenanceWindow = &policyv1alpha1.MaintenanceWindowStatus{
+					StartTime: metav1.NewTime(time.Unix(1735696800, 0)),
+					EndTime:   metav1.NewTime(time.Unix(1735700400, 0)),
+				}
+				return pub
+			},
+			expectAllow: false,
+			expectPubStatus: func() *policyv1alpha1.PodUnavailableBudgetStatus {
+				pubStatus := pubDemo.Status.DeepCopy()
+				pubStatus.CurrentAvailable = 8
+				pubStatus.UnavailableAllowed = 1
+				pubStatus.CurrentMaintenanceWindow = &policyv1alpha1.MaintenanceWindowStatus{
+					StartTime: metav1.NewTime(time.Unix(1735696800, 0)),
+					EndTime:   metav1.NewTime(time.Unix(1735700400, 0)),
+				}
+				return pubStatus
+			},
+		},
 		{
 			name: "valid update pod, pod deletion, ignore",
 			oldPod: func() *corev1.Pod {
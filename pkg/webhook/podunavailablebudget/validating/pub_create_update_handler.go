@@ -28,12 +28,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	validationutil "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	policyv1alpha1 "github.com/openkruise/kruise/apis/policy/v1alpha1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
@@ -163,9 +166,63 @@ func validatePodUnavailableBudgetSpec(obj *policyv1alpha1.PodUnavailableBudget,
 		allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*spec.MinAvailable, fldPath.Child("minAvailable"))...)
 		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*spec.MinAvailable, fldPath.Child("minAvailable"))...)
 	}
+	if spec.TopologyKey != "" {
+		for _, msg := range validationutil.IsQualifiedName(spec.TopologyKey) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("topologyKey"), spec.TopologyKey, msg))
+		}
+	}
+	allErrs = append(allErrs, validateMaintenanceWindows(spec, fldPath)...)
 	return allErrs
 }
 
+func validateMaintenanceWindows(spec *policyv1alpha1.PodUnavailableBudgetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range spec.MaintenanceWindows {
+		window := &spec.MaintenanceWindows[i]
+		idxPath := fldPath.Child("maintenanceWindows").Index(i)
+		if window.TimeZone != nil {
+			if *window.TimeZone == "" || strings.EqualFold(*window.TimeZone, "Local") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("timeZone"), *window.TimeZone, "timeZone must be an explicit time zone as defined in https://www.iana.org/time-zones"))
+				continue
+			}
+			if strings.Contains(window.Schedule, "TZ") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, "cannot use both timeZone field and TZ or CRON_TZ in schedule"))
+				continue
+			}
+		}
+		if _, err := pubcontrol.ParseMaintenanceWindowSchedule(window); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("duration"), window.Duration.String(), "duration must be greater than 0"))
+		}
+	}
+
+	if spec.OutOfWindowMaxUnavailable != nil {
+		childPath := fldPath.Child("outOfWindowMaxUnavailable")
+		if len(spec.MaintenanceWindows) == 0 {
+			allErrs = append(allErrs, field.Invalid(childPath, spec.OutOfWindowMaxUnavailable, "outOfWindowMaxUnavailable requires maintenanceWindows"))
+			return allErrs
+		}
+		allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*spec.OutOfWindowMaxUnavailable, childPath)...)
+		allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*spec.OutOfWindowMaxUnavailable, childPath)...)
+		// the budget outside the windows must be stricter than the one inside
+		if spec.MaxUnavailable != nil && spec.MaxUnavailable.Type == spec.OutOfWindowMaxUnavailable.Type &&
+			getIntOrPercentValue(*spec.OutOfWindowMaxUnavailable) > getIntOrPercentValue(*spec.MaxUnavailable) {
+			allErrs = append(allErrs, field.Invalid(childPath, spec.OutOfWindowMaxUnavailable, "outOfWindowMaxUnavailable must not be greater than maxUnavailable"))
+		}
+	}
+	return allErrs
+}
+
+func getIntOrPercentValue(intOrStringValue intstr.IntOrString) int {
+	if intOrStringValue.Type == intstr.Int {
+		return intOrStringValue.IntValue()
+	}
+	value, _ := strconv.Atoi(strings.TrimSuffix(intOrStringValue.StrVal, "%"))
+	return value
+}
+
 func validatePubConflict(pub *policyv1alpha1.PodUnavailableBudget, others []policyv1alpha1.PodUnavailableBudget, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -19,11 +19,13 @@ package validating
 import (
 	"context"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -181,6 +183,72 @@ func TestValidatingPub(t *testing.T) {
 			},
 			expectErrList: 0,
 		},
+		{
+			name: "valid pub maintenance windows",
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: ptr.To("Asia/Shanghai")},
+				}
+				pub.Spec.OutOfWindowMaxUnavailable = ptr.To(intstr.FromString("5%"))
+				return pub
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid pub maintenance windows",
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.MaintenanceWindows = []policyv1alpha1.MaintenanceWindow{
+					{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+					{Schedule: "0 2 * * *", TimeZone: ptr.To("Mars/Olympus")},
+					{Schedule: "TZ=UTC 0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: ptr.To("UTC")},
+					{Schedule: "0 2 * * *"},
+				}
+				pub.Spec.OutOfWindowMaxUnavailable = ptr.To(intstr.FromString("50%"))
+				return pub
+			},
+			// invalid schedule, invalid time zone and missing duration, TZ with timeZone, missing duration,
+			// outOfWindowMaxUnavailable greater than maxUnavailable
+			expectErrList: 6,
+		},
+		{
+			name: "valid pub topologyKey",
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.TopologyKey = "topology.kubernetes.io/zone"
+				return pub
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid pub topologyKey",
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.TopologyKey = "topology/kubernetes/zone"
+				return pub
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "invalid pub outOfWindowMaxUnavailable without maintenance windows",
+			pub: func() *policyv1alpha1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.OutOfWindowMaxUnavailable = ptr.To(intstr.FromInt32(0))
+				return pub
+			},
+			expectErrList: 1,
+		},
 	}
 
 	decoder := admission.NewDecoder(scheme)
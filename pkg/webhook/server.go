@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
+	"github.com/openkruise/kruise/pkg/webhook/pod/mutating"
 	"github.com/openkruise/kruise/pkg/webhook/types"
 	webhookcontroller "github.com/openkruise/kruise/pkg/webhook/util/controller"
 	"github.com/openkruise/kruise/pkg/webhook/util/health"
@@ -91,6 +92,11 @@ func SetupWithManager(mgr manager.Manager) error {
 		klog.V(3).InfoS("Registered webhook handler", "path", path)
 	}
 
+	// register sidecarSet injection preview handler, which is available along with the pod mutating webhook
+	if _, ok := HandlerMap["/mutate-pod"]; ok {
+		server.Register(mutating.SidecarSetPreviewPath, &mutating.SidecarSetPreviewHandler{Client: mgr.GetClient()})
+	}
+
 	// register conversion webhook
 	server.Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
 
@@ -36,6 +36,7 @@ import (
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	"k8s.io/kubernetes/pkg/apis/core"
 	corev1 "k8s.io/kubernetes/pkg/apis/core/v1"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	corevalidation "k8s.io/kubernetes/pkg/apis/core/validation"
 	"k8s.io/kubernetes/pkg/fieldpath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -244,11 +245,22 @@ func validateSidecarSetUpdateStrategy(strategy *appsv1beta1.SidecarSetUpdateStra
 		if err := strategy.PriorityStrategy.FieldsValidation(); err != nil {
 			allErrs = append(allErrs, field.Required(fldPath.Child("priorityStrategy"), err.Error()))
 		}
+		allErrs = append(allErrs, webhookutil.ValidateUpdatePriorityExtender(strategy.PriorityStrategy, fldPath.Child("priorityStrategy"))...)
 		if strategy.ScatterStrategy != nil {
 			if err := strategy.ScatterStrategy.FieldsValidation(); err != nil {
 				allErrs = append(allErrs, field.Required(fldPath.Child("scatterStrategy"), err.Error()))
 			}
 		}
+		if policy := strategy.FailurePolicy; policy != nil {
+			if policy.Type != appsv1beta1.RollbackSidecarSetUpdateFailurePolicyType {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("failurePolicy", "type"), policy.Type,
+					[]string{string(appsv1beta1.RollbackSidecarSetUpdateFailurePolicyType)}))
+			}
+			if policy.UnreadyThresholdSeconds != nil && *policy.UnreadyThresholdSeconds <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("failurePolicy", "unreadyThresholdSeconds"),
+					*policy.UnreadyThresholdSeconds, "must be greater than 0"))
+			}
+		}
 	}
 	return allErrs
 }
@@ -548,56 +560,67 @@ func validateResourceExpr(expr *appsv1beta1.ResourceExpr, fldPath *field.Path) f
 
 	// Validate Limits if present
 	if expr.Limits != nil {
-		allErrs = append(allErrs, validateResourceExprLimits(expr.Limits, fldPath.Child("limits"))...)
+		allErrs = append(allErrs, validateExtendedResourceNames(expr.Limits.ExtendedResources, fldPath.Child("limits", "extendedResources"))...)
+		allErrs = append(allErrs, validateResourceExpressions(sidecarcontrol.GetResourceExprLimits(expr.Limits), fldPath.Child("limits"))...)
 	}
 
 	// Validate Requests if present
 	if expr.Requests != nil {
-		allErrs = append(allErrs, validateResourceExprRequests(expr.Requests, fldPath.Child("requests"))...)
+		allErrs = append(allErrs, validateExtendedResourceNames(expr.Requests.ExtendedResources, fldPath.Child("requests", "extendedResources"))...)
+		allErrs = append(allErrs, validateResourceExpressions(sidecarcontrol.GetResourceExprRequests(expr.Requests), fldPath.Child("requests"))...)
+
+		// Extended resources can't be overcommitted, so requests must be equal to limits
+		var limits map[v1.ResourceName]string
+		if expr.Limits != nil {
+			limits = expr.Limits.ExtendedResources
+		}
+		for name, request := range expr.Requests.ExtendedResources {
+			if limit, ok := limits[name]; !ok || limit != request {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "extendedResources").Key(string(name)), request,
+					"extended resource expression in requests must be the same as the one in limits"))
+			}
+		}
 	}
 
 	return allErrs
 }
 
-// validateResourceExprLimits validates the ResourceExprLimits configuration
-func validateResourceExprLimits(limits *appsv1beta1.ResourceExprLimits, fldPath *field.Path) field.ErrorList {
+// validateExtendedResourceNames validates the resource names of extended resource expressions
+func validateExtendedResourceNames(extended map[v1.ResourceName]string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-
-	// Validate CPU expression if present
-	if limits.CPU != "" {
-		if err := validateResourceExpression(limits.CPU, "cpu"); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), limits.CPU, err.Error()))
-		}
-	}
-
-	// Validate Memory expression if present
-	if limits.Memory != "" {
-		if err := validateResourceExpression(limits.Memory, "memory"); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), limits.Memory, err.Error()))
+	for name := range extended {
+		switch name {
+		case v1.ResourceCPU, v1.ResourceMemory, v1.ResourceEphemeralStorage:
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(name)), name,
+				fmt.Sprintf("use the dedicated field for %s instead", name)))
+		default:
+			if !v1helper.IsExtendedResourceName(name) && !v1helper.IsHugePageResourceName(name) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key(string(name)), name,
+					"must be an extended resource name or a hugepages resource name"))
+			}
 		}
 	}
-
 	return allErrs
 }
 
-// validateResourceExprRequests validates the ResourceExprRequests configuration
-func validateResourceExprRequests(requests *appsv1beta1.ResourceExprRequests, fldPath *field.Path) field.ErrorList {
+// validateResourceExpressions validates the resource expressions keyed by resource name,
+// each expression can only refer to the variable of its own resource
+func validateResourceExpressions(expressions map[v1.ResourceName]string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-
-	// Validate CPU expression if present
-	if requests.CPU != "" {
-		if err := validateResourceExpression(requests.CPU, "cpu"); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), requests.CPU, err.Error()))
-		}
-	}
-
-	// Validate Memory expression if present
-	if requests.Memory != "" {
-		if err := validateResourceExpression(requests.Memory, "memory"); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), requests.Memory, err.Error()))
+	for name, expr := range expressions {
+		if err := validateResourceExpression(expr, sidecarcontrol.GetResourceExpressionVariable(name)); err != nil {
+			childPath := fldPath.Child("extendedResources").Key(string(name))
+			switch name {
+			case v1.ResourceCPU:
+				childPath = fldPath.Child("cpu")
+			case v1.ResourceMemory:
+				childPath = fldPath.Child("memory")
+			case v1.ResourceEphemeralStorage:
+				childPath = fldPath.Child("ephemeralStorage")
+			}
+			allErrs = append(allErrs, field.Invalid(childPath, expr, err.Error()))
 		}
 	}
-
 	return allErrs
 }
 
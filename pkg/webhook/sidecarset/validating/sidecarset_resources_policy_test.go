@@ -540,6 +540,143 @@ func TestValidateResourcesPolicy(t *testing.T) {
 			expectErrors:  1,
 			errorContains: []string{"invalid expression"},
 		},
+		{
+			name: "valid ephemeral-storage and extended resources",
+			container: appsv1beta1.SidecarContainer{
+				Container: corev1.Container{
+					Name:                     "test-sidecar",
+					Image:                    "test-image",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+				ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+					Type: appsv1beta1.ShareVolumePolicyDisabled,
+				},
+				ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+					TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+					TargetContainersNameRegex: "^.*",
+					ResourceExpr: appsv1beta1.ResourceExpr{
+						Limits: &appsv1beta1.ResourceExprLimits{
+							EphemeralStorage:  "max(ephemeral_storage*10%, 1Gi)",
+							ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "nvidia_com_gpu/2"},
+						},
+						Requests: &appsv1beta1.ResourceExprRequests{
+							EphemeralStorage:  "512Mi",
+							ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "nvidia_com_gpu/2"},
+						},
+					},
+				},
+			},
+			expectErrors: 0,
+		},
+		{
+			name: "ephemeral-storage expression referring to memory - should fail",
+			container: appsv1beta1.SidecarContainer{
+				Container: corev1.Container{
+					Name:                     "test-sidecar",
+					Image:                    "test-image",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+				ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+					Type: appsv1beta1.ShareVolumePolicyDisabled,
+				},
+				ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+					TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+					TargetContainersNameRegex: "^.*",
+					ResourceExpr: appsv1beta1.ResourceExpr{
+						Limits: &appsv1beta1.ResourceExprLimits{
+							EphemeralStorage: "memory*10%",
+						},
+					},
+				},
+			},
+			expectErrors:  1,
+			errorContains: []string{"undefined variable"},
+		},
+		{
+			name: "native resource in extended resources - should fail",
+			container: appsv1beta1.SidecarContainer{
+				Container: corev1.Container{
+					Name:                     "test-sidecar",
+					Image:                    "test-image",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+				ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+					Type: appsv1beta1.ShareVolumePolicyDisabled,
+				},
+				ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+					TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+					TargetContainersNameRegex: "^.*",
+					ResourceExpr: appsv1beta1.ResourceExpr{
+						Limits: &appsv1beta1.ResourceExprLimits{
+							ExtendedResources: map[corev1.ResourceName]string{"cpu": "cpu"},
+						},
+					},
+				},
+			},
+			expectErrors:  1,
+			errorContains: []string{"dedicated field"},
+		},
+		{
+			name: "non extended resource name - should fail",
+			container: appsv1beta1.SidecarContainer{
+				Container: corev1.Container{
+					Name:                     "test-sidecar",
+					Image:                    "test-image",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+				ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+					Type: appsv1beta1.ShareVolumePolicyDisabled,
+				},
+				ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+					TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+					TargetContainersNameRegex: "^.*",
+					ResourceExpr: appsv1beta1.ResourceExpr{
+						Limits: &appsv1beta1.ResourceExprLimits{
+							ExtendedResources: map[corev1.ResourceName]string{"gpu": "gpu"},
+						},
+					},
+				},
+			},
+			expectErrors:  1,
+			errorContains: []string{"must be an extended resource name"},
+		},
+		{
+			name: "extended resource requests differ from limits - should fail",
+			container: appsv1beta1.SidecarContainer{
+				Container: corev1.Container{
+					Name:                     "test-sidecar",
+					Image:                    "test-image",
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+				ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+					Type: appsv1beta1.ShareVolumePolicyDisabled,
+				},
+				ResourcesPolicy: &appsv1beta1.ResourcesPolicy{
+					TargetContainerMode:       appsv1beta1.TargetContainerModeSum,
+					TargetContainersNameRegex: "^.*",
+					ResourceExpr: appsv1beta1.ResourceExpr{
+						Limits: &appsv1beta1.ResourceExprLimits{
+							ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "1"},
+						},
+						Requests: &appsv1beta1.ResourceExprRequests{
+							ExtendedResources: map[corev1.ResourceName]string{"nvidia.com/gpu": "2"},
+						},
+					},
+				},
+			},
+			expectErrors:  1,
+			errorContains: []string{"must be the same as the one in limits"},
+		},
 	}
 
 	for _, tt := range tests {
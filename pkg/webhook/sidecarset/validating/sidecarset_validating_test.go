@@ -841,6 +841,42 @@ func TestValidateSidecarSet(t *testing.T) {
 			},
 			expectErrs: 0, // Should pass because native sidecar (RestartPolicy: Always) can have ResourcesPolicy
 		},
+		{
+			caseName: "wrong-failurePolicy",
+			sidecarSet: appsv1beta1.SidecarSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sidecarset"},
+				Spec: appsv1beta1.SidecarSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"a": "b"},
+					},
+					UpdateStrategy: appsv1beta1.SidecarSetUpdateStrategy{
+						Type: appsv1beta1.RollingUpdateSidecarSetStrategyType,
+						FailurePolicy: &appsv1beta1.SidecarSetUpdateFailurePolicy{
+							Type:                    "Ignore",
+							UnreadyThresholdSeconds: ptr.To(int32(0)),
+						},
+					},
+					Containers: []appsv1beta1.SidecarContainer{
+						{
+							PodInjectPolicy: appsv1beta1.BeforeAppContainerType,
+							ShareVolumePolicy: appsv1beta1.ShareVolumePolicy{
+								Type: appsv1beta1.ShareVolumePolicyDisabled,
+							},
+							UpgradeStrategy: appsv1beta1.SidecarContainerUpgradeStrategy{
+								UpgradeType: appsv1beta1.SidecarContainerColdUpgrade,
+							},
+							Container: corev1.Container{
+								Name:                     "test-sidecar",
+								Image:                    "test-image",
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							},
+						},
+					},
+				},
+			},
+			expectErrs: 2,
+		},
 	}
 
 	SidecarSetRevisions := []client.Object{
@@ -14,6 +14,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	apivalidation "k8s.io/kubernetes/pkg/apis/core/validation"
@@ -125,6 +127,8 @@ func validateRollingUpdateStatefulSetStrategyTypeUnorderedUpdate(spec *appsv1bet
 				Child("rollingUpdate").Child("unorderedUpdate").Child("priorityStrategy"),
 				err.Error()))
 		}
+		allErrs = append(allErrs, webhookutil.ValidateUpdatePriorityExtender(spec.UpdateStrategy.RollingUpdate.UnorderedUpdate.PriorityStrategy,
+			fldPath.Child("updateStrategy", "rollingUpdate", "unorderedUpdate", "priorityStrategy"))...)
 	}
 	return allErrs
 }
@@ -161,6 +165,61 @@ func validateRollingUpdateStatefulSetStrategyType(spec *appsv1beta1.StatefulSetS
 		// validate the `spec.UpdateStrategy.RollingUpdate.UnorderedUpdate` related fields
 		allErrs = append(allErrs, validateRollingUpdateStatefulSetStrategyTypeUnorderedUpdate(spec, fldPath)...)
 
+		// validate the `spec.UpdateStrategy.RollingUpdate.RoleAwareUpdate` related fields
+		allErrs = append(allErrs, validateRoleAwareUpdate(spec.UpdateStrategy.RollingUpdate.RoleAwareUpdate,
+			fldPath.Child("updateStrategy").Child("rollingUpdate").Child("roleAwareUpdate"))...)
+
+		// validate the `spec.UpdateStrategy.RollingUpdate.SnapshotBeforeUpdate` related fields
+		allErrs = append(allErrs, validateSnapshotBeforeUpdate(spec,
+			fldPath.Child("updateStrategy").Child("rollingUpdate").Child("snapshotBeforeUpdate"))...)
+	}
+	return allErrs
+}
+
+func validateSnapshotBeforeUpdate(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	strategy := spec.UpdateStrategy.RollingUpdate.SnapshotBeforeUpdate
+	if strategy == nil {
+		return allErrs
+	}
+	if len(spec.VolumeClaimTemplates) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, strategy, "volumeClaimTemplates must not be empty"))
+	}
+	if name := strategy.VolumeSnapshotClassName; name != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("volumeSnapshotClassName"), *name, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateRoleAwareUpdate(strategy *appsv1beta1.RoleAwareUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy == nil {
+		return allErrs
+	}
+	if strategy.RoleLabelKey == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("roleLabelKey"), ""))
+	} else {
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelName(strategy.RoleLabelKey, fldPath.Child("roleLabelKey"))...)
+	}
+	if strategy.SwitchoverTimeoutSeconds != nil && *strategy.SwitchoverTimeoutSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("switchoverTimeoutSeconds"), *strategy.SwitchoverTimeoutSeconds, "must be greater than 0"))
+	}
+	if strategy.MaxSwitchoverAttempts != nil && *strategy.MaxSwitchoverAttempts <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSwitchoverAttempts"), *strategy.MaxSwitchoverAttempts, "must be greater than 0"))
+	}
+	if strategy.Switchover != nil {
+		httpPost := strategy.Switchover.HTTPPost
+		if httpPost == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("switchover").Child("httpPost"), ""))
+		} else if httpPost.Host != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("switchover").Child("httpPost").Child("host"), "the switchover is always sent to the pod IP"))
+		} else if httpPost.Port.Type == intstr.Int && (httpPost.Port.IntValue() <= 0 || httpPost.Port.IntValue() >= 65536) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("switchover").Child("httpPost").Child("port"), httpPost.Port.IntValue(), "must be between 1 and 65535"))
+		} else if httpPost.Port.Type == intstr.String && httpPost.Port.StrVal == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("switchover").Child("httpPost").Child("port"), ""))
+		}
 	}
 	return allErrs
 }
@@ -212,6 +271,44 @@ func validateSpecSelector(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path
 	return allErrs
 }
 
+func validateVolumeClaimUpdateStrategy(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	strategy := spec.VolumeClaimUpdateStrategy
+	if strategy.Type != appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType {
+		if strategy.DataMigration != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dataMigration"), "only allowed when type is RecreateWithDataMigration"))
+		}
+		return allErrs
+	}
+
+	migration := strategy.DataMigration
+	if migration == nil {
+		return append(allErrs, field.Required(fldPath.Child("dataMigration"), "required when type is RecreateWithDataMigration"))
+	}
+	if len(migration.Template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("dataMigration", "template", "spec", "containers"), ""))
+	}
+	reservedVolumes := sets.NewString()
+	for _, template := range spec.VolumeClaimTemplates {
+		reservedVolumes.Insert(appsv1beta1.DataMigrationSourceVolumePrefix+template.Name, appsv1beta1.DataMigrationTargetVolumePrefix+template.Name)
+	}
+	for i, volume := range migration.Template.Spec.Volumes {
+		if reservedVolumes.Has(volume.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dataMigration", "template", "spec", "volumes").Index(i).Child("name"), volume.Name, "reserved for the volumes to migrate"))
+		}
+	}
+	if migration.BackoffLimit != nil && *migration.BackoffLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dataMigration", "backoffLimit"), *migration.BackoffLimit, "must be greater than or equal to 0"))
+	}
+	switch migration.OldVolumeClaimPolicy {
+	case "", appsv1beta1.RetainOldVolumeClaimPolicyType, appsv1beta1.DeleteOldVolumeClaimPolicyType:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("dataMigration", "oldVolumeClaimPolicy"), migration.OldVolumeClaimPolicy,
+			[]string{string(appsv1beta1.RetainOldVolumeClaimPolicyType), string(appsv1beta1.DeleteOldVolumeClaimPolicyType)}))
+	}
+	return allErrs
+}
+
 func validateRestartPolicy(spec *appsv1beta1.StatefulSetSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -239,6 +336,7 @@ func validateStatefulSetSpec(spec *appsv1beta1.StatefulSetSpec, fldPath *field.P
 	allErrs = append(allErrs, validateScaleStrategy(spec, fldPath)...)
 	allErrs = append(allErrs, validateUpdateStrategyType(spec, fldPath)...)
 	allErrs = append(allErrs, ValidatePersistentVolumeClaimRetentionPolicy(spec.PersistentVolumeClaimRetentionPolicy, fldPath.Child("persistentVolumeClaimRetentionPolicy"))...)
+	allErrs = append(allErrs, validateVolumeClaimUpdateStrategy(spec, fldPath.Child("volumeClaimUpdateStrategy"))...)
 
 	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.Replicas), fldPath.Child("replicas"))...)
 
@@ -251,6 +349,9 @@ func validateStatefulSetSpec(spec *appsv1beta1.StatefulSetSpec, fldPath *field.P
 	// validate `spec.Template.Spec.ActiveDeadlineSeconds`
 	allErrs = append(allErrs, validateActiveDeadlineSeconds(spec, fldPath)...)
 
+	// validate `spec.Lifecycle`
+	allErrs = append(allErrs, webhookutil.ValidateLifecycle(spec.Lifecycle, fldPath.Child("lifecycle"))...)
+
 	return allErrs
 }
 
@@ -380,6 +481,8 @@ func ValidateVolumeClaimTemplateUpdate(c client.Client, sts, oldSts *appsv1beta1
 	if len(sts.Spec.VolumeClaimTemplates) != len(oldSts.Spec.VolumeClaimTemplates) {
 		return field.ErrorList{field.Invalid(field.NewPath("spec", "volumeClaimTemplates"), sts.Spec.VolumeClaimTemplates, "volumeClaimTemplate can not be added or deleted when OnRollingUpdate")}
 	}
+	// any change of templates is allowed for the claims to be recreated, except name
+	migrating := sts.Spec.VolumeClaimUpdateStrategy.Type == appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType
 
 	name2Template := make(map[string]*v1.PersistentVolumeClaim)
 	for i := range oldSts.Spec.VolumeClaimTemplates {
@@ -393,6 +496,9 @@ func ValidateVolumeClaimTemplateUpdate(c client.Client, sts, oldSts *appsv1beta1
 		if !exist {
 			return field.ErrorList{field.Forbidden(field.NewPath("spec", templateIdStr, "name"), "volumeClaimTemplate name can not be modified")}
 		}
+		if migrating {
+			continue
+		}
 
 		matched, resizeOnly := pvc.CompareWithCheckFn(oldTemplate, &template, isPVCResize)
 		if matched {
@@ -1193,3 +1193,66 @@ func TestValidateReserveOrdinals(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateVolumeClaimUpdateStrategy(t *testing.T) {
+	newMigration := func() *appsv1beta1.VolumeClaimDataMigration {
+		return &appsv1beta1.VolumeClaimDataMigration{
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "copy", Image: "busybox"}}}},
+		}
+	}
+	tests := []struct {
+		name           string
+		strategy       appsv1beta1.VolumeClaimUpdateStrategy
+		expectedErrors bool
+	}{
+		{
+			name:     "OnDelete",
+			strategy: appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.OnPVCDeleteVolumeClaimUpdateStrategyType},
+		},
+		{
+			name:           "DataMigrationWithoutRecreate",
+			strategy:       appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.OnPVCDeleteVolumeClaimUpdateStrategyType, DataMigration: newMigration()},
+			expectedErrors: true,
+		},
+		{
+			name:           "RecreateWithoutDataMigration",
+			strategy:       appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType},
+			expectedErrors: true,
+		},
+		{
+			name:     "ValidRecreate",
+			strategy: appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType, DataMigration: newMigration()},
+		},
+		{
+			name: "ReservedVolumeName",
+			strategy: func() appsv1beta1.VolumeClaimUpdateStrategy {
+				migration := newMigration()
+				migration.Template.Spec.Volumes = []v1.Volume{{Name: "source-data"}}
+				return appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType, DataMigration: migration}
+			}(),
+			expectedErrors: true,
+		},
+		{
+			name: "InvalidOldVolumeClaimPolicy",
+			strategy: func() appsv1beta1.VolumeClaimUpdateStrategy {
+				migration := newMigration()
+				migration.OldVolumeClaimPolicy = "Unknown"
+				return appsv1beta1.VolumeClaimUpdateStrategy{Type: appsv1beta1.RecreateWithDataMigrationVolumeClaimUpdateStrategyType, DataMigration: migration}
+			}(),
+			expectedErrors: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := &appsv1beta1.StatefulSetSpec{
+				VolumeClaimTemplates:      []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+				VolumeClaimUpdateStrategy: test.strategy,
+			}
+			errs := validateVolumeClaimUpdateStrategy(spec, field.NewPath("spec", "volumeClaimUpdateStrategy"))
+			if len(errs) > 0 != test.expectedErrors {
+				t.Errorf("validateVolumeClaimUpdateStrategy(%v) = %v, want %v", test.strategy, errs, test.expectedErrors)
+			}
+		})
+	}
+}
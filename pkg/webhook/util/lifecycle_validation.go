@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+// ValidateLifecycle validates the hooks of workload lifecycle.
+func ValidateLifecycle(lifecycle *appspub.Lifecycle, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lifecycle == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateLifecycleHook(lifecycle.PreDelete, fldPath.Child("preDelete"))...)
+	allErrs = append(allErrs, validateLifecycleHook(lifecycle.InPlaceUpdate, fldPath.Child("inPlaceUpdate"))...)
+	allErrs = append(allErrs, validateLifecycleHook(lifecycle.PreNormal, fldPath.Child("preNormal"))...)
+	return allErrs
+}
+
+func validateLifecycleHook(hook *appspub.LifecycleHook, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if hook == nil {
+		return allErrs
+	}
+	if hook.TimeoutSeconds != nil && *hook.TimeoutSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *hook.TimeoutSeconds, "must be positive"))
+	}
+	switch hook.OnTimeout {
+	case "", appspub.LifecycleHookTimeoutProceed, appspub.LifecycleHookTimeoutBlock, appspub.LifecycleHookTimeoutMarkFailed:
+		if hook.OnTimeout != "" && hook.TimeoutSeconds == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("timeoutSeconds"), "timeoutSeconds is required with onTimeout"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("onTimeout"), hook.OnTimeout, []string{string(appspub.LifecycleHookTimeoutProceed),
+			string(appspub.LifecycleHookTimeoutBlock), string(appspub.LifecycleHookTimeoutMarkFailed)}))
+	}
+	if hook.HTTPHandler != nil {
+		allErrs = append(allErrs, validateLifecycleHTTPHandler(hook.HTTPHandler, fldPath.Child("httpHandler"))...)
+	}
+	return allErrs
+}
+
+func validateLifecycleHTTPHandler(handler *appspub.LifecycleHTTPHandler, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if err := httphook.ValidateURL(handler.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), handler.URL, err.Error()))
+	}
+	if handler.TimeoutSeconds != nil && (*handler.TimeoutSeconds <= 0 || *handler.TimeoutSeconds > 30) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *handler.TimeoutSeconds, "must be in range (0, 30]"))
+	}
+	if handler.RetryLimit != nil && *handler.RetryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retryLimit"), *handler.RetryLimit, "must be non-negative"))
+	}
+	switch handler.FailurePolicy {
+	case "", appspub.LifecycleHookFailurePolicyProceed, appspub.LifecycleHookFailurePolicyBlock:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("failurePolicy"), handler.FailurePolicy,
+			[]string{string(appspub.LifecycleHookFailurePolicyProceed), string(appspub.LifecycleHookFailurePolicyBlock)}))
+	}
+	return allErrs
+}
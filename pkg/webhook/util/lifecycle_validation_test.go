@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+func TestValidateLifecycle(t *testing.T) {
+	cases := []struct {
+		name       string
+		handler    *appspub.LifecycleHTTPHandler
+		expectErrs int
+	}{
+		{
+			name:    "valid handler",
+			handler: &appspub.LifecycleHTTPHandler{URL: "https://hook.default.svc/pre-delete", TimeoutSeconds: ptr.To[int32](5), RetryLimit: ptr.To[int32](0), FailurePolicy: appspub.LifecycleHookFailurePolicyProceed},
+		},
+		{
+			name:       "relative url",
+			handler:    &appspub.LifecycleHTTPHandler{URL: "/pre-delete"},
+			expectErrs: 1,
+		},
+		{
+			name:       "unsupported scheme",
+			handler:    &appspub.LifecycleHTTPHandler{URL: "ftp://hook.default.svc"},
+			expectErrs: 1,
+		},
+		{
+			name:       "host not allowed",
+			handler:    &appspub.LifecycleHTTPHandler{URL: "http://169.254.169.254/latest/meta-data"},
+			expectErrs: 1,
+		},
+		{
+			name:       "invalid timeout and retry",
+			handler:    &appspub.LifecycleHTTPHandler{URL: "http://hook.default.svc", TimeoutSeconds: ptr.To[int32](60), RetryLimit: ptr.To[int32](-1)},
+			expectErrs: 2,
+		},
+		{
+			name:       "invalid failure policy",
+			handler:    &appspub.LifecycleHTTPHandler{URL: "http://hook.default.svc", FailurePolicy: "Ignore"},
+			expectErrs: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lifecycle := &appspub.Lifecycle{PreDelete: &appspub.LifecycleHook{HTTPHandler: tc.handler}}
+			errs := ValidateLifecycle(lifecycle, field.NewPath("spec", "lifecycle"))
+			if len(errs) != tc.expectErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrs, errs)
+			}
+		})
+	}
+}
+
+func TestValidateLifecycleTimeout(t *testing.T) {
+	cases := []struct {
+		name       string
+		hook       *appspub.LifecycleHook
+		expectErrs int
+	}{
+		{
+			name: "valid timeout",
+			hook: &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](300), OnTimeout: appspub.LifecycleHookTimeoutMarkFailed},
+		},
+		{
+			name: "timeout without action",
+			hook: &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](300)},
+		},
+		{
+			name:       "non-positive timeout",
+			hook:       &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](0), OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			expectErrs: 1,
+		},
+		{
+			name:       "action without timeout",
+			hook:       &appspub.LifecycleHook{OnTimeout: appspub.LifecycleHookTimeoutProceed},
+			expectErrs: 1,
+		},
+		{
+			name:       "invalid action",
+			hook:       &appspub.LifecycleHook{TimeoutSeconds: ptr.To[int32](300), OnTimeout: "Delete"},
+			expectErrs: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lifecycle := &appspub.Lifecycle{InPlaceUpdate: tc.hook}
+			errs := ValidateLifecycle(lifecycle, field.NewPath("spec", "lifecycle"))
+			if len(errs) != tc.expectErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrs, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/util/httphook"
+)
+
+// ValidateUpdatePriorityExtender validates the endpoint of update priority extender is allowed to call.
+// Other fields are validated by UpdatePriorityStrategy.FieldsValidation.
+func ValidateUpdatePriorityExtender(strategy *appspub.UpdatePriorityStrategy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy == nil || strategy.Extender == nil || strategy.Extender.HTTPHandler == nil {
+		return allErrs
+	}
+	urlPath := fldPath.Child("extender", "httpHandler", "url")
+	if err := httphook.ValidateURL(strategy.Extender.HTTPHandler.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(urlPath, strategy.Extender.HTTPHandler.URL, err.Error()))
+	}
+	return allErrs
+}
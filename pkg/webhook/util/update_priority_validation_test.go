@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+func TestValidateUpdatePriorityExtender(t *testing.T) {
+	cases := []struct {
+		name       string
+		strategy   *appspub.UpdatePriorityStrategy
+		expectErrs int
+	}{
+		{
+			name: "no strategy",
+		},
+		{
+			name:     "pod condition",
+			strategy: &appspub.UpdatePriorityStrategy{Extender: &appspub.UpdatePriorityExtender{PodConditionType: "Leader"}},
+		},
+		{
+			name: "allowed host",
+			strategy: &appspub.UpdatePriorityStrategy{Extender: &appspub.UpdatePriorityExtender{
+				HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: "http://ranker.default.svc/rank"},
+			}},
+		},
+		{
+			name: "host not allowed",
+			strategy: &appspub.UpdatePriorityStrategy{Extender: &appspub.UpdatePriorityExtender{
+				HTTPHandler: &appspub.UpdatePriorityHTTPHandler{URL: "http://10.0.0.1/rank"},
+			}},
+			expectErrs: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateUpdatePriorityExtender(tc.strategy, field.NewPath("priorityStrategy"))
+			if len(errs) != tc.expectErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrs, errs)
+			}
+		})
+	}
+}